@@ -0,0 +1,231 @@
+package dataframe
+
+import (
+	"fmt"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/pkg/errors"
+)
+
+// AggFunc is a function that aggregates many values into one.
+type AggFunc int
+
+const (
+	// AggSum is the sum of the values. Integers are summed as int64 or uint64 and floats as float64.
+	AggSum AggFunc = iota
+	// AggMean is the mean of the values as a float64.
+	AggMean
+	// AggMin is the smallest value.
+	AggMin
+	// AggMax is the largest value.
+	AggMax
+	// AggCount is the number of values that are not null as an int64.
+	AggCount
+	// AggFirst is the first value that is not null.
+	AggFirst
+	// AggLast is the last value that is not null.
+	AggLast
+)
+
+func (a AggFunc) String() string {
+	switch a {
+	case AggSum:
+		return "sum"
+	case AggMean:
+		return "mean"
+	case AggMin:
+		return "min"
+	case AggMax:
+		return "max"
+	case AggCount:
+		return "count"
+	case AggFirst:
+		return "first"
+	case AggLast:
+		return "last"
+	default:
+		return fmt.Sprintf("AggFunc(%d)", int(a))
+	}
+}
+
+// resultType returns the type of the aggregate of values of type dtype.
+func (a AggFunc) resultType(dtype arrow.DataType) (arrow.DataType, error) {
+	switch a {
+	case AggSum:
+		switch {
+		case isSignedInteger(dtype):
+			return arrow.PrimitiveTypes.Int64, nil
+		case isUnsignedInteger(dtype):
+			return arrow.PrimitiveTypes.Uint64, nil
+		case isFloat(dtype):
+			return arrow.PrimitiveTypes.Float64, nil
+		}
+	case AggMean:
+		if isNumeric(dtype) {
+			return arrow.PrimitiveTypes.Float64, nil
+		}
+	case AggMin, AggMax:
+		switch {
+		case isNumeric(dtype), isDate(dtype), dtype.ID() == arrow.STRING, dtype.ID() == arrow.BOOL:
+			return dtype, nil
+		}
+	case AggCount:
+		return arrow.PrimitiveTypes.Int64, nil
+	case AggFirst, AggLast:
+		return dtype, nil
+	default:
+		return nil, errors.Errorf("invalid aggregate function %v", a)
+	}
+	return nil, errors.Errorf("cannot %v a %v", a, dtype)
+}
+
+// aggregator accumulates values to compute an aggregate.
+type aggregator interface {
+	// add adds v to the aggregate. nil values are ignored.
+	add(v interface{}) error
+	// value returns the aggregate, or nil if there wasn't anything to aggregate.
+	value() interface{}
+}
+
+// newAggregator creates an aggregator for values of type dtype.
+// The type must have been checked with resultType.
+func (a AggFunc) newAggregator(dtype arrow.DataType) aggregator {
+	switch a {
+	case AggSum:
+		result, _ := a.resultType(dtype)
+		return &sumAggregator{dtype: result}
+	case AggMean:
+		return &meanAggregator{}
+	case AggMin:
+		return &extremeAggregator{dtype: dtype, want: -1}
+	case AggMax:
+		return &extremeAggregator{dtype: dtype, want: 1}
+	case AggCount:
+		return &countAggregator{}
+	case AggFirst:
+		return &firstAggregator{}
+	case AggLast:
+		return &lastAggregator{}
+	}
+	panic(errors.Errorf("bullseye/agg: invalid aggregate function %v", a))
+}
+
+type sumAggregator struct {
+	dtype arrow.DataType
+	sum   interface{}
+}
+
+func (s *sumAggregator) add(v interface{}) error {
+	if v == nil {
+		return nil
+	}
+	cv, err := castNumericValue(v, s.dtype)
+	if err != nil {
+		return err
+	}
+	switch t := cv.(type) {
+	case int64:
+		prev, _ := s.sum.(int64)
+		s.sum = prev + t
+	case uint64:
+		prev, _ := s.sum.(uint64)
+		s.sum = prev + t
+	case float64:
+		prev, _ := s.sum.(float64)
+		s.sum = prev + t
+	}
+	return nil
+}
+
+func (s *sumAggregator) value() interface{} { return s.sum }
+
+type meanAggregator struct {
+	sum float64
+	n   int64
+}
+
+func (m *meanAggregator) add(v interface{}) error {
+	if v == nil {
+		return nil
+	}
+	f, err := castNumericValue(v, arrow.PrimitiveTypes.Float64)
+	if err != nil {
+		return err
+	}
+	m.sum += f.(float64)
+	m.n++
+	return nil
+}
+
+func (m *meanAggregator) value() interface{} {
+	if m.n == 0 {
+		return nil
+	}
+	return m.sum / float64(m.n)
+}
+
+// extremeAggregator keeps the smallest value when want is -1 and the largest when want is 1.
+type extremeAggregator struct {
+	dtype arrow.DataType
+	want  int
+	best  interface{}
+}
+
+func (e *extremeAggregator) add(v interface{}) error {
+	if v == nil {
+		return nil
+	}
+	if e.best == nil {
+		e.best = v
+		return nil
+	}
+	cmp, err := compareValues(e.dtype, v, e.best)
+	if err != nil {
+		return err
+	}
+	if cmp == e.want {
+		e.best = v
+	}
+	return nil
+}
+
+func (e *extremeAggregator) value() interface{} { return e.best }
+
+type countAggregator struct {
+	n int64
+}
+
+func (c *countAggregator) add(v interface{}) error {
+	if v != nil {
+		c.n++
+	}
+	return nil
+}
+
+func (c *countAggregator) value() interface{} { return c.n }
+
+type firstAggregator struct {
+	first interface{}
+}
+
+func (f *firstAggregator) add(v interface{}) error {
+	if f.first == nil {
+		f.first = v
+	}
+	return nil
+}
+
+func (f *firstAggregator) value() interface{} { return f.first }
+
+type lastAggregator struct {
+	last interface{}
+}
+
+func (l *lastAggregator) add(v interface{}) error {
+	if v != nil {
+		l.last = v
+	}
+	return nil
+}
+
+func (l *lastAggregator) value() interface{} { return l.last }
@@ -0,0 +1,601 @@
+package dataframe
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"time"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/memory"
+	"github.com/go-bullseye/bullseye/internal/temporal"
+	"github.com/pkg/errors"
+)
+
+// OverflowMode is how Cast handles numbers that do not fit in the new type.
+type OverflowMode int
+
+const (
+	// OverflowChecked returns an error when a number does not fit in the new type. This is the default.
+	OverflowChecked OverflowMode = iota
+	// OverflowWrap converts numbers like Go does, i.e. int16(300) becomes int8(44). Floats are
+	// truncated toward zero and then wrapped like integers, so 300.5 becomes int8(44), but NaN
+	// and infinities cannot be converted to integers.
+	OverflowWrap
+)
+
+func (o OverflowMode) String() string {
+	switch o {
+	case OverflowChecked:
+		return "checked"
+	case OverflowWrap:
+		return "wrap"
+	default:
+		return fmt.Sprintf("OverflowMode(%d)", int(o))
+	}
+}
+
+const (
+	// DefaultDateLayout is the layout used to parse and format dates when WithLayout is not provided.
+	DefaultDateLayout = "2006-01-02"
+	// DefaultTimestampLayout is the layout used to parse and format timestamps when WithLayout is not provided.
+	DefaultTimestampLayout = time.RFC3339Nano
+)
+
+// castConfig are the config params for Cast.
+type castConfig struct {
+	overflow OverflowMode
+	layout   string
+}
+
+// newCastConfig creates a new config using options.
+func newCastConfig(opts ...Option) (*castConfig, error) {
+	cfg := &castConfig{}
+	for _, opt := range opts {
+		if err := opt(cfg); err != nil {
+			return cfg, err
+		}
+	}
+	return cfg, nil
+}
+
+// WithOverflow configures Cast to use the provided OverflowMode for numbers.
+func WithOverflow(mode OverflowMode) Option {
+	return func(p interface{}) error {
+		o, ok := p.(*castConfig)
+		if !ok {
+			return errors.Errorf("cannot apply WithOverflow to: %T", p)
+		}
+		switch mode {
+		case OverflowChecked, OverflowWrap:
+		default:
+			return errors.Errorf("invalid overflow mode: %v", mode)
+		}
+		o.overflow = mode
+		return nil
+	}
+}
+
+// WithLayout configures Cast to use the provided time.Parse layout when converting
+// strings to and from Date32, Date64 and Timestamp columns.
+func WithLayout(layout string) Option {
+	return func(p interface{}) error {
+		o, ok := p.(*castConfig)
+		if !ok {
+			return errors.Errorf("cannot apply WithLayout to: %T", p)
+		}
+		o.layout = layout
+		return nil
+	}
+}
+
+// Cast returns a DataFrame with the columns in types converted to the new types. The supported conversions are:
+//   - numeric to numeric, checking for overflow unless WithOverflow(OverflowWrap) is provided.
+//     Floats are truncated toward zero when converted to integers.
+//   - numeric or bool to and from string.
+//   - bool to and from numeric, where anything other than zero is true.
+//   - Date32, Date64 and Timestamp to and from each other and string, using WithLayout
+//     or DefaultDateLayout and DefaultTimestampLayout. Times are in UTC, and times the new
+//     type can't hold are an error, e.g. nanosecond timestamps hold the years 1678 to 2262.
+//
+// Columns that already have the new type, and the columns that are not cast, are not copied.
+// The error for a value that cannot be converted includes its row and value.
+func (m *Mutator) Cast(types map[string]arrow.DataType, opts ...Option) MutationFunc {
+	cfg, err := newCastConfig(opts...)
+	return func(df *DataFrame) (*DataFrame, error) {
+		if err != nil {
+			return nil, err
+		}
+
+		for name, dtype := range types {
+			col := df.Column(name)
+			if col == nil {
+				return nil, errors.Errorf("bullseye/mutations: column %s does not exist", name)
+			}
+			if dtype == nil || !canCast(col.DataType(), dtype) {
+				return nil, errors.Errorf("bullseye/mutations: cannot cast column %s from %v to %v", name, col.DataType(), dtype)
+			}
+		}
+
+		dfCols := df.Columns()
+		cols := make([]array.Column, 0, len(dfCols))
+		defer func() {
+			for i := range cols {
+				cols[i].Release()
+			}
+		}()
+
+		for i := range dfCols {
+			col := &dfCols[i]
+			dtype, ok := types[col.Name()]
+			if !ok || reflect.DeepEqual(col.DataType(), dtype) {
+				col.Retain()
+				cols = append(cols, *col)
+				continue
+			}
+
+			newCol, err := castColumn(m.mem, col, dtype, cfg)
+			if err != nil {
+				return nil, err
+			}
+			cols = append(cols, *newCol)
+		}
+
+		return NewDataFrameFromShape(m.mem, cols, df.NumRows())
+	}
+}
+
+// castColumn returns a copy of col with every value converted to dtype. The new column must be released.
+func castColumn(mem memory.Allocator, col *array.Column, dtype arrow.DataType, cfg *castConfig) (*array.Column, error) {
+	from := col.DataType()
+	chunks := make([]array.Interface, 0, len(col.Data().Chunks()))
+	defer func() {
+		for _, chunk := range chunks {
+			chunk.Release()
+		}
+	}()
+
+	row := 0
+	for _, chunk := range col.Data().Chunks() {
+		values := arrayValues(chunk)
+		for i, v := range values {
+			if v == nil {
+				continue
+			}
+			nv, err := cfg.castValue(v, from, dtype)
+			if err != nil {
+				return nil, errors.Wrapf(err, "bullseye/mutations: cannot cast column %s to %v at row %d with value %v", col.Name(), dtype, row+i, v)
+			}
+			values[i] = nv
+		}
+		row += len(values)
+
+		arr, err := newArrayFromValues(mem, dtype, values)
+		if err != nil {
+			return nil, err
+		}
+		chunks = append(chunks, arr)
+	}
+
+	field := col.Field()
+	field.Type = dtype
+	return newColumnFromChunks(field, chunks), nil
+}
+
+// isTemporal returns true if dtype is one of the types that can be converted to a time.Time.
+func isTemporal(dtype arrow.DataType) bool {
+	return isDate(dtype) || dtype.ID() == arrow.TIMESTAMP
+}
+
+// canCast returns true if Cast supports converting from to to.
+func canCast(from, to arrow.DataType) bool {
+	if reflect.DeepEqual(from, to) {
+		return true
+	}
+
+	fromNumeric := isNumeric(from) || from.ID() == arrow.BOOL
+	toNumeric := isNumeric(to) || to.ID() == arrow.BOOL
+	switch {
+	case fromNumeric && toNumeric:
+		return true
+	case from.ID() == arrow.STRING:
+		return toNumeric || isTemporal(to)
+	case to.ID() == arrow.STRING:
+		return fromNumeric || isTemporal(from)
+	case isTemporal(from) && isTemporal(to):
+		return true
+	}
+	return false
+}
+
+// castValue converts the non-nil value v of type from to the Go type of to.
+func (c *castConfig) castValue(v interface{}, from, to arrow.DataType) (interface{}, error) {
+	switch {
+	case to.ID() == arrow.STRING:
+		return c.formatValue(v, from)
+	case from.ID() == arrow.STRING:
+		return c.parseValue(v.(string), to)
+	case from.ID() == arrow.BOOL:
+		if v.(bool) {
+			return castInt64(1, to)
+		}
+		return castInt64(0, to)
+	case to.ID() == arrow.BOOL:
+		f, err := castNumericValue(v, arrow.PrimitiveTypes.Float64)
+		if err != nil {
+			return nil, err
+		}
+		return f.(float64) != 0, nil
+	case isNumeric(from):
+		if c.overflow == OverflowChecked {
+			if err := checkNumericRange(v, to); err != nil {
+				return nil, err
+			}
+		} else if isFloat(from) && !isFloat(to) {
+			return wrapFloat(v, to)
+		}
+		return castNumericValue(v, to)
+	case isTemporal(from):
+		return fromTime(toTime(v, from), to)
+	}
+	return nil, errors.Errorf("cannot cast %v to %v", from, to)
+}
+
+// layoutFor returns the layout used to parse and format values of dtype.
+func (c *castConfig) layoutFor(dtype arrow.DataType) string {
+	switch {
+	case c.layout != "":
+		return c.layout
+	case isDate(dtype):
+		return DefaultDateLayout
+	default:
+		return DefaultTimestampLayout
+	}
+}
+
+// formatValue converts the non-nil value v of type from to a string.
+func (c *castConfig) formatValue(v interface{}, from arrow.DataType) (string, error) {
+	switch t := v.(type) {
+	case bool:
+		return strconv.FormatBool(t), nil
+	case float32:
+		return strconv.FormatFloat(float64(t), 'g', -1, 32), nil
+	case float64:
+		return strconv.FormatFloat(t, 'g', -1, 64), nil
+	}
+	if isTemporal(from) {
+		return toTime(v, from).Format(c.layoutFor(from)), nil
+	}
+	return fmt.Sprint(v), nil
+}
+
+// parseValue converts s to the Go type of to.
+func (c *castConfig) parseValue(s string, to arrow.DataType) (interface{}, error) {
+	switch {
+	case to.ID() == arrow.BOOL:
+		return strconv.ParseBool(s)
+	case isSignedInteger(to):
+		bits := to.(arrow.FixedWidthDataType).BitWidth()
+		i, err := strconv.ParseInt(s, 10, bits)
+		if err != nil {
+			return nil, err
+		}
+		return castInt64(i, to)
+	case isUnsignedInteger(to):
+		bits := to.(arrow.FixedWidthDataType).BitWidth()
+		u, err := strconv.ParseUint(s, 10, bits)
+		if err != nil {
+			return nil, err
+		}
+		return castUint64(u, to)
+	case isFloat(to):
+		bits := to.(arrow.FixedWidthDataType).BitWidth()
+		f, err := strconv.ParseFloat(s, bits)
+		if err != nil {
+			return nil, err
+		}
+		return castFloat64(f, to)
+	case isTemporal(to):
+		t, err := time.Parse(c.layoutFor(to), s)
+		if err != nil {
+			return nil, err
+		}
+		return fromTime(t, to)
+	}
+	return nil, errors.Errorf("cannot parse %v", to)
+}
+
+// toTime converts a Date32, Date64 or Timestamp value to a time in UTC.
+func toTime(v interface{}, dtype arrow.DataType) time.Time {
+	switch t := v.(type) {
+	case arrow.Date32:
+		return time.Unix(int64(t)*temporal.SecondsPerDay, 0).UTC()
+	case arrow.Date64:
+		return temporal.Time(int64(t), arrow.Millisecond)
+	case arrow.Timestamp:
+		return temporal.Time(int64(t), dtype.(*arrow.TimestampType).Unit)
+	}
+	panic(errors.Errorf("bullseye/cast: %T is not a time", v))
+}
+
+// fromTime converts a time to the Go type of dtype. Dates are truncated to midnight.
+// Times that dtype can't hold are an error.
+func fromTime(t time.Time, dtype arrow.DataType) (interface{}, error) {
+	switch dt := dtype.(type) {
+	case *arrow.Date32Type:
+		if v, ok := temporal.Date32(t); ok {
+			return v, nil
+		}
+	case *arrow.Date64Type:
+		if v, ok := temporal.Date64(t); ok {
+			return v, nil
+		}
+	case *arrow.TimestampType:
+		if v, ok := temporal.FromTime(t, dt.Unit); ok {
+			return arrow.Timestamp(v), nil
+		}
+	default:
+		return nil, errors.Errorf("cannot convert a time to %v", dtype)
+	}
+	return nil, errors.Errorf("time %v is out of the range of %v", t, dtype)
+}
+
+// checkNumericRange returns an error if the numeric value v does not fit in dtype.
+func checkNumericRange(v interface{}, dtype arrow.DataType) error {
+	var (
+		i        int64
+		u        uint64
+		f        float64
+		signed   bool
+		unsigned bool
+	)
+	switch t := v.(type) {
+	case int8:
+		i, signed = int64(t), true
+	case int16:
+		i, signed = int64(t), true
+	case int32:
+		i, signed = int64(t), true
+	case int64:
+		i, signed = t, true
+	case uint8:
+		u, unsigned = uint64(t), true
+	case uint16:
+		u, unsigned = uint64(t), true
+	case uint32:
+		u, unsigned = uint64(t), true
+	case uint64:
+		u, unsigned = t, true
+	case float32:
+		f = float64(t)
+	case float64:
+		f = t
+	default:
+		return errors.Errorf("%T is not a number", v)
+	}
+
+	// Floats are truncated toward zero when converted to integers, so -128.5 fits in int8.
+	f = math.Trunc(f)
+	overflow := false
+	bits := uint(dtype.(arrow.FixedWidthDataType).BitWidth())
+	switch {
+	case isFloat(dtype):
+		overflow = !signed && !unsigned && bits == 32 &&
+			!math.IsInf(f, 0) && !math.IsNaN(f) && math.Abs(f) > math.MaxFloat32
+	case isSignedInteger(dtype):
+		max := int64(1)<<(bits-1) - 1
+		min := -max - 1
+		switch {
+		case signed:
+			overflow = i < min || i > max
+		case unsigned:
+			overflow = u > uint64(max)
+		default:
+			overflow = math.IsNaN(f) || f < float64(min) || f >= -float64(min)
+		}
+	case isUnsignedInteger(dtype):
+		max := uint64(1)<<(bits-1) - 1 + uint64(1)<<(bits-1)
+		switch {
+		case signed:
+			overflow = i < 0 || uint64(i) > max
+		case unsigned:
+			overflow = u > max
+		default:
+			overflow = math.IsNaN(f) || f < 0 || f >= float64(max)+1
+		}
+	}
+
+	if overflow {
+		return errors.Errorf("overflows %v", dtype)
+	}
+	return nil
+}
+
+// wrapFloat converts the float v to the integer type dtype like OverflowWrap converts integers,
+// by truncating it toward zero and keeping the low bits of the result in two's complement.
+func wrapFloat(v interface{}, dtype arrow.DataType) (interface{}, error) {
+	f, err := castNumericValue(v, arrow.PrimitiveTypes.Float64)
+	if err != nil {
+		return nil, err
+	}
+	t := math.Trunc(f.(float64))
+	if math.IsNaN(t) || math.IsInf(t, 0) {
+		return nil, errors.Errorf("cannot wrap %v to %v", t, dtype)
+	}
+	if math.Abs(t) < 1<<63 {
+		return castInt64(int64(t), dtype)
+	}
+	// The remainder of a float is exact, and below 2^64 it fits in a uint64.
+	low := uint64(math.Mod(math.Abs(t), 1<<64))
+	if t < 0 {
+		low = -low
+	}
+	return castUint64(low, dtype)
+}
+
+// commonNumericType returns the smallest numeric type that can hold the values of both a and b.
+// Mixing int64 or uint64 with a type of the other signedness, or integers wider than
+// 16 bits with float32, results in float64. The second return value is false if a
+// or b is not a numeric type.
+func commonNumericType(a, b arrow.DataType) (arrow.DataType, bool) {
+	if !isNumeric(a) || !isNumeric(b) {
+		return nil, false
+	}
+	if a.ID() == b.ID() {
+		return a, true
+	}
+
+	aw := a.(arrow.FixedWidthDataType).BitWidth()
+	bw := b.(arrow.FixedWidthDataType).BitWidth()
+
+	switch {
+	case isFloat(a) || isFloat(b):
+		// float32 can only hold every value of the integers up to 16 bits.
+		if aw <= 32 && bw <= 32 && (isFloat(a) || aw <= 16) && (isFloat(b) || bw <= 16) {
+			return arrow.PrimitiveTypes.Float32, true
+		}
+		return arrow.PrimitiveTypes.Float64, true
+	case isSignedInteger(a) == isSignedInteger(b):
+		if aw >= bw {
+			return a, true
+		}
+		return b, true
+	}
+
+	// One is signed and the other is unsigned so the signed type
+	// must be wider than the unsigned type.
+	signedWidth, unsignedWidth := aw, bw
+	if isUnsignedInteger(a) {
+		signedWidth, unsignedWidth = bw, aw
+	}
+	if unsignedWidth*2 > signedWidth {
+		signedWidth = unsignedWidth * 2
+	}
+	switch signedWidth {
+	case 16:
+		return arrow.PrimitiveTypes.Int16, true
+	case 32:
+		return arrow.PrimitiveTypes.Int32, true
+	case 64:
+		return arrow.PrimitiveTypes.Int64, true
+	}
+	return arrow.PrimitiveTypes.Float64, true
+}
+
+// castNumericValue converts a numeric value to the Go type used by dtype.
+// nil is returned as is.
+func castNumericValue(v interface{}, dtype arrow.DataType) (interface{}, error) {
+	switch t := v.(type) {
+	case nil:
+		return nil, nil
+	case int:
+		return castInt64(int64(t), dtype)
+	case int8:
+		return castInt64(int64(t), dtype)
+	case int16:
+		return castInt64(int64(t), dtype)
+	case int32:
+		return castInt64(int64(t), dtype)
+	case int64:
+		return castInt64(t, dtype)
+	case uint:
+		return castUint64(uint64(t), dtype)
+	case uint8:
+		return castUint64(uint64(t), dtype)
+	case uint16:
+		return castUint64(uint64(t), dtype)
+	case uint32:
+		return castUint64(uint64(t), dtype)
+	case uint64:
+		return castUint64(t, dtype)
+	case float32:
+		return castFloat64(float64(t), dtype)
+	case float64:
+		return castFloat64(t, dtype)
+	default:
+		return nil, errors.Errorf("bullseye/cast: cannot cast %T to %v", v, dtype)
+	}
+}
+
+func castInt64(v int64, dtype arrow.DataType) (interface{}, error) {
+	switch dtype.ID() {
+	case arrow.INT8:
+		return int8(v), nil
+	case arrow.INT16:
+		return int16(v), nil
+	case arrow.INT32:
+		return int32(v), nil
+	case arrow.INT64:
+		return v, nil
+	case arrow.UINT8:
+		return uint8(v), nil
+	case arrow.UINT16:
+		return uint16(v), nil
+	case arrow.UINT32:
+		return uint32(v), nil
+	case arrow.UINT64:
+		return uint64(v), nil
+	case arrow.FLOAT32:
+		return float32(v), nil
+	case arrow.FLOAT64:
+		return float64(v), nil
+	default:
+		return nil, errors.Errorf("bullseye/cast: cannot cast %T to %v", v, dtype)
+	}
+}
+
+func castUint64(v uint64, dtype arrow.DataType) (interface{}, error) {
+	switch dtype.ID() {
+	case arrow.INT8:
+		return int8(v), nil
+	case arrow.INT16:
+		return int16(v), nil
+	case arrow.INT32:
+		return int32(v), nil
+	case arrow.INT64:
+		return int64(v), nil
+	case arrow.UINT8:
+		return uint8(v), nil
+	case arrow.UINT16:
+		return uint16(v), nil
+	case arrow.UINT32:
+		return uint32(v), nil
+	case arrow.UINT64:
+		return v, nil
+	case arrow.FLOAT32:
+		return float32(v), nil
+	case arrow.FLOAT64:
+		return float64(v), nil
+	default:
+		return nil, errors.Errorf("bullseye/cast: cannot cast %T to %v", v, dtype)
+	}
+}
+
+func castFloat64(v float64, dtype arrow.DataType) (interface{}, error) {
+	switch dtype.ID() {
+	case arrow.INT8:
+		return int8(v), nil
+	case arrow.INT16:
+		return int16(v), nil
+	case arrow.INT32:
+		return int32(v), nil
+	case arrow.INT64:
+		return int64(v), nil
+	case arrow.UINT8:
+		return uint8(v), nil
+	case arrow.UINT16:
+		return uint16(v), nil
+	case arrow.UINT32:
+		return uint32(v), nil
+	case arrow.UINT64:
+		return uint64(v), nil
+	case arrow.FLOAT32:
+		return float32(v), nil
+	case arrow.FLOAT64:
+		return v, nil
+	default:
+		return nil, errors.Errorf("bullseye/cast: cannot cast %T to %v", v, dtype)
+	}
+}
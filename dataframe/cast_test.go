@@ -0,0 +1,332 @@
+package dataframe
+
+import (
+	"math"
+	"testing"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/memory"
+)
+
+func TestCast(t *testing.T) {
+	pool := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer pool.AssertSize(t, 0)
+
+	df, err := NewDataFrameFromMem(pool, Dict{
+		"A": []interface{}{int64(1), nil, int64(300)},
+		"B": []string{"2019-06-01", "1969-12-31", "2020-02-29"},
+		"C": []float64{1.5, 0, -2.5},
+		"D": []string{"1.5", "2", "-3"},
+		"E": []bool{true, false, true},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer df.Release()
+
+	testCases := []struct {
+		name  string
+		types map[string]arrow.DataType
+		opts  []Option
+		want  string
+	}{
+		{
+			name: "numeric",
+			types: map[string]arrow.DataType{
+				"A": arrow.PrimitiveTypes.Float64,
+				"C": arrow.PrimitiveTypes.Int32,
+				"E": arrow.PrimitiveTypes.Uint8,
+			},
+			want: `rec[0]["A"]: [1 (null) 300]
+rec[0]["B"]: ["2019-06-01" "1969-12-31" "2020-02-29"]
+rec[0]["C"]: [1 0 -2]
+rec[0]["D"]: ["1.5" "2" "-3"]
+rec[0]["E"]: [1 0 1]
+`,
+		},
+		{
+			name: "wrap",
+			types: map[string]arrow.DataType{
+				"A": arrow.PrimitiveTypes.Int8,
+			},
+			opts: []Option{WithOverflow(OverflowWrap)},
+			want: `rec[0]["A"]: [1 (null) 44]
+rec[0]["B"]: ["2019-06-01" "1969-12-31" "2020-02-29"]
+rec[0]["C"]: [1.5 0 -2.5]
+rec[0]["D"]: ["1.5" "2" "-3"]
+rec[0]["E"]: [true false true]
+`,
+		},
+		{
+			name: "strings",
+			types: map[string]arrow.DataType{
+				"A": arrow.BinaryTypes.String,
+				"B": arrow.FixedWidthTypes.Date32,
+				"C": arrow.BinaryTypes.String,
+				"D": arrow.PrimitiveTypes.Float32,
+				"E": arrow.BinaryTypes.String,
+			},
+			want: `rec[0]["A"]: ["1" (null) "300"]
+rec[0]["B"]: [18048 -1 18321]
+rec[0]["C"]: ["1.5" "0" "-2.5"]
+rec[0]["D"]: [1.5 2 -3]
+rec[0]["E"]: ["true" "false" "true"]
+`,
+		},
+		{
+			name: "numeric to bool",
+			types: map[string]arrow.DataType{
+				"C": arrow.FixedWidthTypes.Boolean,
+			},
+			want: `rec[0]["A"]: [1 (null) 300]
+rec[0]["B"]: ["2019-06-01" "1969-12-31" "2020-02-29"]
+rec[0]["C"]: [true false true]
+rec[0]["D"]: ["1.5" "2" "-3"]
+rec[0]["E"]: [true false true]
+`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			castDf, err := df.Cast(tc.types, tc.opts...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer castDf.Release()
+
+			for name, dtype := range tc.types {
+				if got := castDf.Column(name).DataType(); got != dtype {
+					t.Fatalf("column %s: got=%v, want=%v", name, got, dtype)
+				}
+			}
+			if got := castDf.Display(-1); got != tc.want {
+				t.Fatalf("\ngot=\n%v\nwant=\n%v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestCastDates(t *testing.T) {
+	pool := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer pool.AssertSize(t, 0)
+
+	df, err := NewDataFrameFromMem(pool, Dict{
+		"A": []string{"01/06/2019 13:45", "31/12/1969 23:59"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer df.Release()
+
+	timestampType := &arrow.TimestampType{Unit: arrow.Second}
+	tsDf, err := df.Cast(map[string]arrow.DataType{"A": timestampType}, WithLayout("02/01/2006 15:04"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer tsDf.Release()
+
+	date64Df, err := tsDf.Cast(map[string]arrow.DataType{"A": arrow.FixedWidthTypes.Date64})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer date64Df.Release()
+
+	date32Df, err := date64Df.Cast(map[string]arrow.DataType{"A": arrow.FixedWidthTypes.Date32})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer date32Df.Release()
+
+	stringDf, err := date32Df.Cast(map[string]arrow.DataType{"A": arrow.BinaryTypes.String})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer stringDf.Release()
+
+	got := tsDf.Display(-1) + date64Df.Display(-1) + date32Df.Display(-1) + stringDf.Display(-1)
+	want := `rec[0]["A"]: [1559396700 -60]
+rec[0]["A"]: [1559347200000 -86400000]
+rec[0]["A"]: [18048 -1]
+rec[0]["A"]: ["2019-06-01" "1969-12-31"]
+`
+	if got != want {
+		t.Fatalf("\ngot=\n%v\nwant=\n%v", got, want)
+	}
+}
+
+func TestCastFloatToInt(t *testing.T) {
+	pool := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer pool.AssertSize(t, 0)
+
+	testCases := []struct {
+		name   string
+		values []float64
+		dtype  arrow.DataType
+		opts   []Option
+		want   string
+	}{
+		{
+			// The floats are truncated before they are checked, so they fit.
+			name:   "checked",
+			values: []float64{-128.5, 127.9, -0.5},
+			dtype:  arrow.PrimitiveTypes.Int8,
+			want:   `rec[0]["A"]: [-128 127 0]` + "\n",
+		},
+		{
+			name:   "checked unsigned",
+			values: []float64{-0.5, 255.9},
+			dtype:  arrow.PrimitiveTypes.Uint8,
+			want:   `rec[0]["A"]: [0 255]` + "\n",
+		},
+		{
+			name:   "wrap",
+			values: []float64{300.5, -129.5, 1e19},
+			dtype:  arrow.PrimitiveTypes.Int8,
+			opts:   []Option{WithOverflow(OverflowWrap)},
+			want:   `rec[0]["A"]: [44 127 0]` + "\n",
+		},
+		{
+			name:   "wrap 64 bits",
+			values: []float64{1e19, -1e19, -1.5},
+			dtype:  arrow.PrimitiveTypes.Uint64,
+			opts:   []Option{WithOverflow(OverflowWrap)},
+			want:   `rec[0]["A"]: [10000000000000000000 8446744073709551616 18446744073709551615]` + "\n",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			df, err := NewDataFrameFromMem(pool, Dict{"A": tc.values})
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer df.Release()
+
+			castDf, err := df.Cast(map[string]arrow.DataType{"A": tc.dtype}, tc.opts...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer castDf.Release()
+
+			if got := castDf.Display(-1); got != tc.want {
+				t.Fatalf("\ngot=\n%v\nwant=\n%v", got, tc.want)
+			}
+		})
+	}
+
+	df, err := NewDataFrameFromMem(pool, Dict{"A": []float64{1, math.Inf(1)}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer df.Release()
+	_, err = df.Cast(map[string]arrow.DataType{"A": arrow.PrimitiveTypes.Int64}, WithOverflow(OverflowWrap))
+	want := "bullseye/mutations: cannot cast column A to int64 at row 1 with value +Inf: cannot wrap +Inf to int64"
+	if err == nil || err.Error() != want {
+		t.Fatalf("got error %v, want %q", err, want)
+	}
+}
+
+func TestCastDatesOutOfNanoseconds(t *testing.T) {
+	pool := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer pool.AssertSize(t, 0)
+
+	// The times are outside the years 1678 to 2262 of nanosecond timestamps.
+	df, err := NewDataFrameFromMem(pool, Dict{
+		"A": []string{"3000-01-01T00:00:00Z", "1000-06-15T12:30:00.5Z"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer df.Release()
+
+	// Each type is cast from the one before and back to strings.
+	var got string
+	from := df
+	for _, dtype := range []arrow.DataType{
+		&arrow.TimestampType{Unit: arrow.Millisecond},
+		&arrow.TimestampType{Unit: arrow.Microsecond},
+		arrow.FixedWidthTypes.Date64,
+	} {
+		castDf, err := from.Cast(map[string]arrow.DataType{"A": dtype})
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer castDf.Release()
+		backDf, err := castDf.Cast(map[string]arrow.DataType{"A": arrow.BinaryTypes.String})
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer backDf.Release()
+		got += castDf.Display(-1) + backDf.Display(-1)
+		from = castDf
+	}
+	want := `rec[0]["A"]: [32503680000000 -30595922999500]
+rec[0]["A"]: ["3000-01-01T00:00:00Z" "1000-06-15T12:30:00.5Z"]
+rec[0]["A"]: [32503680000000000 -30595922999500000]
+rec[0]["A"]: ["3000-01-01T00:00:00Z" "1000-06-15T12:30:00.5Z"]
+rec[0]["A"]: [32503680000000 -30595968000000]
+rec[0]["A"]: ["3000-01-01" "1000-06-15"]
+`
+	if got != want {
+		t.Fatalf("\ngot=\n%v\nwant=\n%v", got, want)
+	}
+
+	_, err = df.Cast(map[string]arrow.DataType{"A": &arrow.TimestampType{Unit: arrow.Nanosecond}})
+	want = "bullseye/mutations: cannot cast column A to timestamp[ns] at row 0 with value 3000-01-01T00:00:00Z: " +
+		"time 3000-01-01 00:00:00 +0000 UTC is out of the range of timestamp[ns]"
+	if err == nil || err.Error() != want {
+		t.Fatalf("got error %v, want %q", err, want)
+	}
+}
+
+func TestCastErrors(t *testing.T) {
+	pool := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer pool.AssertSize(t, 0)
+
+	df, err := NewDataFrameFromMem(pool, Dict{
+		"A": []int64{1, 2, 300},
+		"B": []string{"1", "x", "3"},
+		"C": []float64{1, -1, 2},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer df.Release()
+
+	testCases := []struct {
+		types map[string]arrow.DataType
+		want  string
+	}{
+		{
+			types: map[string]arrow.DataType{"A": arrow.PrimitiveTypes.Int8},
+			want:  "bullseye/mutations: cannot cast column A to int8 at row 2 with value 300: overflows int8",
+		},
+		{
+			types: map[string]arrow.DataType{"C": arrow.PrimitiveTypes.Uint32},
+			want:  "bullseye/mutations: cannot cast column C to uint32 at row 1 with value -1: overflows uint32",
+		},
+		{
+			types: map[string]arrow.DataType{"B": arrow.PrimitiveTypes.Int32},
+			want:  `bullseye/mutations: cannot cast column B to int32 at row 1 with value x: strconv.ParseInt: parsing "x": invalid syntax`,
+		},
+		{
+			types: map[string]arrow.DataType{"A": arrow.FixedWidthTypes.Date32},
+			want:  "bullseye/mutations: cannot cast column A from int64 to date32",
+		},
+		{
+			types: map[string]arrow.DataType{"Z": arrow.PrimitiveTypes.Int8},
+			want:  "bullseye/mutations: column Z does not exist",
+		},
+	}
+
+	for _, tc := range testCases {
+		_, err := df.Cast(tc.types)
+		if err == nil {
+			t.Fatalf("expected an error for %v", tc.types)
+		}
+		if got := err.Error(); got != tc.want {
+			t.Fatalf("got=%q, want=%q", got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,80 @@
+package dataframe
+
+import (
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/memory"
+	"github.com/go-bullseye/bullseye/internal/constructors"
+)
+
+// columnBuilder builds a set of columns value by value and knows how to convert
+// to the correct type like the SmartBuilder. Unlike array.RecordBuilder it also
+// supports the Date32, Date64 and Timestamp types.
+type columnBuilder struct {
+	mem       memory.Allocator
+	schema    *arrow.Schema
+	builders  []array.Builder
+	appenders []AppenderFunc
+}
+
+// newColumnBuilder creates a columnBuilder for the fields of the provided schema.
+func newColumnBuilder(mem memory.Allocator, schema *arrow.Schema) (*columnBuilder, error) {
+	fields := schema.Fields()
+	cb := &columnBuilder{
+		mem:       mem,
+		schema:    schema,
+		builders:  make([]array.Builder, 0, len(fields)),
+		appenders: make([]AppenderFunc, 0, len(fields)),
+	}
+
+	for i := range fields {
+		builder, err := constructors.NewBuilder(mem, fields[i].Type)
+		if err != nil {
+			cb.Release()
+			return nil, err
+		}
+		cb.builders = append(cb.builders, builder)
+		cb.appenders = append(cb.appenders, initFieldAppender(&fields[i]))
+	}
+
+	return cb, nil
+}
+
+// Append will append the value to the builder for the i-th field.
+func (cb *columnBuilder) Append(i int, v interface{}) {
+	cb.appenders[i](cb.builders[i], v)
+}
+
+// NewColumns creates new columns from the values appended so far
+// and resets the builders. The columns must be released.
+func (cb *columnBuilder) NewColumns() []array.Column {
+	cols := make([]array.Column, len(cb.builders))
+	for i, builder := range cb.builders {
+		arr := builder.NewArray()
+		chunk := array.NewChunked(arr.DataType(), []array.Interface{arr})
+		cols[i] = *array.NewColumn(cb.schema.Field(i), chunk)
+		chunk.Release()
+		arr.Release()
+	}
+	return cols
+}
+
+// NewDataFrame creates a new DataFrame from the values appended so far
+// and resets the builders.
+func (cb *columnBuilder) NewDataFrame() (*DataFrame, error) {
+	cols := cb.NewColumns()
+	defer func() {
+		for i := range cols {
+			cols[i].Release()
+		}
+	}()
+	return NewDataFrameFromColumns(cb.mem, cols)
+}
+
+// Release releases the underlying builders.
+func (cb *columnBuilder) Release() {
+	for _, builder := range cb.builders {
+		builder.Release()
+	}
+	cb.builders = nil
+}
@@ -0,0 +1,280 @@
+package dataframe
+
+import (
+	"reflect"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/memory"
+	"github.com/go-bullseye/bullseye/internal/constructors"
+	"github.com/pkg/errors"
+)
+
+// concatConfig are the config params for Concat.
+type concatConfig struct {
+	alignByName bool
+	fillMissing bool
+	upcast      bool
+	distinct    bool
+}
+
+// newConcatConfig creates a new config using options.
+func newConcatConfig(opts ...Option) (*concatConfig, error) {
+	cfg := &concatConfig{}
+	for _, opt := range opts {
+		if err := opt(cfg); err != nil {
+			return cfg, err
+		}
+	}
+	return cfg, nil
+}
+
+// WithAlignByName configures Concat to match columns by name instead of by position.
+// The columns are in the order of the first DataFrame.
+func WithAlignByName() Option {
+	return func(p interface{}) error {
+		o, ok := p.(*concatConfig)
+		if !ok {
+			return errors.Errorf("cannot apply WithAlignByName to: %T", p)
+		}
+		o.alignByName = true
+		return nil
+	}
+}
+
+// WithFillMissing configures Concat to fill columns missing from a DataFrame with nulls.
+// This implies WithAlignByName. The columns are in the order they are first seen.
+func WithFillMissing() Option {
+	return func(p interface{}) error {
+		o, ok := p.(*concatConfig)
+		if !ok {
+			return errors.Errorf("cannot apply WithFillMissing to: %T", p)
+		}
+		o.alignByName = true
+		o.fillMissing = true
+		return nil
+	}
+}
+
+// WithUpcast configures Concat to convert columns with different numeric
+// types to the smallest type that can hold all of them, i.e. int32 and
+// float32 become float64. Only the columns that need it are copied.
+func WithUpcast() Option {
+	return func(p interface{}) error {
+		o, ok := p.(*concatConfig)
+		if !ok {
+			return errors.Errorf("cannot apply WithUpcast to: %T", p)
+		}
+		o.upcast = true
+		return nil
+	}
+}
+
+// WithDistinct configures Concat to remove duplicate rows like a SQL UNION.
+// The first of any duplicate rows is kept and nil elements are equal to each other.
+func WithDistinct() Option {
+	return func(p interface{}) error {
+		o, ok := p.(*concatConfig)
+		if !ok {
+			return errors.Errorf("cannot apply WithDistinct to: %T", p)
+		}
+		o.distinct = true
+		return nil
+	}
+}
+
+// Concat returns a DataFrame with the rows of all of the frames, similar to a SQL UNION ALL.
+// By default the frames must have the same columns in the same order. The chunks of the
+// frames are reused so no data is copied, unless WithUpcast or WithDistinct require it.
+func Concat(mem memory.Allocator, frames []*DataFrame, opts ...Option) (*DataFrame, error) {
+	cfg, err := newConcatConfig(opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, df := range frames {
+		if df == nil {
+			return nil, errors.Errorf("bullseye/concat: frame %d is nil", i)
+		}
+	}
+
+	fields, indexes, err := concatFields(cfg, frames)
+	if err != nil {
+		return nil, err
+	}
+
+	var rows int64
+	for _, df := range frames {
+		rows += df.NumRows()
+	}
+
+	cols := make([]array.Column, 0, len(fields))
+	defer func() {
+		for i := range cols {
+			cols[i].Release()
+		}
+	}()
+	for i := range fields {
+		col, err := concatColumn(mem, fields[i], frames, indexes[i])
+		if err != nil {
+			return nil, err
+		}
+		cols = append(cols, *col)
+	}
+
+	df, err := NewDataFrameFromShape(mem, cols, rows)
+	if err != nil || !cfg.distinct {
+		return df, err
+	}
+
+	keep, found := duplicateRows(df.Columns(), KeepFirst)
+	if !found {
+		return df, nil
+	}
+	defer df.Release()
+	return filterDataFrame(mem, df, keep)
+}
+
+// concatFields works out the fields of the concatenated DataFrame and, for each field,
+// the index of its column in each of the frames, -1 being missing.
+func concatFields(cfg *concatConfig, frames []*DataFrame) ([]arrow.Field, [][]int, error) {
+	if len(frames) == 0 {
+		return nil, nil, nil
+	}
+
+	var fields []arrow.Field
+	var indexes [][]int
+	if cfg.alignByName {
+		positions := make(map[string]int)
+		for f, df := range frames {
+			for c, field := range df.ColumnTypes() {
+				pos, ok := positions[field.Name]
+				if !ok {
+					if f > 0 && !cfg.fillMissing {
+						return nil, nil, errors.Errorf("bullseye/concat: column %s of frame %d is not in frame 0", field.Name, f)
+					}
+					pos = len(fields)
+					positions[field.Name] = pos
+					fields = append(fields, field)
+					idx := make([]int, len(frames))
+					for i := range idx {
+						idx[i] = -1
+					}
+					indexes = append(indexes, idx)
+				}
+				indexes[pos][f] = c
+			}
+		}
+	} else {
+		fields = append(fields, frames[0].ColumnTypes()...)
+		for range fields {
+			indexes = append(indexes, make([]int, len(frames)))
+		}
+		for f, df := range frames {
+			dfFields := df.ColumnTypes()
+			if len(dfFields) != len(fields) {
+				return nil, nil, errors.Errorf("bullseye/concat: frame %d has %d columns, expected %d", f, len(dfFields), len(fields))
+			}
+			for c := range dfFields {
+				if dfFields[c].Name != fields[c].Name {
+					return nil, nil, errors.Errorf("bullseye/concat: column %d of frame %d is %s, expected %s", c, f, dfFields[c].Name, fields[c].Name)
+				}
+				indexes[c][f] = c
+			}
+		}
+	}
+
+	// Work out the type and nullability of each field.
+	for i := range fields {
+		field := &fields[i]
+		for f, c := range indexes[i] {
+			if c < 0 {
+				if !cfg.fillMissing {
+					return nil, nil, errors.Errorf("bullseye/concat: column %s is not in frame %d", field.Name, f)
+				}
+				field.Nullable = true
+				continue
+			}
+
+			dfField := frames[f].ColumnTypes()[c]
+			field.Nullable = field.Nullable || dfField.Nullable
+			if reflect.DeepEqual(field.Type, dfField.Type) {
+				continue
+			}
+			if cfg.upcast {
+				if dtype, ok := commonNumericType(field.Type, dfField.Type); ok {
+					field.Type = dtype
+					continue
+				}
+			}
+			return nil, nil, errors.Errorf("bullseye/concat: column %s of frame %d has type %v, expected %v", field.Name, f, dfField.Type, field.Type)
+		}
+	}
+
+	return fields, indexes, nil
+}
+
+// concatColumn creates a column from the chunks of the frames' columns. Columns missing from
+// a frame are filled with nulls and columns of a different type are converted to the field type.
+func concatColumn(mem memory.Allocator, field arrow.Field, frames []*DataFrame, indexes []int) (*array.Column, error) {
+	var chunks []array.Interface
+	defer func() {
+		for _, chunk := range chunks {
+			chunk.Release()
+		}
+	}()
+
+	for f, df := range frames {
+		rows := df.NumRows()
+		if rows == 0 {
+			continue
+		}
+
+		c := indexes[f]
+		if c < 0 {
+			nulls, err := newNullArray(mem, field.Type, rows)
+			if err != nil {
+				return nil, err
+			}
+			chunks = append(chunks, nulls)
+			continue
+		}
+
+		col := df.ColumnAt(c)
+		if columnLen(*col) != rows {
+			col = col.NewSlice(0, rows)
+			defer col.Release()
+		}
+		if !reflect.DeepEqual(col.DataType(), field.Type) {
+			var err error
+			col, err = castColumn(mem, col, field.Type, &castConfig{})
+			if err != nil {
+				return nil, err
+			}
+			defer col.Release()
+		}
+
+		for _, chunk := range col.Data().Chunks() {
+			chunk.Retain()
+			chunks = append(chunks, chunk)
+		}
+	}
+
+	chunked := array.NewChunked(field.Type, chunks)
+	defer chunked.Release()
+	return array.NewColumn(field, chunked), nil
+}
+
+// newNullArray returns an array of the given type where all of the values are null.
+func newNullArray(mem memory.Allocator, dtype arrow.DataType, length int64) (array.Interface, error) {
+	builder, err := constructors.NewBuilder(mem, dtype)
+	if err != nil {
+		return nil, err
+	}
+	defer builder.Release()
+
+	for i := int64(0); i < length; i++ {
+		builder.AppendNull()
+	}
+	return builder.NewArray(), nil
+}
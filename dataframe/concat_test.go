@@ -0,0 +1,179 @@
+package dataframe
+
+import (
+	"testing"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/memory"
+)
+
+func TestConcat(t *testing.T) {
+	pool := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer pool.AssertSize(t, 0)
+
+	df1, err := NewDataFrameFromMem(pool, Dict{
+		"A": []int32{1, 2},
+		"B": []float64{1.5, 2.5},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer df1.Release()
+
+	df2, err := NewDataFrameFromMem(pool, Dict{
+		"A": []int32{3, 4, 5},
+		"B": []float64{3.5, 4.5, 5.5},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer df2.Release()
+
+	df, err := Concat(pool, []*DataFrame{df1, df2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer df.Release()
+
+	if got, want := df.NumRows(), int64(5); got != want {
+		t.Fatalf("got=%d, want=%d", got, want)
+	}
+
+	got := df.Display(-1)
+	want := `rec[0]["A"]: [1 2]
+rec[0]["B"]: [1.5 2.5]
+rec[1]["A"]: [3 4 5]
+rec[1]["B"]: [3.5 4.5 5.5]
+`
+	if got != want {
+		t.Fatalf("\ngot=\n%v\nwant=\n%v", got, want)
+	}
+
+	// The chunks should be reused and not copied.
+	chunks := df.Column("A").Data().Chunks()
+	if chunks[0] != df1.Column("A").Data().Chunk(0) || chunks[1] != df2.Column("A").Data().Chunk(0) {
+		t.Fatal("expected the chunks to be reused")
+	}
+}
+
+func TestConcatOptions(t *testing.T) {
+	pool := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer pool.AssertSize(t, 0)
+
+	df1, err := NewDataFrameFromMem(pool, Dict{
+		"A": []int32{1, 2},
+		"B": []float32{1.5, 2.5},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer df1.Release()
+
+	// Same as df1 but with the columns in a different order.
+	df2, err := NewDataFrameFromColumns(pool, []array.Column{*df1.Column("B"), *df1.Column("A")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer df2.Release()
+
+	df3, err := NewDataFrameFromMem(pool, Dict{
+		"A": []int64{2, 3},
+		"C": []string{"x", "y"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer df3.Release()
+
+	testCases := []struct {
+		name   string
+		frames []*DataFrame
+		opts   []Option
+		want   string
+		typeA  arrow.DataType
+		err    string
+	}{
+		{
+			name:   "align by name",
+			frames: []*DataFrame{df1, df2},
+			opts:   []Option{WithAlignByName()},
+			want: `rec[0]["A"]: [1 2]
+rec[0]["B"]: [1.5 2.5]
+rec[1]["A"]: [1 2]
+rec[1]["B"]: [1.5 2.5]
+`,
+		},
+		{
+			name:   "distinct",
+			frames: []*DataFrame{df1, df2},
+			opts:   []Option{WithAlignByName(), WithDistinct()},
+			want: `rec[0]["A"]: [1 2]
+rec[0]["B"]: [1.5 2.5]
+`,
+		},
+		{
+			name:   "fill missing and upcast",
+			frames: []*DataFrame{df1, df3},
+			opts:   []Option{WithFillMissing(), WithUpcast()},
+			want: `rec[0]["A"]: [1 2]
+rec[0]["B"]: [1.5 2.5]
+rec[0]["C"]: [(null) (null)]
+rec[1]["A"]: [2 3]
+rec[1]["B"]: [(null) (null)]
+rec[1]["C"]: ["x" "y"]
+`,
+			typeA: arrow.PrimitiveTypes.Int64,
+		},
+		{
+			name:   "positional names",
+			frames: []*DataFrame{df1, df2},
+			err:    "bullseye/concat: column 0 of frame 1 is B, expected A",
+		},
+		{
+			name:   "missing column",
+			frames: []*DataFrame{df1, df3},
+			opts:   []Option{WithAlignByName()},
+			err:    "bullseye/concat: column C of frame 1 is not in frame 0",
+		},
+		{
+			name:   "type mismatch",
+			frames: []*DataFrame{df1, df3},
+			opts:   []Option{WithFillMissing()},
+			err:    "bullseye/concat: column A of frame 1 has type int64, expected int32",
+		},
+		{
+			name:   "wrong option",
+			frames: []*DataFrame{df1, df2},
+			opts:   []Option{WithLsuffix("_x")},
+			err:    "cannot apply WithLsuffix to: *dataframe.concatConfig",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			df, err := Concat(pool, tc.frames, tc.opts...)
+			if tc.err != "" {
+				if err == nil {
+					df.Release()
+					t.Fatal("expected an error")
+				}
+				if got := err.Error(); got != tc.err {
+					t.Fatalf("got=%q, want=%q", got, tc.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer df.Release()
+
+			if got := df.Display(-1); got != tc.want {
+				t.Fatalf("\ngot=\n%v\nwant=\n%v", got, tc.want)
+			}
+			if tc.typeA != nil && df.Column("A").DataType() != tc.typeA {
+				t.Fatalf("got=%v, want=%v", df.Column("A").DataType(), tc.typeA)
+			}
+		})
+	}
+}
@@ -0,0 +1,203 @@
+package dataframe
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/pkg/errors"
+)
+
+// CorrMethod is the method used to compute a correlation.
+type CorrMethod int
+
+const (
+	// CorrPearson is the Pearson product-moment correlation, which measures a linear relationship.
+	CorrPearson CorrMethod = iota
+	// CorrSpearman is the Spearman rank correlation, the Pearson correlation of the ranks of the values,
+	// which measures a monotonic relationship. Equal values get the average of their ranks.
+	CorrSpearman
+)
+
+func (c CorrMethod) String() string {
+	switch c {
+	case CorrPearson:
+		return "pearson"
+	case CorrSpearman:
+		return "spearman"
+	default:
+		return fmt.Sprintf("CorrMethod(%d)", int(c))
+	}
+}
+
+// MatrixLabelName is the name of the column holding the column names of Corr and Cov matrices.
+const MatrixLabelName = "column"
+
+// Corr returns the correlation matrix of numeric columns, or of all of the numeric columns if none
+// are given. The first column of the matrix, named MatrixLabelName, holds the column names and
+// is followed by a float64 column for each column. Nulls are handled pairwise: the correlation
+// of two columns only uses the rows where both are not null. A correlation is null when there are
+// fewer than two such rows or when either column is constant in them.
+func (m *Mutator) Corr(method CorrMethod, columns ...string) MutationFunc {
+	corr := m.matrix(columns, func(x, y []float64) interface{} {
+		if method == CorrSpearman {
+			x, y = averageRanks(x), averageRanks(y)
+		}
+		cov, varX, varY, ok := covariance(x, y)
+		if !ok || varX == 0 || varY == 0 {
+			return nil
+		}
+		// Keep rounding errors from producing a correlation outside of [-1, 1].
+		return math.Max(-1, math.Min(1, cov/math.Sqrt(varX*varY)))
+	})
+
+	return func(df *DataFrame) (*DataFrame, error) {
+		if method != CorrPearson && method != CorrSpearman {
+			return nil, errors.Errorf("bullseye/mutations: invalid correlation method %v", method)
+		}
+		return corr(df)
+	}
+}
+
+// Cov returns the sample covariance matrix of numeric columns, or of all of the numeric columns if
+// none are given, laid out like Corr. Nulls are handled pairwise like Corr, and a covariance is null
+// when there are fewer than two rows where both columns are not null.
+func (m *Mutator) Cov(columns ...string) MutationFunc {
+	return m.matrix(columns, func(x, y []float64) interface{} {
+		cov, _, _, ok := covariance(x, y)
+		if !ok {
+			return nil
+		}
+		return cov
+	})
+}
+
+// matrix returns a MutationFunc that builds the matrix of fn applied to the
+// values of every pair of columns where both are not null.
+func (m *Mutator) matrix(columns []string, fn func(x, y []float64) interface{}) MutationFunc {
+	return func(df *DataFrame) (*DataFrame, error) {
+		var cols []array.Column
+		if len(columns) == 0 {
+			for _, col := range df.Columns() {
+				if isNumeric(col.DataType()) {
+					cols = append(cols, col)
+				}
+			}
+		} else {
+			var err error
+			if cols, err = df.subsetColumns(columns); err != nil {
+				return nil, err
+			}
+			for _, col := range cols {
+				if !isNumeric(col.DataType()) {
+					return nil, errors.Errorf("bullseye/mutations: column %s of type %v is not numeric", col.Name(), col.DataType())
+				}
+			}
+		}
+
+		values := make([][]float64, len(cols))
+		valid := make([][]bool, len(cols))
+		for i := range cols {
+			values[i], valid[i] = floatValues(&cols[i], true)
+		}
+
+		taken := make(map[string]struct{}, len(cols))
+		for _, col := range cols {
+			taken[col.Name()] = struct{}{}
+		}
+		labelName := uniqueName(MatrixLabelName, taken)
+
+		fields := make([]arrow.Field, 0, len(cols)+1)
+		fields = append(fields, arrow.Field{Name: labelName, Type: arrow.BinaryTypes.String})
+		for _, col := range cols {
+			fields = append(fields, arrow.Field{Name: col.Name(), Type: arrow.PrimitiveTypes.Float64, Nullable: true})
+		}
+		builder, err := newColumnBuilder(m.mem, arrow.NewSchema(fields, nil))
+		if err != nil {
+			return nil, err
+		}
+		defer builder.Release()
+
+		cells := make([][]interface{}, len(cols))
+		for i := range cols {
+			cells[i] = make([]interface{}, len(cols))
+			for j := 0; j <= i; j++ {
+				x, y := pairwiseValues(values[i], valid[i], values[j], valid[j])
+				cells[i][j] = fn(x, y)
+				cells[j][i] = cells[i][j]
+			}
+		}
+		for i, col := range cols {
+			builder.Append(0, col.Name())
+			for j := range cols {
+				builder.Append(j+1, cells[i][j])
+			}
+		}
+
+		return builder.NewDataFrame()
+	}
+}
+
+// pairwiseValues returns the values of x and y at the rows where both are valid.
+func pairwiseValues(x []float64, validX []bool, y []float64, validY []bool) ([]float64, []float64) {
+	var px, py []float64
+	for i := range x {
+		if validX[i] && validY[i] {
+			px = append(px, x[i])
+			py = append(py, y[i])
+		}
+	}
+	return px, py
+}
+
+// covariance returns the sample covariance and variances of x and y, or false if there are fewer than two values.
+func covariance(x, y []float64) (cov, varX, varY float64, ok bool) {
+	n := len(x)
+	if n < 2 {
+		return 0, 0, 0, false
+	}
+
+	var meanX, meanY float64
+	for i := range x {
+		meanX += x[i]
+		meanY += y[i]
+	}
+	meanX /= float64(n)
+	meanY /= float64(n)
+
+	for i := range x {
+		dx, dy := x[i]-meanX, y[i]-meanY
+		cov += dx * dy
+		varX += dx * dx
+		varY += dy * dy
+	}
+	d := float64(n - 1)
+	return cov / d, varX / d, varY / d, true
+}
+
+// averageRanks returns the ranks of the values starting at 1, with equal values getting the average of their ranks.
+func averageRanks(values []float64) []float64 {
+	order := make([]int, len(values))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return values[order[i]] < values[order[j]]
+	})
+
+	ranks := make([]float64, len(values))
+	for start := 0; start < len(order); {
+		end := start + 1
+		for end < len(order) && values[order[end]] == values[order[start]] {
+			end++
+		}
+		rank := float64(start+end+1) / 2
+		for _, i := range order[start:end] {
+			ranks[i] = rank
+		}
+		start = end
+	}
+	return ranks
+}
@@ -0,0 +1,79 @@
+package dataframe
+
+import (
+	"testing"
+
+	"github.com/apache/arrow/go/arrow/memory"
+)
+
+func TestCorr(t *testing.T) {
+	pool := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer pool.AssertSize(t, 0)
+
+	df, err := NewDataFrameFromMem(pool, Dict{
+		"A": []int32{1, 2, 3, 4, 5},
+		"B": []interface{}{2.0, 4.0, nil, 8.0, 10.0},
+		"C": []float64{1, 8, 27, 64, 125},
+		"D": []uint8{7, 7, 7, 7, 7},
+		"S": []string{"a", "b", "c", "d", "e"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer df.Release()
+
+	tests := []struct {
+		name string
+		fn   MutationFunc
+		want string
+	}{
+		{
+			name: "pearson",
+			fn:   NewMutator(pool).Corr(CorrPearson, "A", "B", "D"),
+			want: `rec[0]["column"]: ["A" "B" "D"]
+rec[0]["A"]: [1 1 (null)]
+rec[0]["B"]: [1 1 (null)]
+rec[0]["D"]: [(null) (null) (null)]
+`,
+		},
+		{
+			name: "spearman",
+			fn:   NewMutator(pool).Corr(CorrSpearman, "A", "C"),
+			want: `rec[0]["column"]: ["A" "C"]
+rec[0]["A"]: [1 1]
+rec[0]["C"]: [1 1]
+`,
+		},
+		{
+			name: "cov",
+			fn:   NewMutator(pool).Cov(),
+			want: `rec[0]["column"]: ["A" "B" "C" "D"]
+rec[0]["A"]: [2.5 6.666666666666667 76 0]
+rec[0]["B"]: [6.666666666666667 13.333333333333334 202.66666666666666 0]
+rec[0]["C"]: [76 202.66666666666666 2597.5 0]
+rec[0]["D"]: [0 0 0 0]
+`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			matrixDf, err := df.Apply(tc.fn)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer matrixDf.Release()
+
+			if got := matrixDf.Display(-1); got != tc.want {
+				t.Fatalf("\ngot=\n%v\nwant=\n%v", got, tc.want)
+			}
+		})
+	}
+
+	if _, err := df.Corr(CorrPearson, "A", "S"); err == nil || err.Error() != "bullseye/mutations: column S of type utf8 is not numeric" {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := df.Corr(CorrMethod(5)); err == nil || err.Error() != "bullseye/mutations: invalid correlation method CorrMethod(5)" {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
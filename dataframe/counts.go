@@ -0,0 +1,246 @@
+package dataframe
+
+import (
+	"math"
+	"sort"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/go-bullseye/bullseye/internal/arrays"
+	"github.com/pkg/errors"
+)
+
+const (
+	// ValueCountsCountName is the name of the column of counts of ValueCounts.
+	ValueCountsCountName = "count"
+	// ValueCountsProportionName is the name of the column of proportions of ValueCounts when normalizing.
+	ValueCountsProportionName = "proportion"
+)
+
+// valueCount is a distinct value of a column and how often it occurs.
+type valueCount struct {
+	value interface{}
+	count int64
+}
+
+// countValues returns the distinct values of the column in the order they are first seen, with their counts.
+// Null values are counted as a nil value.
+func countValues(col *array.Column) []valueCount {
+	var (
+		counts []valueCount
+		index  = make(map[string]int)
+		key    = make([]interface{}, 1)
+	)
+	for _, chunk := range col.Data().Chunks() {
+		for i := 0; i < chunk.Len(); i++ {
+			key[0] = arrays.Value(chunk, i)
+			k := rowKey(key)
+			j, ok := index[k]
+			if !ok {
+				j = len(counts)
+				index[k] = j
+				counts = append(counts, valueCount{value: key[0]})
+			}
+			counts[j].count++
+		}
+	}
+	return counts
+}
+
+// ValueCounts returns a DataFrame with the distinct values of the column and the number of times each
+// occurs, in a column named ValueCountsCountName. The values are sorted from the most to the least
+// frequent, with values that are as frequent in the order they are first seen. When normalize is true
+// the counts are divided by the number of values in a float64 column named ValueCountsProportionName.
+// Null values are counted as a value unless dropNulls is true, in which case they are also left out
+// of the number of values.
+func (m *Mutator) ValueCounts(column string, normalize bool, dropNulls bool) MutationFunc {
+	return func(df *DataFrame) (*DataFrame, error) {
+		col := df.Column(column)
+		if col == nil {
+			return nil, errors.Errorf("bullseye/mutations: column %s does not exist", column)
+		}
+
+		counts := countValues(col)
+		total := col.Len()
+		if dropNulls {
+			total -= col.NullN()
+			kept := counts[:0]
+			for _, c := range counts {
+				if c.value != nil {
+					kept = append(kept, c)
+				}
+			}
+			counts = kept
+		}
+		sort.SliceStable(counts, func(i, j int) bool {
+			return counts[i].count > counts[j].count
+		})
+
+		valueField := col.Field()
+		valueField.Nullable = valueField.Nullable && !dropNulls
+		countField := arrow.Field{Name: ValueCountsCountName, Type: arrow.PrimitiveTypes.Int64}
+		if normalize {
+			countField = arrow.Field{Name: ValueCountsProportionName, Type: arrow.PrimitiveTypes.Float64}
+		}
+		countField.Name = uniqueName(countField.Name, map[string]struct{}{column: {}})
+
+		// The values are built with newArrayFromValues, which also builds List and Struct arrays.
+		values := make([]interface{}, len(counts))
+		freqs := make([]interface{}, len(counts))
+		for i, c := range counts {
+			values[i] = c.value
+			if normalize {
+				freqs[i] = float64(c.count) / float64(total)
+			} else {
+				freqs[i] = c.count
+			}
+		}
+
+		valueArr, err := newArrayFromValues(m.mem, valueField.Type, values)
+		if err != nil {
+			return nil, err
+		}
+		defer valueArr.Release()
+		countArr, err := newArrayFromValues(m.mem, countField.Type, freqs)
+		if err != nil {
+			return nil, err
+		}
+		defer countArr.Release()
+
+		schema := arrow.NewSchema([]arrow.Field{valueField, countField}, nil)
+		return NewDataFrame(m.mem, schema, []array.Interface{valueArr, countArr})
+	}
+}
+
+// Unique returns a column with the distinct values of the named column in the order they are
+// first seen, including null if there are any null values. The column must be released.
+func (df *DataFrame) Unique(column string) (*array.Column, error) {
+	col := df.Column(column)
+	if col == nil {
+		return nil, errors.Errorf("bullseye/dataframe: column %s does not exist", column)
+	}
+
+	counts := countValues(col)
+	values := make([]interface{}, len(counts))
+	for i, c := range counts {
+		values[i] = c.value
+	}
+	arr, err := newArrayFromValues(df.mem, col.DataType(), values)
+	if err != nil {
+		return nil, err
+	}
+	defer arr.Release()
+	return newColumnFromChunks(col.Field(), []array.Interface{arr}), nil
+}
+
+// NUnique returns the number of distinct values of the named column.
+// Null is counted as a value unless dropNulls is true.
+func (df *DataFrame) NUnique(column string, dropNulls bool) (int64, error) {
+	col := df.Column(column)
+	if col == nil {
+		return 0, errors.Errorf("bullseye/dataframe: column %s does not exist", column)
+	}
+
+	n := int64(len(countValues(col)))
+	if dropNulls && col.NullN() > 0 {
+		n--
+	}
+	return n, nil
+}
+
+const (
+	// HistogramStartName is the name of the column of the lower edges of the bins of Histogram.
+	HistogramStartName = "bin_start"
+	// HistogramEndName is the name of the column of the upper edges of the bins of Histogram.
+	HistogramEndName = "bin_end"
+	// HistogramCountName is the name of the column of the counts of the bins of Histogram.
+	HistogramCountName = "count"
+)
+
+// Histogram returns a DataFrame with the counts of the values of a numeric column in bins of equal
+// width between the smallest and the largest value. Each bin includes its lower edge and excludes
+// its upper edge, except for the last bin which includes both. When all of the values are the same
+// the bins span from 0.5 below to 0.5 above the value, or a millionth of the value when that is more,
+// so large values still get bins of some width. Nulls, NaNs and infinities are not counted, and a
+// column without any other values has bins between 0 and 1.
+func (m *Mutator) Histogram(column string, bins int) MutationFunc {
+	return func(df *DataFrame) (*DataFrame, error) {
+		if bins < 1 {
+			return nil, errors.Errorf("bullseye/mutations: bins must be positive: %d", bins)
+		}
+		col := df.Column(column)
+		if col == nil {
+			return nil, errors.Errorf("bullseye/mutations: column %s does not exist", column)
+		}
+		if !isNumeric(col.DataType()) {
+			return nil, errors.Errorf("bullseye/mutations: cannot compute a histogram of column %s of type %v", column, col.DataType())
+		}
+
+		values, _ := floatValues(col, false)
+		finite := values[:0]
+		lower, upper := math.Inf(1), math.Inf(-1)
+		for _, v := range values {
+			if math.IsNaN(v) || math.IsInf(v, 0) {
+				continue
+			}
+			finite = append(finite, v)
+			lower, upper = math.Min(lower, v), math.Max(upper, v)
+		}
+		switch {
+		case len(finite) == 0:
+			lower, upper = 0, 1
+		case lower == upper:
+			half := math.Max(0.5, math.Abs(lower)*1e-6)
+			lower, upper = math.Max(lower-half, -math.MaxFloat64), math.Min(upper+half, math.MaxFloat64)
+		}
+
+		// When upper - lower overflows, the bins are computed from half of the values and of the width.
+		scale := 1.0
+		if math.IsInf(upper-lower, 0) {
+			scale = 0.5
+		}
+		width := (upper*scale - lower*scale) / float64(bins)
+		edge := func(i int) float64 {
+			e := lower + float64(i)*width
+			if scale < 1 {
+				e += float64(i) * width
+			}
+			return e
+		}
+
+		counts := make([]int64, bins)
+		for _, v := range finite {
+			i := int((v*scale - lower*scale) / width)
+			switch {
+			case i < 0:
+				i = 0
+			case i >= bins:
+				i = bins - 1
+			}
+			counts[i]++
+		}
+
+		fields := []arrow.Field{
+			{Name: HistogramStartName, Type: arrow.PrimitiveTypes.Float64},
+			{Name: HistogramEndName, Type: arrow.PrimitiveTypes.Float64},
+			{Name: HistogramCountName, Type: arrow.PrimitiveTypes.Int64},
+		}
+		builder, err := newColumnBuilder(m.mem, arrow.NewSchema(fields, nil))
+		if err != nil {
+			return nil, err
+		}
+		defer builder.Release()
+
+		for i, count := range counts {
+			end := edge(i + 1)
+			if i == bins-1 {
+				end = upper
+			}
+			builder.Append(0, edge(i))
+			builder.Append(1, end)
+			builder.Append(2, count)
+		}
+
+		return builder.NewDataFrame()
+	}
+}
@@ -0,0 +1,241 @@
+package dataframe
+
+import (
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/memory"
+)
+
+func TestValueCounts(t *testing.T) {
+	pool := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer pool.AssertSize(t, 0)
+
+	df, err := NewDataFrameFromMem(pool, Dict{
+		"A": []interface{}{"b", "a", nil, "a", "c", "b", nil, "a"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer df.Release()
+
+	tests := []struct {
+		name      string
+		normalize bool
+		dropNulls bool
+		want      string
+	}{
+		{
+			name: "counts",
+			want: `rec[0]["A"]: ["a" "b" (null) "c"]
+rec[0]["count"]: [3 2 2 1]
+`,
+		},
+		{
+			name:      "normalized without nulls",
+			normalize: true,
+			dropNulls: true,
+			want: `rec[0]["A"]: ["a" "b" "c"]
+rec[0]["proportion"]: [0.5 0.3333333333333333 0.16666666666666666]
+`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			countsDf, err := df.ValueCounts("A", tc.normalize, tc.dropNulls)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer countsDf.Release()
+
+			if got := countsDf.Display(-1); got != tc.want {
+				t.Fatalf("\ngot=\n%v\nwant=\n%v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestUnique(t *testing.T) {
+	pool := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer pool.AssertSize(t, 0)
+
+	df, err := NewDataFrameFromMem(pool, Dict{
+		"A": []interface{}{int64(3), nil, int64(1), int64(3), nil, int64(2)},
+		"B": []bool{true, true, true, true, true, true},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer df.Release()
+
+	col, err := df.Unique("A")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer col.Release()
+
+	uniqueDf, err := NewDataFrameFromColumns(pool, []array.Column{*col})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer uniqueDf.Release()
+
+	got := uniqueDf.Display(-1)
+	want := `rec[0]["A"]: [3 (null) 1 2]
+`
+	if got != want {
+		t.Fatalf("\ngot=\n%v\nwant=\n%v", got, want)
+	}
+
+	for _, tc := range []struct {
+		column    string
+		dropNulls bool
+		want      int64
+	}{
+		{"A", false, 4},
+		{"A", true, 3},
+		{"B", true, 1},
+	} {
+		n, err := df.NUnique(tc.column, tc.dropNulls)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if n != tc.want {
+			t.Fatalf("NUnique(%s, %v): got=%d, want=%d", tc.column, tc.dropNulls, n, tc.want)
+		}
+	}
+
+	if _, err := df.Unique("X"); err == nil || err.Error() != "bullseye/dataframe: column X does not exist" {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestCountsNested(t *testing.T) {
+	pool := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer pool.AssertSize(t, 0)
+
+	df, err := ReadJSONLines(pool, strings.NewReader(`{"o": {"a": 1, "b": "x"}, "l": [1, 2]}
+{"o": {"a": 1, "b": "x"}, "l": [null, 2]}
+{"o": {"a": 1, "b": null}, "l": [1, 2]}
+{"o": null, "l": []}
+{"o": {"a": 1, "b": "x"}, "l": null}
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer df.Release()
+
+	unique, err := df.Unique("o")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer unique.Release()
+	if got, want := unique.Len(), 3; got != want {
+		t.Fatalf("got %d unique values, want %d", got, want)
+	}
+
+	counts, err := df.ValueCounts("l", false, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer counts.Release()
+
+	got := counts.Display(-1)
+	want := `rec[0]["l"]: [[1 2] [(null) 2] [] (null)]
+rec[0]["count"]: [2 1 1 1]
+`
+	if got != want {
+		t.Fatalf("\ngot=\n%v\nwant=\n%v", got, want)
+	}
+}
+
+func TestHistogram(t *testing.T) {
+	pool := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer pool.AssertSize(t, 0)
+
+	df, err := NewDataFrameFromMem(pool, Dict{
+		"A": []interface{}{int32(0), int32(1), int32(4), nil, int32(5), int32(10), int32(9)},
+		"B": []float64{2, 2, 2, 2, 2, 2, 2},
+		"C": []float64{1e20, 1e20, 1e20, 1e20, 1e20, 1e20, 1e20},
+		"D": []int64{1 << 60, 1 << 60, 1 << 60, 1 << 60, 1 << 60, 1 << 60, 1 << 60},
+		"E": []float64{-math.MaxFloat64, 0, 0, 0, 0, math.MaxFloat64, math.NaN()},
+		"S": []string{"a", "b", "c", "d", "e", "f", "g"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer df.Release()
+
+	tests := []struct {
+		column string
+		bins   int
+		want   string
+	}{
+		{
+			column: "A",
+			bins:   4,
+			want: `rec[0]["bin_start"]: [0 2.5 5 7.5]
+rec[0]["bin_end"]: [2.5 5 7.5 10]
+rec[0]["count"]: [2 1 1 2]
+`,
+		},
+		{
+			column: "B",
+			bins:   2,
+			want: `rec[0]["bin_start"]: [1.5 2]
+rec[0]["bin_end"]: [2 2.5]
+rec[0]["count"]: [0 7]
+`,
+		},
+		{
+			// 0.5 is too small to change 1e20.
+			column: "C",
+			bins:   2,
+			want: `rec[0]["bin_start"]: [9.99999e+19 1e+20]
+rec[0]["bin_end"]: [1e+20 1.000001e+20]
+rec[0]["count"]: [0 7]
+`,
+		},
+		{
+			column: "D",
+			bins:   2,
+			want: `rec[0]["bin_start"]: [1.1529203516853423e+18 1.152921504606847e+18]
+rec[0]["bin_end"]: [1.152921504606847e+18 1.1529226575283515e+18]
+rec[0]["count"]: [0 7]
+`,
+		},
+		{
+			// The width of the range overflows a float64.
+			column: "E",
+			bins:   4,
+			want: `rec[0]["bin_start"]: [-1.7976931348623157e+308 -8.988465674311578e+307 0 8.988465674311578e+307]
+rec[0]["bin_end"]: [-8.988465674311578e+307 0 8.988465674311578e+307 1.7976931348623157e+308]
+rec[0]["count"]: [1 0 4 1]
+`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.column, func(t *testing.T) {
+			histDf, err := df.Histogram(tc.column, tc.bins)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer histDf.Release()
+
+			if got := histDf.Display(-1); got != tc.want {
+				t.Fatalf("\ngot=\n%v\nwant=\n%v", got, tc.want)
+			}
+		})
+	}
+
+	if _, err := df.Histogram("S", 2); err == nil || err.Error() != "bullseye/mutations: cannot compute a histogram of column S of type utf8" {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := df.Histogram("A", 0); err == nil || err.Error() != "bullseye/mutations: bins must be positive: 0" {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
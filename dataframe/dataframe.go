@@ -285,30 +285,50 @@ func (df *DataFrame) Display(chunkSize int64) string {
  * These are column specific helpers
  */
 
-// SelectColumns returns only columns matching names.
+// SelectColumns returns only columns matching names, in the order of names.
+// Names that do not match a column, or that were already selected, are ignored.
 func (df *DataFrame) SelectColumns(names ...string) []array.Column {
 	if len(names) == 0 {
 		return []array.Column{}
 	}
 
 	set := make(map[string]struct{}, len(names))
+	cols := make([]array.Column, 0, len(names))
+
 	for _, name := range names {
+		if _, ok := set[name]; ok {
+			continue
+		}
 		set[name] = struct{}{}
-	}
-
-	cols := make([]array.Column, 0, len(names))
 
-	dfColumns := df.Columns()
-	for i := range dfColumns {
-		if _, ok := set[dfColumns[i].Name()]; !ok {
+		col := df.Column(name)
+		if col == nil {
 			continue
 		}
-		cols = append(cols, dfColumns[i])
+		cols = append(cols, *col)
 	}
 
 	return cols[:len(cols):len(cols)]
 }
 
+// subsetColumns returns the columns matching names in the order of names, or all
+// of the columns if names is empty. It is an error if a column does not exist.
+func (df *DataFrame) subsetColumns(names []string) ([]array.Column, error) {
+	if len(names) == 0 {
+		return df.Columns(), nil
+	}
+
+	cols := make([]array.Column, len(names))
+	for i, name := range names {
+		col := df.Column(name)
+		if col == nil {
+			return nil, errors.Errorf("bullseye/mutations: column %s does not exist", name)
+		}
+		cols[i] = *col
+	}
+	return cols, nil
+}
+
 // RejectColumns returns only columns not matching names.
 func (df *DataFrame) RejectColumns(names ...string) []array.Column {
 	if len(names) == 0 {
@@ -424,6 +444,174 @@ func (df *DataFrame) Select(names ...string) (*DataFrame, error) {
 	return fn(df)
 }
 
+// Cast returns a DataFrame with the columns in types converted to the new types.
+func (df *DataFrame) Cast(types map[string]arrow.DataType, opts ...Option) (*DataFrame, error) {
+	fn := df.mutator.Cast(types, opts...)
+	return fn(df)
+}
+
+// Describe returns a DataFrame of summary statistics with one row for each column.
+func (df *DataFrame) Describe() (*DataFrame, error) {
+	fn := df.mutator.Describe()
+	return fn(df)
+}
+
+// Distinct returns a DataFrame without any duplicate rows.
+func (df *DataFrame) Distinct() (*DataFrame, error) {
+	fn := df.mutator.Distinct()
+	return fn(df)
+}
+
+// DropDuplicates returns a DataFrame without the rows that have the same values for the subset of columns.
+func (df *DataFrame) DropDuplicates(subset []string, keep DuplicateKeep) (*DataFrame, error) {
+	fn := df.mutator.DropDuplicates(subset, keep)
+	return fn(df)
+}
+
+// DropNulls returns a DataFrame without the rows that have nulls in the subset of columns.
+func (df *DataFrame) DropNulls(subset []string, how NullHow) (*DataFrame, error) {
+	fn := df.mutator.DropNulls(subset, how)
+	return fn(df)
+}
+
+// FillNull returns a DataFrame with the nulls of each column in values replaced by its value.
+func (df *DataFrame) FillNull(values map[string]interface{}) (*DataFrame, error) {
+	fn := df.mutator.FillNull(values)
+	return fn(df)
+}
+
+// FillForward returns a DataFrame with the nulls of the columns replaced by the last value before them.
+func (df *DataFrame) FillForward(columns []string, opts ...Option) (*DataFrame, error) {
+	fn := df.mutator.FillForward(columns, opts...)
+	return fn(df)
+}
+
+// FillBackward returns a DataFrame with the nulls of the columns replaced by the next value after them.
+func (df *DataFrame) FillBackward(columns []string, opts ...Option) (*DataFrame, error) {
+	fn := df.mutator.FillBackward(columns, opts...)
+	return fn(df)
+}
+
+// Pivot returns a DataFrame reshaped from long to wide.
+func (df *DataFrame) Pivot(index []string, columns string, values string, agg AggFunc) (*DataFrame, error) {
+	fn := df.mutator.Pivot(index, columns, values, agg)
+	return fn(df)
+}
+
+// Melt returns a DataFrame reshaped from wide to long.
+func (df *DataFrame) Melt(idVars, valueVars []string, varName, valueName string) (*DataFrame, error) {
+	fn := df.mutator.Melt(idVars, valueVars, varName, valueName)
+	return fn(df)
+}
+
+// Rename the given DataFrame columns, mapping old names to new names.
+func (df *DataFrame) Rename(names map[string]string) (*DataFrame, error) {
+	fn := df.mutator.Rename(names)
+	return fn(df)
+}
+
+// Reorder moves the given DataFrame columns to the front in the order of names.
+func (df *DataFrame) Reorder(names ...string) (*DataFrame, error) {
+	fn := df.mutator.Reorder(names...)
+	return fn(df)
+}
+
+// Rolling creates a window over the current row and the window-1 rows before it.
+// Apply the MutationFunc of one of its aggregates to append it to the DataFrame.
+func (df *DataFrame) Rolling(column string, window, minPeriods int, opts ...Option) *Window {
+	return df.mutator.Rolling(column, window, minPeriods, opts...)
+}
+
+// Expanding creates a window over the current row and all of the rows before it.
+// Apply the MutationFunc of one of its aggregates to append it to the DataFrame.
+func (df *DataFrame) Expanding(column string, minPeriods int, opts ...Option) *Window {
+	return df.mutator.Expanding(column, minPeriods, opts...)
+}
+
+// CumSum appends the cumulative sum of the column.
+func (df *DataFrame) CumSum(column string, opts ...Option) (*DataFrame, error) {
+	fn := df.mutator.CumSum(column, opts...)
+	return fn(df)
+}
+
+// CumProd appends the cumulative product of the column.
+func (df *DataFrame) CumProd(column string, opts ...Option) (*DataFrame, error) {
+	fn := df.mutator.CumProd(column, opts...)
+	return fn(df)
+}
+
+// CumMin appends the cumulative minimum of the column.
+func (df *DataFrame) CumMin(column string, opts ...Option) (*DataFrame, error) {
+	fn := df.mutator.CumMin(column, opts...)
+	return fn(df)
+}
+
+// CumMax appends the cumulative maximum of the column.
+func (df *DataFrame) CumMax(column string, opts ...Option) (*DataFrame, error) {
+	fn := df.mutator.CumMax(column, opts...)
+	return fn(df)
+}
+
+// Shift appends the column shifted by n rows.
+func (df *DataFrame) Shift(column string, n int, opts ...Option) (*DataFrame, error) {
+	fn := df.mutator.Shift(column, n, opts...)
+	return fn(df)
+}
+
+// Diff appends the difference between each row of the column and the row n rows before it.
+func (df *DataFrame) Diff(column string, n int, opts ...Option) (*DataFrame, error) {
+	fn := df.mutator.Diff(column, n, opts...)
+	return fn(df)
+}
+
+// Rank appends the rank of each value of the column within its partition.
+func (df *DataFrame) Rank(column string, method RankMethod, ascending bool, partitionBy []string, opts ...Option) (*DataFrame, error) {
+	fn := df.mutator.Rank(column, method, ascending, partitionBy, opts...)
+	return fn(df)
+}
+
+// PercentRank appends the relative rank of each value of the column within its partition.
+func (df *DataFrame) PercentRank(column string, ascending bool, partitionBy []string, opts ...Option) (*DataFrame, error) {
+	fn := df.mutator.PercentRank(column, ascending, partitionBy, opts...)
+	return fn(df)
+}
+
+// RowNumber appends the number of each row within its partition.
+func (df *DataFrame) RowNumber(partitionBy, orderBy []string, opts ...Option) (*DataFrame, error) {
+	fn := df.mutator.RowNumber(partitionBy, orderBy, opts...)
+	return fn(df)
+}
+
+// ValueCounts returns the distinct values of the column and the number of times each occurs.
+func (df *DataFrame) ValueCounts(column string, normalize bool, dropNulls bool) (*DataFrame, error) {
+	fn := df.mutator.ValueCounts(column, normalize, dropNulls)
+	return fn(df)
+}
+
+// Histogram returns the counts of the values of a numeric column in bins of equal width.
+func (df *DataFrame) Histogram(column string, bins int) (*DataFrame, error) {
+	fn := df.mutator.Histogram(column, bins)
+	return fn(df)
+}
+
+// Corr returns the correlation matrix of numeric columns.
+func (df *DataFrame) Corr(method CorrMethod, columns ...string) (*DataFrame, error) {
+	fn := df.mutator.Corr(method, columns...)
+	return fn(df)
+}
+
+// Cov returns the sample covariance matrix of numeric columns.
+func (df *DataFrame) Cov(columns ...string) (*DataFrame, error) {
+	fn := df.mutator.Cov(columns...)
+	return fn(df)
+}
+
+// Resample groups the rows into time buckets and aggregates each bucket.
+func (df *DataFrame) Resample(timeCol string, rule ResampleRule, aggs []Aggregation, opts ...Option) (*DataFrame, error) {
+	fn := df.mutator.Resample(timeCol, rule, aggs, opts...)
+	return fn(df)
+}
+
 // Drop the given DataFrame columns by name.
 func (df *DataFrame) Drop(names ...string) (*DataFrame, error) {
 	fn := df.mutator.Drop(names...)
@@ -455,6 +643,18 @@ func (df *DataFrame) RightJoin(right *DataFrame, columns []string, opts ...Optio
 	return fn(df)
 }
 
+// JoinWhere returns a DataFrame containing the join of this DataFrame and right where the predicate is true.
+func (df *DataFrame) JoinWhere(right *DataFrame, pred JoinPredicate, kind JoinKind, opts ...Option) (*DataFrame, error) {
+	fn := df.mutator.JoinWhere(right, pred, kind, opts...)
+	return fn(df)
+}
+
+// JoinRange returns a DataFrame containing the join of this DataFrame and right where every condition is true.
+func (df *DataFrame) JoinRange(right *DataFrame, conds []RangeCondition, kind JoinKind, opts ...Option) (*DataFrame, error) {
+	fn := df.mutator.JoinRange(right, conds, kind, opts...)
+	return fn(df)
+}
+
 // Slice creates a new DataFrame consisting of rows[beg:end].
 func (df *DataFrame) Slice(beg, end int64) (*DataFrame, error) {
 	return df.mutator.Slice(beg, end)(df)
@@ -441,6 +441,141 @@ rec[0]["col6-f64"]: [1 2 3 4 5 6 7 8 9 10]
 	}
 }
 
+func TestSelectOrder(t *testing.T) {
+	pool := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer pool.AssertSize(t, 0)
+
+	df, err := NewDataFrameFromMem(pool, Dict{
+		"col1-i32": []int32{1, 2, 3},
+		"col2-f64": []float64{4, 5, 6},
+		"col3-i32": []int32{7, 8, 9},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer df.Release()
+
+	df2, err := df.Select("col3-i32", "missing", "col1-i32", "col3-i32")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer df2.Release()
+
+	got := df2.Display(-1)
+	want := `rec[0]["col3-i32"]: [7 8 9]
+rec[0]["col1-i32"]: [1 2 3]
+`
+
+	if got != want {
+		t.Fatalf("\ngot=\n%v\nwant=\n%v", got, want)
+	}
+}
+
+func TestRename(t *testing.T) {
+	pool := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer pool.AssertSize(t, 0)
+
+	df, err := NewDataFrameFromMem(pool, Dict{
+		"A": []int32{1, 2, 3},
+		"B": []float64{4, 5, 6},
+		"C": []int32{7, 8, 9},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer df.Release()
+
+	df2, err := df.Rename(map[string]string{"A": "B", "B": "A", "C": "D"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer df2.Release()
+
+	got := df2.Display(-1)
+	want := `rec[0]["B"]: [1 2 3]
+rec[0]["A"]: [4 5 6]
+rec[0]["D"]: [7 8 9]
+`
+	if got != want {
+		t.Fatalf("\ngot=\n%v\nwant=\n%v", got, want)
+	}
+
+	// The data is shared, only the field changes.
+	if df2.Column("D").Data() != df.Column("C").Data() {
+		t.Fatal("expected the data of column C to be shared")
+	}
+
+	errorCases := []struct {
+		names map[string]string
+		want  string
+	}{
+		{
+			names: map[string]string{"Z": "A"},
+			want:  "bullseye/mutations: column Z does not exist",
+		},
+		{
+			names: map[string]string{"C": "A"},
+			want:  "bullseye/mutations: cannot rename C to A, column A exists",
+		},
+		{
+			names: map[string]string{"A": "B"},
+			want:  "bullseye/mutations: cannot rename A to B, column B exists",
+		},
+		{
+			names: map[string]string{"C": "X", "A": "X"},
+			want:  "bullseye/mutations: cannot rename A and C to X",
+		},
+	}
+	for _, tc := range errorCases {
+		_, err := df.Rename(tc.names)
+		if err == nil {
+			t.Fatalf("expected an error for %v", tc.names)
+		}
+		if got := err.Error(); got != tc.want {
+			t.Fatalf("got=%q, want=%q", got, tc.want)
+		}
+	}
+}
+
+func TestReorder(t *testing.T) {
+	pool := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer pool.AssertSize(t, 0)
+
+	df, err := NewDataFrameFromMem(pool, Dict{
+		"A": []int32{1, 2, 3},
+		"B": []float64{4, 5, 6},
+		"C": []int32{7, 8, 9},
+		"D": []int32{10, 11, 12},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer df.Release()
+
+	df2, err := df.Reorder("C", "A")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer df2.Release()
+
+	got := df2.Display(-1)
+	want := `rec[0]["C"]: [7 8 9]
+rec[0]["A"]: [1 2 3]
+rec[0]["B"]: [4 5 6]
+rec[0]["D"]: [10 11 12]
+`
+	if got != want {
+		t.Fatalf("\ngot=\n%v\nwant=\n%v", got, want)
+	}
+
+	if _, err := df.Reorder("A", "A"); err == nil || err.Error() != "bullseye/mutations: column A is given more than once" {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := df.Reorder("Z"); err == nil || err.Error() != "bullseye/mutations: column Z does not exist" {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
 func TestDrop(t *testing.T) {
 	pool := memory.NewCheckedAllocator(memory.NewGoAllocator())
 	defer pool.AssertSize(t, 0)
@@ -1122,6 +1257,341 @@ rec[0]["D_left"]: [5 (null) 1 5 0 0]
 	}
 }
 
+func TestInnerJoinNullsEqual(t *testing.T) {
+	pool := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer pool.AssertSize(t, 0)
+
+	// With WithNullsEqual, nil elements in the key columns should match each other.
+	leftDf, err := NewDataFrameFromMem(pool, Dict{
+		"A": []interface{}{nil, 2, 3, 1},
+		"B": []float64{6, 4, 3, 2},
+		"D": []int64{5, 1, 0, 0},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer leftDf.Release()
+
+	rightDf, err := NewDataFrameFromMem(pool, Dict{
+		"A": []interface{}{nil, 4, 2, 5},
+		"F": []float64{7, 3, 5, 8},
+		"D": []int64{5, 0, 1, 0},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rightDf.Release()
+
+	joinedDf, err := leftDf.InnerJoin(rightDf, []string{"A", "D"}, WithNullsEqual())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer joinedDf.Release()
+
+	got := joinedDf.Display(-1)
+	want := `rec[0]["A"]: [(null) 2]
+rec[0]["D"]: [5 1]
+rec[0]["B"]: [6 4]
+rec[0]["F"]: [7 5]
+`
+	if got != want {
+		t.Fatalf("\ngot=\n%v\nwant=\n%v", got, want)
+	}
+}
+
+func TestLeftJoinNullsEqual(t *testing.T) {
+	pool := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer pool.AssertSize(t, 0)
+
+	// With WithNullsEqual, nil elements in the key columns should match each other.
+	leftDf, err := NewDataFrameFromMem(pool, Dict{
+		"A": []interface{}{nil, 2, 3, 1},
+		"B": []float64{6, 4, 3, 2},
+		"D": []int64{5, 1, 0, 0},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer leftDf.Release()
+
+	rightDf, err := NewDataFrameFromMem(pool, Dict{
+		"A": []interface{}{nil, 4, 2, 5},
+		"F": []float64{7, 3, 5, 8},
+		"D": []int64{5, 0, 1, 0},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rightDf.Release()
+
+	joinedDf, err := leftDf.LeftJoin(rightDf, []string{"A", "D"}, WithNullsEqual())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer joinedDf.Release()
+
+	got := joinedDf.Display(-1)
+	want := `rec[0]["A"]: [(null) 2 3 1]
+rec[0]["D"]: [5 1 0 0]
+rec[0]["B"]: [6 4 3 2]
+rec[0]["F"]: [7 5 (null) (null)]
+`
+	if got != want {
+		t.Fatalf("\ngot=\n%v\nwant=\n%v", got, want)
+	}
+}
+
+func TestRightJoinNullsEqual(t *testing.T) {
+	pool := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer pool.AssertSize(t, 0)
+
+	// With WithNullsEqual, nil elements in the key columns should match each other.
+	leftDf, err := NewDataFrameFromMem(pool, Dict{
+		"A": []interface{}{nil, 2, 3, 1},
+		"B": []float64{6, 4, 3, 2},
+		"D": []int64{5, 1, 0, 0},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer leftDf.Release()
+
+	rightDf, err := NewDataFrameFromMem(pool, Dict{
+		"A": []interface{}{nil, 4, 2, 5},
+		"F": []float64{7, 3, 5, 8},
+		"D": []int64{5, 0, 1, 0},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rightDf.Release()
+
+	joinedDf, err := leftDf.RightJoin(rightDf, []string{"A", "D"}, WithNullsEqual())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer joinedDf.Release()
+
+	got := joinedDf.Display(-1)
+	want := `rec[0]["A"]: [(null) 4 2 5]
+rec[0]["D"]: [5 0 1 0]
+rec[0]["F"]: [7 3 5 8]
+rec[0]["B"]: [6 (null) 4 (null)]
+`
+	if got != want {
+		t.Fatalf("\ngot=\n%v\nwant=\n%v", got, want)
+	}
+}
+
+func TestOuterJoinNullsEqual(t *testing.T) {
+	pool := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer pool.AssertSize(t, 0)
+
+	// With WithNullsEqual, nil elements in the key columns should match each other.
+	leftDf, err := NewDataFrameFromMem(pool, Dict{
+		"A": []interface{}{nil, 2, 3, 1},
+		"B": []float64{6, 4, 3, 2},
+		"D": []int64{5, 1, 0, 0},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer leftDf.Release()
+
+	rightDf, err := NewDataFrameFromMem(pool, Dict{
+		"A": []interface{}{nil, 4, 2, 5},
+		"F": []float64{7, 3, 5, 8},
+		"D": []int64{5, 0, 1, 0},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rightDf.Release()
+
+	joinedDf, err := leftDf.OuterJoin(rightDf, []string{"A", "D"}, WithNullsEqual())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer joinedDf.Release()
+
+	got := joinedDf.Display(-1)
+	want := `rec[0]["A"]: [(null) 2 3 1 4 5]
+rec[0]["D"]: [5 1 0 0 0 0]
+rec[0]["B"]: [6 4 3 2 (null) (null)]
+rec[0]["F"]: [7 5 (null) (null) 3 8]
+`
+	if got != want {
+		t.Fatalf("\ngot=\n%v\nwant=\n%v", got, want)
+	}
+}
+
+func TestJoinValidate(t *testing.T) {
+	pool := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer pool.AssertSize(t, 0)
+
+	leftDf, err := NewDataFrameFromMem(pool, Dict{
+		"A": []int64{1, 2, 3, 4},
+		"B": []float64{6, 4, 3, 2},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer leftDf.Release()
+
+	rightDf, err := NewDataFrameFromMem(pool, Dict{
+		"A": []interface{}{1, 2, 2, 3, 3, nil, nil},
+		"F": []float64{7, 3, 5, 8, 9, 1, 2},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rightDf.Release()
+
+	testCases := []struct {
+		name string
+		join func(*DataFrame, []string, ...Option) (*DataFrame, error)
+		opts []Option
+		want string
+	}{
+		{
+			name: "one_to_many",
+			join: leftDf.LeftJoin,
+			opts: []Option{WithValidate(OneToMany)},
+		},
+		{
+			name: "many_to_one",
+			join: leftDf.LeftJoin,
+			opts: []Option{WithValidate(ManyToOne)},
+			want: "bullseye/mutations: join validation many_to_one failed for right DataFrame: duplicate keys (A=2), (A=3)",
+		},
+		{
+			name: "one_to_one",
+			join: leftDf.InnerJoin,
+			opts: []Option{WithValidate(OneToOne)},
+			want: "bullseye/mutations: join validation one_to_one failed for right DataFrame: duplicate keys (A=2), (A=3)",
+		},
+		{
+			name: "one_to_one_nulls_equal",
+			join: leftDf.OuterJoin,
+			opts: []Option{WithValidate(OneToOne), WithNullsEqual()},
+			want: "bullseye/mutations: join validation one_to_one failed for right DataFrame: duplicate keys (A=2), (A=3), (A=(null))",
+		},
+		{
+			name: "right_join_many_to_one",
+			join: leftDf.RightJoin,
+			opts: []Option{WithValidate(ManyToOne)},
+			want: "bullseye/mutations: join validation many_to_one failed for right DataFrame: duplicate keys (A=2), (A=3)",
+		},
+		{
+			name: "right_join_one_to_many",
+			join: leftDf.RightJoin,
+			opts: []Option{WithValidate(OneToMany)},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			joinedDf, err := tc.join(rightDf, []string{"A"}, tc.opts...)
+			if err == nil {
+				joinedDf.Release()
+			}
+			got := ""
+			if err != nil {
+				got = err.Error()
+			}
+			if got != tc.want {
+				t.Fatalf("\ngot=\n%v\nwant=\n%v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestOuterJoinIndicator(t *testing.T) {
+	pool := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer pool.AssertSize(t, 0)
+
+	leftDf, err := NewDataFrameFromMem(pool, Dict{
+		"A": []int32{5, 2, 3, 1},
+		"B": []float64{6, 4, 3, 2},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer leftDf.Release()
+
+	rightDf, err := NewDataFrameFromMem(pool, Dict{
+		"A": []int32{5, 4, 2, 5},
+		"F": []float64{7, 3, 5, 8},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rightDf.Release()
+
+	joinedDf, err := leftDf.OuterJoin(rightDf, []string{"A"}, WithIndicator("_merge"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer joinedDf.Release()
+
+	got := joinedDf.Display(-1)
+	want := `rec[0]["A"]: [5 5 2 3 1 4]
+rec[0]["B"]: [6 6 4 3 2 (null)]
+rec[0]["F"]: [7 8 5 (null) (null) 3]
+rec[0]["_merge"]: ["both" "both" "both" "left_only" "left_only" "right_only"]
+`
+	if got != want {
+		t.Fatalf("\ngot=\n%v\nwant=\n%v", got, want)
+	}
+}
+
+func TestRightJoinIndicator(t *testing.T) {
+	pool := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer pool.AssertSize(t, 0)
+
+	leftDf, err := NewDataFrameFromMem(pool, Dict{
+		"A": []int32{5, 2, 3, 1},
+		"B": []float64{6, 4, 3, 2},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer leftDf.Release()
+
+	rightDf, err := NewDataFrameFromMem(pool, Dict{
+		"A": []int32{5, 4, 2, 5},
+		"F": []float64{7, 3, 5, 8},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rightDf.Release()
+
+	joinedDf, err := leftDf.RightJoin(rightDf, []string{"A"}, WithIndicator("_merge"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer joinedDf.Release()
+
+	got := joinedDf.Display(-1)
+	want := `rec[0]["A"]: [5 4 2 5]
+rec[0]["F"]: [7 3 5 8]
+rec[0]["B"]: [6 (null) 4 6]
+rec[0]["_merge"]: ["both" "right_only" "both" "both"]
+`
+	if got != want {
+		t.Fatalf("\ngot=\n%v\nwant=\n%v", got, want)
+	}
+
+	_, err = leftDf.LeftJoin(rightDf, []string{"A"}, WithIndicator("B"))
+	if err == nil {
+		t.Fatal("expected an error when the indicator conflicts with an existing column")
+	}
+	if got, want := err.Error(), "bullseye/mutations: indicator column B conflicts with an existing column"; got != want {
+		t.Fatalf("got=%v, want=%v", got, want)
+	}
+}
+
 func TestInconsistentDataTypesError(t *testing.T) {
 	// When elements are nil at the same location we should not consider them equal as they are unknown.
 	// This follows SQL practices.
@@ -0,0 +1,179 @@
+package dataframe
+
+import (
+	"math"
+	"sort"
+	"strconv"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/go-bullseye/bullseye/iterator"
+)
+
+// describeSchema is the schema of the DataFrame returned by Describe.
+var describeSchema = arrow.NewSchema([]arrow.Field{
+	{Name: "column", Type: arrow.BinaryTypes.String},
+	{Name: "count", Type: arrow.PrimitiveTypes.Int64},
+	{Name: "null_count", Type: arrow.PrimitiveTypes.Int64},
+	{Name: "mean", Type: arrow.PrimitiveTypes.Float64, Nullable: true},
+	{Name: "std", Type: arrow.PrimitiveTypes.Float64, Nullable: true},
+	{Name: "min", Type: arrow.PrimitiveTypes.Float64, Nullable: true},
+	{Name: "25%", Type: arrow.PrimitiveTypes.Float64, Nullable: true},
+	{Name: "50%", Type: arrow.PrimitiveTypes.Float64, Nullable: true},
+	{Name: "75%", Type: arrow.PrimitiveTypes.Float64, Nullable: true},
+	{Name: "max", Type: arrow.PrimitiveTypes.Float64, Nullable: true},
+	{Name: "unique", Type: arrow.PrimitiveTypes.Int64, Nullable: true},
+	{Name: "top", Type: arrow.BinaryTypes.String, Nullable: true},
+	{Name: "freq", Type: arrow.PrimitiveTypes.Int64, Nullable: true},
+}, nil)
+
+// columnSummary holds the statistics of one column for Describe.
+type columnSummary struct {
+	name  string
+	count int64
+	nulls int64
+
+	// numeric columns
+	numeric   bool
+	mean, std float64
+	quartiles [5]float64 // min, 25%, 50%, 75% and max
+
+	// string and bool columns
+	categorical bool
+	unique      int64
+	top         string
+	freq        int64
+}
+
+// Describe returns a DataFrame of summary statistics with one row for each column.
+// Every column has the count of values that are not null and the null count.
+// Numeric columns also have the mean, sample standard deviation, min, quartiles and max.
+// String and bool columns also have the number of unique values and the most frequent
+// value, called top, with its frequency. The first value seen wins a tie for top.
+// Statistics that don't apply to a column are null.
+func (m *Mutator) Describe() MutationFunc {
+	return func(df *DataFrame) (*DataFrame, error) {
+		builder, err := newColumnBuilder(m.mem, describeSchema)
+		if err != nil {
+			return nil, err
+		}
+		defer builder.Release()
+
+		dfCols := df.Columns()
+		for i := range dfCols {
+			for j, v := range summarizeColumn(&dfCols[i]).row() {
+				builder.Append(j, v)
+			}
+		}
+
+		return builder.NewDataFrame()
+	}
+}
+
+// row returns the values of the summary in the order of describeSchema.
+func (s *columnSummary) row() []interface{} {
+	row := make([]interface{}, len(describeSchema.Fields()))
+	row[0] = s.name
+	row[1] = s.count
+	row[2] = s.nulls
+
+	if s.numeric && s.count > 0 {
+		row[3] = s.mean
+		if s.count > 1 {
+			row[4] = s.std
+		}
+		for i, q := range s.quartiles {
+			row[5+i] = q
+		}
+	}
+
+	if s.categorical {
+		row[10] = s.unique
+		if s.count > 0 {
+			row[11] = s.top
+			row[12] = s.freq
+		}
+	}
+
+	return row
+}
+
+// summarizeColumn computes the statistics of the column in a single pass.
+func summarizeColumn(col *array.Column) *columnSummary {
+	it := iterator.NewChunkIterator(col)
+	defer it.Release()
+
+	s := &columnSummary{name: col.Name()}
+	s.nulls = it.NullN()
+	s.count = it.Len() - s.nulls
+
+	switch {
+	case isNumeric(col.DataType()):
+		s.numeric = true
+		values, _ := floatValues(col, false)
+		s.summarizeNumeric(values)
+	case col.DataType().ID() == arrow.STRING, col.DataType().ID() == arrow.BOOL:
+		s.categorical = true
+		s.summarizeCategorical(it)
+	}
+
+	return s
+}
+
+// summarizeNumeric computes the mean, standard deviation and quartiles of values.
+func (s *columnSummary) summarizeNumeric(values []float64) {
+	if len(values) == 0 {
+		return
+	}
+
+	// Welford's algorithm is used as it is more stable than summing the squares.
+	var mean, m2 float64
+	for i, v := range values {
+		delta := v - mean
+		mean += delta / float64(i+1)
+		m2 += delta * (v - mean)
+	}
+	s.mean = mean
+	if len(values) > 1 {
+		s.std = math.Sqrt(m2 / float64(len(values)-1))
+	}
+
+	sort.Float64s(values)
+	for i, q := range []float64{0, 0.25, 0.5, 0.75, 1} {
+		s.quartiles[i] = quantileSorted(values, q, InterpolationLinear)
+	}
+}
+
+// summarizeCategorical computes the number of unique values and the most frequent value of a string or bool column.
+func (s *columnSummary) summarizeCategorical(it *iterator.ChunkIterator) {
+	counts := make(map[string]int64)
+	var order []string // the order the values are first seen in to break ties
+	for it.Next() {
+		chunk := it.Chunk()
+		for i := 0; i < chunk.Len(); i++ {
+			if chunk.IsNull(i) {
+				continue
+			}
+
+			var v string
+			switch c := chunk.(type) {
+			case *array.String:
+				v = c.Value(i)
+			case *array.Boolean:
+				v = strconv.FormatBool(c.Value(i))
+			}
+
+			if _, ok := counts[v]; !ok {
+				order = append(order, v)
+			}
+			counts[v]++
+		}
+	}
+
+	for _, v := range order {
+		if counts[v] > s.freq {
+			s.top, s.freq = v, counts[v]
+		}
+	}
+	s.unique = int64(len(counts))
+}
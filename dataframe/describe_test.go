@@ -0,0 +1,48 @@
+package dataframe
+
+import (
+	"testing"
+
+	"github.com/apache/arrow/go/arrow/memory"
+)
+
+func TestDescribe(t *testing.T) {
+	pool := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer pool.AssertSize(t, 0)
+
+	df, err := NewDataFrameFromMem(pool, Dict{
+		"A": []interface{}{int32(1), int32(2), nil, int32(3), int32(4)},
+		"B": []float64{2, 4, 4, 4, 6},
+		"C": []interface{}{"x", "y", "y", nil, "x"},
+		"D": []bool{true, false, false, true, false},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer df.Release()
+
+	describeDf, err := df.Describe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer describeDf.Release()
+
+	got := describeDf.Display(-1)
+	want := `rec[0]["column"]: ["A" "B" "C" "D"]
+rec[0]["count"]: [4 5 4 5]
+rec[0]["null_count"]: [1 0 1 0]
+rec[0]["mean"]: [2.5 4 (null) (null)]
+rec[0]["std"]: [1.2909944487358056 1.4142135623730951 (null) (null)]
+rec[0]["min"]: [1 2 (null) (null)]
+rec[0]["25%"]: [1.75 4 (null) (null)]
+rec[0]["50%"]: [2.5 4 (null) (null)]
+rec[0]["75%"]: [3.25 4 (null) (null)]
+rec[0]["max"]: [4 6 (null) (null)]
+rec[0]["unique"]: [(null) (null) 2 2]
+rec[0]["top"]: [(null) (null) "x" "false"]
+rec[0]["freq"]: [(null) (null) 2 3]
+`
+	if got != want {
+		t.Fatalf("\ngot=\n%v\nwant=\n%v", got, want)
+	}
+}
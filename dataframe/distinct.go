@@ -0,0 +1,104 @@
+package dataframe
+
+import (
+	"fmt"
+
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/go-bullseye/bullseye/iterator"
+	"github.com/pkg/errors"
+)
+
+// DuplicateKeep is which of the duplicate rows DropDuplicates keeps.
+type DuplicateKeep int
+
+const (
+	// KeepFirst keeps the first of the duplicate rows.
+	KeepFirst DuplicateKeep = iota
+	// KeepLast keeps the last of the duplicate rows.
+	KeepLast
+	// KeepNone drops all of the duplicate rows.
+	KeepNone
+)
+
+func (k DuplicateKeep) String() string {
+	switch k {
+	case KeepFirst:
+		return "first"
+	case KeepLast:
+		return "last"
+	case KeepNone:
+		return "none"
+	default:
+		return fmt.Sprintf("DuplicateKeep(%d)", int(k))
+	}
+}
+
+// Distinct returns a DataFrame without any duplicate rows, keeping the first of them.
+// nil elements are equal to each other.
+func (m *Mutator) Distinct() MutationFunc {
+	return m.DropDuplicates(nil, KeepFirst)
+}
+
+// DropDuplicates returns a DataFrame without the rows that have the same values for the subset
+// of columns. All of the columns are used when subset is empty. keep chooses which of the duplicate
+// rows remain. The kept rows stay in their original order and nil elements are equal to each other.
+func (m *Mutator) DropDuplicates(subset []string, keep DuplicateKeep) MutationFunc {
+	return func(df *DataFrame) (*DataFrame, error) {
+		switch keep {
+		case KeepFirst, KeepLast, KeepNone:
+		default:
+			return nil, errors.Errorf("bullseye/mutations: invalid duplicate keep %v", keep)
+		}
+
+		cols, err := df.subsetColumns(subset)
+		if err != nil {
+			return nil, err
+		}
+
+		kept, found := duplicateRows(cols, keep)
+		if !found {
+			// Nothing to drop so share the columns.
+			return NewDataFrameFromShape(m.mem, df.Columns(), df.NumRows())
+		}
+		return filterDataFrame(m.mem, df, kept)
+	}
+}
+
+// duplicateRows returns which rows of the columns to keep so that only one, or none,
+// of any duplicate rows remain. nil elements are equal to each other.
+// The second return value is false if there were no duplicates.
+func duplicateRows(cols []array.Column, keep DuplicateKeep) ([]bool, bool) {
+	var keys []string
+	found := false
+	counts := make(map[string]int)
+	first := make(map[string]int)
+	last := make(map[string]int)
+
+	it := iterator.NewStepIteratorForColumns(cols)
+	defer it.Release()
+	for i := 0; it.Next(); i++ {
+		key := rowKey(it.Values().Values)
+		keys = append(keys, key)
+		counts[key]++
+		if counts[key] == 1 {
+			first[key] = i
+		} else {
+			found = true
+		}
+		last[key] = i
+	}
+
+	kept := make([]bool, len(keys))
+	for i, key := range keys {
+		switch keep {
+		case KeepFirst:
+			kept[i] = first[key] == i
+		case KeepLast:
+			kept[i] = last[key] == i
+		case KeepNone:
+			kept[i] = counts[key] == 1
+		}
+	}
+
+	return kept, found
+}
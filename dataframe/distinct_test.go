@@ -0,0 +1,124 @@
+package dataframe
+
+import (
+	"testing"
+
+	"github.com/apache/arrow/go/arrow/memory"
+)
+
+func TestDropDuplicates(t *testing.T) {
+	pool := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer pool.AssertSize(t, 0)
+
+	df, err := NewDataFrameFromMem(pool, Dict{
+		"A": []int32{1, 2, 1, 3, 2, 1},
+		"B": []string{"a", "b", "a", "c", "x", "a"},
+		"C": []interface{}{1.5, nil, 1.5, 3.5, nil, 2.5},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer df.Release()
+
+	testCases := []struct {
+		name   string
+		subset []string
+		keep   DuplicateKeep
+		want   string
+	}{
+		{
+			name: "all columns",
+			keep: KeepFirst,
+			want: `rec[0]["A"]: [1 2]
+rec[0]["B"]: ["a" "b"]
+rec[0]["C"]: [1.5 (null)]
+rec[1]["A"]: [3 2 1]
+rec[1]["B"]: ["c" "x" "a"]
+rec[1]["C"]: [3.5 (null) 2.5]
+`,
+		},
+		{
+			name:   "first",
+			subset: []string{"A"},
+			keep:   KeepFirst,
+			want: `rec[0]["A"]: [1 2]
+rec[0]["B"]: ["a" "b"]
+rec[0]["C"]: [1.5 (null)]
+rec[1]["A"]: [3]
+rec[1]["B"]: ["c"]
+rec[1]["C"]: [3.5]
+`,
+		},
+		{
+			name:   "last",
+			subset: []string{"A", "B"},
+			keep:   KeepLast,
+			want: `rec[0]["A"]: [2]
+rec[0]["B"]: ["b"]
+rec[0]["C"]: [(null)]
+rec[1]["A"]: [3 2 1]
+rec[1]["B"]: ["c" "x" "a"]
+rec[1]["C"]: [3.5 (null) 2.5]
+`,
+		},
+		{
+			name:   "none",
+			subset: []string{"C"},
+			keep:   KeepNone,
+			want: `rec[0]["A"]: [3]
+rec[0]["B"]: ["c"]
+rec[0]["C"]: [3.5]
+rec[1]["A"]: [1]
+rec[1]["B"]: ["a"]
+rec[1]["C"]: [2.5]
+`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := df.DropDuplicates(tc.subset, tc.keep)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer got.Release()
+
+			// The kept rows are slices of the original chunk so each run is displayed separately.
+			if s := got.Display(-1); s != tc.want {
+				t.Fatalf("\ngot=\n%v\nwant=\n%v", s, tc.want)
+			}
+		})
+	}
+}
+
+func TestDistinct(t *testing.T) {
+	pool := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer pool.AssertSize(t, 0)
+
+	df, err := NewDataFrameFromMem(pool, Dict{
+		"A": []int32{1, 2, 3},
+		"B": []bool{true, false, true},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer df.Release()
+
+	distinctDf, err := df.Distinct()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer distinctDf.Release()
+
+	// Nothing is dropped so the columns are shared.
+	if distinctDf.Column("A").Data() != df.Column("A").Data() {
+		t.Fatal("expected the columns to be shared")
+	}
+
+	if _, err = df.DropDuplicates([]string{"Z"}, KeepFirst); err == nil {
+		t.Fatal("expected an error")
+	}
+	if got, want := err.Error(), "bullseye/mutations: column Z does not exist"; got != want {
+		t.Fatalf("got=%q, want=%q", got, want)
+	}
+}
@@ -44,7 +44,8 @@ type Element interface {
 func CastElement(dtype arrow.DataType, v interface{}) Element {
 	switch dtype.(type) {
 	// case *arrow.NullType: // TODO: implement
-	// case *arrow.BooleanType: // TODO: implement
+	case *arrow.BooleanType:
+		return NewBooleanElement(v)
 	case *arrow.Uint8Type:
 		return NewUint8Element(v)
 	case *arrow.Int8Type:
@@ -70,8 +71,42 @@ func CastElement(dtype arrow.DataType, v interface{}) Element {
 		return NewDate32Element(v)
 	case *arrow.Date64Type:
 		return NewDate64Element(v)
-		// case *arrow.StringType: // TODO: implement
-
+	case *arrow.StringType:
+		return NewStringElement(v)
 	}
 	panic(fmt.Errorf("bullseye/element: unsupported element for %T", dtype))
 }
+
+// compareValues compares the values a and b of the given DataType using the Element
+// ordering rules. It returns -1 if a < b, 0 if a == b and 1 if a > b.
+// nil values are ordered after all other values and are equal to each other.
+func compareValues(dtype arrow.DataType, a, b interface{}) (int, error) {
+	switch {
+	case a == nil && b == nil:
+		return 0, nil
+	case a == nil:
+		return 1, nil
+	case b == nil:
+		return -1, nil
+	}
+
+	left := CastElement(dtype, a)
+	right := CastElement(dtype, b)
+
+	less, err := left.Less(right)
+	if err != nil {
+		return 0, err
+	}
+	if less {
+		return -1, nil
+	}
+
+	greater, err := left.Greater(right)
+	if err != nil {
+		return 0, err
+	}
+	if greater {
+		return 1, nil
+	}
+	return 0, nil
+}
@@ -0,0 +1,66 @@
+package dataframe
+
+import (
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/memory"
+)
+
+// filterColumns returns new columns with only the rows where keep is true.
+// Nothing is copied: chunks that are kept completely are reused and runs of
+// kept rows are slices of the original chunks. The columns must be released.
+func filterColumns(cols []array.Column, keep []bool) []array.Column {
+	filtered := make([]array.Column, len(cols))
+	for i := range cols {
+		col := &cols[i]
+
+		var chunks []array.Interface
+		offset := 0
+		for _, chunk := range col.Data().Chunks() {
+			n := chunk.Len()
+			start := -1
+			for j := 0; j <= n; j++ {
+				kept := j < n && offset+j < len(keep) && keep[offset+j]
+				if kept && start < 0 {
+					start = j
+				}
+				if !kept && start >= 0 {
+					if start == 0 && j == n {
+						chunk.Retain()
+						chunks = append(chunks, chunk)
+					} else {
+						chunks = append(chunks, array.NewSlice(chunk, int64(start), int64(j)))
+					}
+					start = -1
+				}
+			}
+			offset += n
+		}
+
+		chunked := array.NewChunked(col.DataType(), chunks)
+		filtered[i] = *array.NewColumn(col.Field(), chunked)
+		chunked.Release()
+		for _, chunk := range chunks {
+			chunk.Release()
+		}
+	}
+	return filtered
+}
+
+// filterDataFrame returns a new DataFrame with only the rows where keep is true.
+func filterDataFrame(mem memory.Allocator, df *DataFrame, keep []bool) (*DataFrame, error) {
+	var rows int64
+	for _, kept := range keep {
+		if kept {
+			rows++
+		}
+	}
+
+	cols := filterColumns(df.Columns(), keep)
+	defer func() {
+		for i := range cols {
+			cols[i].Release()
+		}
+	}()
+
+	return NewDataFrameFromShape(mem, cols, rows)
+}
@@ -0,0 +1,491 @@
+package dataframe
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/go-bullseye/bullseye/iterator"
+	"github.com/pkg/errors"
+)
+
+// JoinKind is the kind of join done by JoinWhere and JoinRange.
+type JoinKind int
+
+const (
+	// InnerJoinKind keeps only the rows that matched.
+	InnerJoinKind JoinKind = iota
+	// LeftJoinKind keeps every row of the left DataFrame.
+	LeftJoinKind
+	// RightJoinKind keeps every row of the right DataFrame.
+	RightJoinKind
+	// OuterJoinKind keeps every row of both DataFrames.
+	OuterJoinKind
+)
+
+func (k JoinKind) String() string {
+	switch k {
+	case InnerJoinKind:
+		return "inner"
+	case LeftJoinKind:
+		return "left"
+	case RightJoinKind:
+		return "right"
+	case OuterJoinKind:
+		return "outer"
+	default:
+		return fmt.Sprintf("JoinKind(%d)", int(k))
+	}
+}
+
+// JoinPredicate returns true when a row of the left DataFrame matches a row of the right DataFrame.
+// Each StepValue holds the values for all of the columns of its DataFrame.
+type JoinPredicate func(left, right *iterator.StepValue) (bool, error)
+
+// RangeOp is the comparison operator used by a RangeCondition.
+type RangeOp int
+
+const (
+	// OpLess matches when the left value is less than the right value.
+	OpLess RangeOp = iota
+	// OpLessEq matches when the left value is less than or equal to the right value.
+	OpLessEq
+	// OpGreater matches when the left value is greater than the right value.
+	OpGreater
+	// OpGreaterEq matches when the left value is greater than or equal to the right value.
+	OpGreaterEq
+)
+
+func (op RangeOp) String() string {
+	switch op {
+	case OpLess:
+		return "<"
+	case OpLessEq:
+		return "<="
+	case OpGreater:
+		return ">"
+	case OpGreaterEq:
+		return ">="
+	default:
+		return fmt.Sprintf("RangeOp(%d)", int(op))
+	}
+}
+
+// eval returns true if the result of comparing left to right satisfies the operator.
+func (op RangeOp) eval(cmp int) bool {
+	switch op {
+	case OpLess:
+		return cmp < 0
+	case OpLessEq:
+		return cmp <= 0
+	case OpGreater:
+		return cmp > 0
+	case OpGreaterEq:
+		return cmp >= 0
+	}
+	return false
+}
+
+// flip returns the operator with its operands swapped, i.e. a < b becomes b > a.
+func (op RangeOp) flip() RangeOp {
+	switch op {
+	case OpLess:
+		return OpGreater
+	case OpLessEq:
+		return OpGreaterEq
+	case OpGreater:
+		return OpLess
+	case OpGreaterEq:
+		return OpLessEq
+	}
+	return op
+}
+
+// RangeCondition is an inequality between a column of the left DataFrame and
+// a column of the right DataFrame, i.e. left.Left < right.Right.
+type RangeCondition struct {
+	Left  string
+	Op    RangeOp
+	Right string
+}
+
+// Between returns the conditions for a left column being between
+// two right columns, i.e. right.lower <= left.column <= right.upper.
+func Between(column, lower, upper string) []RangeCondition {
+	return []RangeCondition{
+		{Left: column, Op: OpGreaterEq, Right: lower},
+		{Left: column, Op: OpLessEq, Right: upper},
+	}
+}
+
+// JoinWhere returns a DataFrame containing the join of two DataFrames where the predicate is true.
+// The predicate is evaluated for every pair of rows, but the cross product is never materialized.
+// All of the columns of both DataFrames are kept, like CrossJoin. Use JoinRange instead for
+// inequality and between conditions on numeric or date columns.
+func (m *Mutator) JoinWhere(rightDf *DataFrame, pred JoinPredicate, kind JoinKind, opts ...Option) MutationFunc {
+	cfg, err := newLeftJoinConfig(opts...)
+	return func(leftDf *DataFrame) (*DataFrame, error) {
+		if err != nil {
+			return nil, err
+		}
+
+		if kind == RightJoinKind {
+			// Like RightJoin, this is just a left join in reverse order.
+			swapped := *cfg
+			swapped.swap()
+			return m.thetaJoin(&swapped, rightDf, leftDf, LeftJoinKind, predicateMatcher(func(left, right *iterator.StepValue) (bool, error) {
+				return pred(right, left)
+			}))
+		}
+
+		return m.thetaJoin(cfg, leftDf, rightDf, kind, predicateMatcher(pred))
+	}
+}
+
+// JoinRange returns a DataFrame containing the join of two DataFrames where every condition is true.
+// The columns in a condition must be the same numeric or date type. Rather than comparing every pair of
+// rows, the right DataFrame is sorted on the column of each condition so the rows matching each one
+// can be found with a binary search, as both bounds of Between are. The other conditions are checked
+// only for the fewest rows found.
+// nil elements never match. All of the columns of both DataFrames are kept, like CrossJoin.
+func (m *Mutator) JoinRange(rightDf *DataFrame, conds []RangeCondition, kind JoinKind, opts ...Option) MutationFunc {
+	cfg, err := newLeftJoinConfig(opts...)
+	if err == nil && len(conds) == 0 {
+		err = errors.New("bullseye/mutations: JoinRange requires at least one condition")
+	}
+	return func(leftDf *DataFrame) (*DataFrame, error) {
+		if err != nil {
+			return nil, err
+		}
+
+		if kind == RightJoinKind {
+			swapped := *cfg
+			swapped.swap()
+			flipped := make([]RangeCondition, len(conds))
+			for i, cond := range conds {
+				flipped[i] = RangeCondition{Left: cond.Right, Op: cond.Op.flip(), Right: cond.Left}
+			}
+			return m.thetaJoin(&swapped, rightDf, leftDf, LeftJoinKind, rangeMatcher(flipped))
+		}
+
+		return m.thetaJoin(cfg, leftDf, rightDf, kind, rangeMatcher(conds))
+	}
+}
+
+// rowMatcher returns the indexes of the right rows that match the left row, in ascending order.
+type rowMatcher func(left *iterator.StepValue) ([]int, error)
+
+// newRowMatcherFunc builds a rowMatcher for the rows of the right DataFrame of a join.
+type newRowMatcherFunc func(data *joinFuncConfig, rightRows []*iterator.StepValue) (rowMatcher, error)
+
+// thetaJoin is the join shared by JoinWhere and JoinRange. kind must not be RightJoinKind.
+func (m *Mutator) thetaJoin(cfg *leftJoinConfig, leftDf, rightDf *DataFrame, kind JoinKind, newMatcher newRowMatcherFunc) (*DataFrame, error) {
+	keepLeft := false
+	switch kind {
+	case InnerJoinKind:
+	case LeftJoinKind, OuterJoinKind:
+		keepLeft = true
+	default:
+		return nil, errors.Errorf("bullseye/mutations: unsupported join kind %v", kind)
+	}
+
+	data, err := m.newJoinFuncConfig(cfg, leftDf, rightDf, nil, keepLeft)
+	if err != nil {
+		return nil, err
+	}
+	defer data.Release()
+
+	// The right rows are read once instead of once per left row.
+	rightRows := readStepValues(data.rightColumns)
+	match, err := newMatcher(data, rightRows)
+	if err != nil {
+		return nil, err
+	}
+
+	var rightMatched []bool
+	if kind == OuterJoinKind {
+		rightMatched = make([]bool, len(rightRows))
+	}
+
+	leftIterator := iterator.NewStepIteratorForColumns(data.leftColumns)
+	defer leftIterator.Release()
+	for leftIterator.Next() { // Iterate through every row in the left df.
+		leftStepValues := leftIterator.Values()
+		matches, err := match(leftStepValues)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, j := range matches {
+			data.appendRow(leftStepValues, rightRows[j])
+			if rightMatched != nil {
+				rightMatched[j] = true
+			}
+		}
+
+		if len(matches) == 0 && keepLeft {
+			data.appendRow(leftStepValues, nil)
+		}
+	}
+
+	for j, matched := range rightMatched {
+		if !matched {
+			data.appendRow(nil, rightRows[j])
+		}
+	}
+
+	return data.buildDataFrame()
+}
+
+// appendRow appends all of the left and right values to the builder.
+// A nil StepValue appends nil for all of the columns of that side.
+// This is only valid for joins without key columns.
+func (jc *joinFuncConfig) appendRow(left, right *iterator.StepValue) {
+	cIdx := 0
+	for i := range jc.leftColumns {
+		var v interface{}
+		if left != nil {
+			v = left.Values[i]
+		}
+		jc.builder.Append(cIdx, v)
+		cIdx++
+	}
+	for i := range jc.rightColumns {
+		var v interface{}
+		if right != nil {
+			v = right.Values[i]
+		}
+		jc.builder.Append(cIdx, v)
+		cIdx++
+	}
+	jc.appendIndicator(left != nil, right != nil)
+}
+
+// readStepValues reads every row of the columns.
+func readStepValues(cols []array.Column) []*iterator.StepValue {
+	var rows []*iterator.StepValue
+	it := iterator.NewStepIteratorForColumns(cols)
+	defer it.Release()
+	for it.Next() {
+		// Next creates a new StepValue each step so it's safe to hold on to.
+		rows = append(rows, it.Values())
+	}
+	return rows
+}
+
+// predicateMatcher matches by evaluating the predicate for every right row.
+func predicateMatcher(pred JoinPredicate) newRowMatcherFunc {
+	return func(data *joinFuncConfig, rightRows []*iterator.StepValue) (rowMatcher, error) {
+		return func(left *iterator.StepValue) ([]int, error) {
+			var matches []int
+			for j, right := range rightRows {
+				ok, err := pred(left, right)
+				if err != nil {
+					return nil, err
+				}
+				if ok {
+					matches = append(matches, j)
+				}
+			}
+			return matches, nil
+		}, nil
+	}
+}
+
+// rangeCondition is a RangeCondition resolved to column indexes.
+type rangeCondition struct {
+	RangeCondition
+	left  int
+	right int
+}
+
+// eval evaluates the condition for the left and right rows. nil never matches.
+func (c rangeCondition) eval(data *joinFuncConfig, left, right *iterator.StepValue) (bool, error) {
+	lv, rv := left.Values[c.left], right.Values[c.right]
+	if lv == nil || rv == nil {
+		return false, nil
+	}
+	cmp, err := compareValues(data.leftColumns[c.left].DataType(), lv, rv)
+	if err != nil {
+		return false, err
+	}
+	return c.Op.eval(cmp), nil
+}
+
+// rangeMatcher matches by searching the right rows sorted on the column of each condition,
+// and checking the other conditions on the fewest rows found by a search.
+func rangeMatcher(conds []RangeCondition) newRowMatcherFunc {
+	return func(data *joinFuncConfig, rightRows []*iterator.StepValue) (rowMatcher, error) {
+		resolved, err := resolveRangeConditions(data, conds)
+		if err != nil {
+			return nil, err
+		}
+
+		indexes := make([]*rangeIndex, len(resolved))
+		for i, cond := range resolved {
+			if indexes[i], err = newRangeIndex(data, cond, rightRows); err != nil {
+				return nil, err
+			}
+		}
+
+		return func(left *iterator.StepValue) ([]int, error) {
+			var candidates []int
+			searched := -1
+			for i, index := range indexes {
+				rows, err := index.search(left)
+				if err != nil {
+					return nil, err
+				}
+				if searched < 0 || len(rows) < len(candidates) {
+					candidates, searched = rows, i
+				}
+				if len(candidates) == 0 {
+					return nil, nil
+				}
+			}
+
+			matches := make([]int, 0, len(candidates))
+			for _, j := range candidates {
+				ok := true
+				for i := 0; ok && i < len(resolved); i++ {
+					if i == searched {
+						continue
+					}
+					matched, err := resolved[i].eval(data, left, rightRows[j])
+					if err != nil {
+						return nil, err
+					}
+					ok = matched
+				}
+				if ok {
+					matches = append(matches, j)
+				}
+			}
+
+			// Keep the right rows in their original order.
+			sort.Ints(matches)
+			return matches, nil
+		}, nil
+	}
+}
+
+// rangeIndex holds the right rows sorted on the right column of a condition.
+// nil never matches so the rows where it is nil are left out.
+type rangeIndex struct {
+	cond   rangeCondition
+	dtype  arrow.DataType
+	rows   []*iterator.StepValue
+	sorted []int
+}
+
+func newRangeIndex(data *joinFuncConfig, cond rangeCondition, rightRows []*iterator.StepValue) (*rangeIndex, error) {
+	index := &rangeIndex{
+		cond:   cond,
+		dtype:  data.rightColumns[cond.right].DataType(),
+		rows:   rightRows,
+		sorted: make([]int, 0, len(rightRows)),
+	}
+	for j := range rightRows {
+		if rightRows[j].Values[cond.right] != nil {
+			index.sorted = append(index.sorted, j)
+		}
+	}
+
+	var sortErr error
+	sort.SliceStable(index.sorted, func(a, b int) bool {
+		cmp, err := compareValues(index.dtype, index.value(a), index.value(b))
+		if err != nil && sortErr == nil {
+			sortErr = err
+		}
+		return cmp < 0
+	})
+	if sortErr != nil {
+		return nil, sortErr
+	}
+	return index, nil
+}
+
+// value returns the value of the right column of the i-th sorted row.
+func (index *rangeIndex) value(i int) interface{} {
+	return index.rows[index.sorted[i]].Values[index.cond.right]
+}
+
+// search returns the right rows for which the condition holds with the left row.
+func (index *rangeIndex) search(left *iterator.StepValue) ([]int, error) {
+	v := left.Values[index.cond.left]
+	if v == nil {
+		return nil, nil
+	}
+
+	var searchErr error
+	search := func(f func(cmp int) bool) int {
+		return sort.Search(len(index.sorted), func(i int) bool {
+			cmp, err := compareValues(index.dtype, index.value(i), v)
+			if err != nil && searchErr == nil {
+				searchErr = err
+			}
+			return f(cmp)
+		})
+	}
+	// lower is the first right value >= v and upper is the first right value > v.
+	lower := search(func(cmp int) bool { return cmp >= 0 })
+	upper := search(func(cmp int) bool { return cmp > 0 })
+	if searchErr != nil {
+		return nil, searchErr
+	}
+
+	switch index.cond.Op {
+	case OpLess:
+		return index.sorted[upper:], nil
+	case OpLessEq:
+		return index.sorted[lower:], nil
+	case OpGreater:
+		return index.sorted[:lower], nil
+	default:
+		return index.sorted[:upper], nil
+	}
+}
+
+// resolveRangeConditions finds the columns used by the conditions and makes sure they can be compared.
+func resolveRangeConditions(data *joinFuncConfig, conds []RangeCondition) ([]rangeCondition, error) {
+	resolved := make([]rangeCondition, len(conds))
+	for i, cond := range conds {
+		switch cond.Op {
+		case OpLess, OpLessEq, OpGreater, OpGreaterEq:
+		default:
+			return nil, errors.Errorf("bullseye/mutations: invalid range operator %v", cond.Op)
+		}
+
+		left := findColumn(data.leftColumns, cond.Left)
+		if left < 0 {
+			return nil, errors.Errorf("bullseye/mutations: column %s is not in left DataFrame", cond.Left)
+		}
+		right := findColumn(data.rightColumns, cond.Right)
+		if right < 0 {
+			return nil, errors.Errorf("bullseye/mutations: column %s is not in right DataFrame", cond.Right)
+		}
+
+		ltype := data.leftColumns[left].DataType()
+		rtype := data.rightColumns[right].DataType()
+		if !(isNumeric(ltype) || isDate(ltype)) {
+			return nil, errors.Errorf("bullseye/mutations: column %s must be a numeric or date type to be used in a range condition, got %v", cond.Left, ltype)
+		}
+		if ltype.ID() != rtype.ID() {
+			return nil, errors.Errorf("bullseye/mutations: cannot compare column %s (%v) with column %s (%v)", cond.Left, ltype, cond.Right, rtype)
+		}
+
+		resolved[i] = rangeCondition{RangeCondition: cond, left: left, right: right}
+	}
+	return resolved, nil
+}
+
+// findColumn returns the index of the column matching name or -1.
+func findColumn(cols []array.Column, name string) int {
+	for i := range cols {
+		if cols[i].Name() == name {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,279 @@
+package dataframe
+
+import (
+	"testing"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/memory"
+	"github.com/go-bullseye/bullseye/iterator"
+)
+
+func buildRangeFrames(pool *memory.CheckedAllocator, t *testing.T) (*DataFrame, *DataFrame) {
+	leftDf, err := NewDataFrameFromMem(pool, Dict{
+		"A": []int32{1, 5, 10, 4},
+		"B": []float64{1, 2, 3, 4},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rightDf, err := NewDataFrameFromMem(pool, Dict{
+		"Hi": []int32{6, 3, 30, 5},
+		"Lo": []int32{4, 0, 20, 1},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return leftDf, rightDf
+}
+
+// between is the JoinPredicate equivalent of Between("A", "Lo", "Hi") for the range frames.
+func between(left, right *iterator.StepValue) (bool, error) {
+	a := left.Values[0].(int32)
+	return a >= right.Values[1].(int32) && a <= right.Values[0].(int32), nil
+}
+
+func TestJoinWhere(t *testing.T) {
+	pool := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer pool.AssertSize(t, 0)
+
+	leftDf, rightDf := buildRangeFrames(pool, t)
+	defer leftDf.Release()
+	defer rightDf.Release()
+
+	testCases := []struct {
+		kind JoinKind
+		want string
+	}{
+		{
+			kind: InnerJoinKind,
+			want: `rec[0]["A"]: [1 1 5 5 4 4]
+rec[0]["B"]: [1 1 2 2 4 4]
+rec[0]["Hi"]: [3 5 6 5 6 5]
+rec[0]["Lo"]: [0 1 4 1 4 1]
+rec[0]["_merge"]: ["both" "both" "both" "both" "both" "both"]
+`,
+		},
+		{
+			kind: LeftJoinKind,
+			want: `rec[0]["A"]: [1 1 5 5 10 4 4]
+rec[0]["B"]: [1 1 2 2 3 4 4]
+rec[0]["Hi"]: [3 5 6 5 (null) 6 5]
+rec[0]["Lo"]: [0 1 4 1 (null) 4 1]
+rec[0]["_merge"]: ["both" "both" "both" "both" "left_only" "both" "both"]
+`,
+		},
+		{
+			kind: RightJoinKind,
+			want: `rec[0]["Hi"]: [6 6 3 30 5 5 5]
+rec[0]["Lo"]: [4 4 0 20 1 1 1]
+rec[0]["A"]: [5 4 1 (null) 1 5 4]
+rec[0]["B"]: [2 4 1 (null) 1 2 4]
+rec[0]["_merge"]: ["both" "both" "both" "right_only" "both" "both" "both"]
+`,
+		},
+		{
+			kind: OuterJoinKind,
+			want: `rec[0]["A"]: [1 1 5 5 10 4 4 (null)]
+rec[0]["B"]: [1 1 2 2 3 4 4 (null)]
+rec[0]["Hi"]: [3 5 6 5 (null) 6 5 30]
+rec[0]["Lo"]: [0 1 4 1 (null) 4 1 20]
+rec[0]["_merge"]: ["both" "both" "both" "both" "left_only" "both" "both" "right_only"]
+`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.kind.String(), func(t *testing.T) {
+			joinedDf, err := leftDf.JoinWhere(rightDf, between, tc.kind, WithIndicator("_merge"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer joinedDf.Release()
+
+			if got := joinedDf.Display(-1); got != tc.want {
+				t.Fatalf("\ngot=\n%v\nwant=\n%v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestJoinRange(t *testing.T) {
+	pool := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer pool.AssertSize(t, 0)
+
+	leftDf, rightDf := buildRangeFrames(pool, t)
+	defer leftDf.Release()
+	defer rightDf.Release()
+
+	testCases := []struct {
+		name  string
+		conds []RangeCondition
+		pred  JoinPredicate
+	}{
+		{
+			name:  "between",
+			conds: Between("A", "Lo", "Hi"),
+			pred:  between,
+		},
+		{
+			name: "between upper first",
+			conds: []RangeCondition{
+				{Left: "A", Op: OpLessEq, Right: "Hi"},
+				{Left: "A", Op: OpGreaterEq, Right: "Lo"},
+			},
+			pred: between,
+		},
+		{
+			name:  "less",
+			conds: []RangeCondition{{Left: "A", Op: OpLess, Right: "Hi"}},
+			pred: func(left, right *iterator.StepValue) (bool, error) {
+				return left.Values[0].(int32) < right.Values[0].(int32), nil
+			},
+		},
+		{
+			name:  "greater",
+			conds: []RangeCondition{{Left: "A", Op: OpGreater, Right: "Lo"}},
+			pred: func(left, right *iterator.StepValue) (bool, error) {
+				return left.Values[0].(int32) > right.Values[1].(int32), nil
+			},
+		},
+		{
+			name: "strict between",
+			conds: []RangeCondition{
+				{Left: "A", Op: OpLessEq, Right: "Hi"},
+				{Left: "A", Op: OpGreater, Right: "Lo"},
+			},
+			pred: func(left, right *iterator.StepValue) (bool, error) {
+				a := left.Values[0].(int32)
+				return a <= right.Values[0].(int32) && a > right.Values[1].(int32), nil
+			},
+		},
+	}
+
+	// JoinRange must give the same result as the equivalent JoinWhere.
+	for _, tc := range testCases {
+		for _, kind := range []JoinKind{InnerJoinKind, LeftJoinKind, RightJoinKind, OuterJoinKind} {
+			t.Run(tc.name+"/"+kind.String(), func(t *testing.T) {
+				want, err := leftDf.JoinWhere(rightDf, tc.pred, kind)
+				if err != nil {
+					t.Fatal(err)
+				}
+				defer want.Release()
+
+				got, err := leftDf.JoinRange(rightDf, tc.conds, kind)
+				if err != nil {
+					t.Fatal(err)
+				}
+				defer got.Release()
+
+				if !got.Equals(want) {
+					t.Fatalf("\ngot=\n%v\nwant=\n%v", got.Display(-1), want.Display(-1))
+				}
+			})
+		}
+	}
+}
+
+func TestJoinRangeDate32(t *testing.T) {
+	pool := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer pool.AssertSize(t, 0)
+
+	newDate32Column := func(name string, values []arrow.Date32, valid []bool) array.Column {
+		b := array.NewDate32Builder(pool)
+		defer b.Release()
+		b.AppendValues(values, valid)
+		arr := b.NewArray()
+		defer arr.Release()
+
+		chunked := array.NewChunked(arr.DataType(), []array.Interface{arr})
+		defer chunked.Release()
+		field := arrow.Field{Name: name, Type: arr.DataType(), Nullable: true}
+		return *array.NewColumn(field, chunked)
+	}
+
+	leftCols := []array.Column{
+		newDate32Column("Day", []arrow.Date32{17900, 18000, 18100, 0}, []bool{true, true, true, false}),
+	}
+	leftDf, err := NewDataFrameFromColumns(pool, leftCols)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer leftDf.Release()
+	for i := range leftCols {
+		leftCols[i].Release()
+	}
+
+	rightCols := []array.Column{
+		newDate32Column("Start", []arrow.Date32{17950, 17800}, nil),
+		newDate32Column("End", []arrow.Date32{18050, 17999}, nil),
+	}
+	rightDf, err := NewDataFrameFromColumns(pool, rightCols)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rightDf.Release()
+	for i := range rightCols {
+		rightCols[i].Release()
+	}
+
+	joinedDf, err := leftDf.JoinRange(rightDf, Between("Day", "Start", "End"), LeftJoinKind)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer joinedDf.Release()
+
+	got := joinedDf.Display(-1)
+	want := `rec[0]["Day"]: [17900 18000 18100 (null)]
+rec[0]["Start"]: [17800 17950 (null) (null)]
+rec[0]["End"]: [17999 18050 (null) (null)]
+`
+	if got != want {
+		t.Fatalf("\ngot=\n%v\nwant=\n%v", got, want)
+	}
+}
+
+func TestJoinRangeErrors(t *testing.T) {
+	pool := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer pool.AssertSize(t, 0)
+
+	leftDf, rightDf := buildRangeFrames(pool, t)
+	defer leftDf.Release()
+	defer rightDf.Release()
+
+	testCases := []struct {
+		name  string
+		conds []RangeCondition
+		want  string
+	}{
+		{
+			name: "no conditions",
+			want: "bullseye/mutations: JoinRange requires at least one condition",
+		},
+		{
+			name:  "missing column",
+			conds: []RangeCondition{{Left: "A", Op: OpLess, Right: "C"}},
+			want:  "bullseye/mutations: column C is not in right DataFrame",
+		},
+		{
+			name:  "mismatched types",
+			conds: []RangeCondition{{Left: "B", Op: OpLess, Right: "Hi"}},
+			want:  "bullseye/mutations: cannot compare column B (float64) with column Hi (int32)",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			joinedDf, err := leftDf.JoinRange(rightDf, tc.conds, InnerJoinKind)
+			if err == nil {
+				joinedDf.Release()
+				t.Fatal("expected an error")
+			}
+			if got := err.Error(); got != tc.want {
+				t.Fatalf("got=%q, want=%q", got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,722 @@
+package dataframe
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/memory"
+	"github.com/go-bullseye/bullseye/internal/temporal"
+	"github.com/go-bullseye/bullseye/iterator"
+	"github.com/go-bullseye/bullseye/timeops"
+	"github.com/pkg/errors"
+)
+
+// DefaultSampleSize is the number of records ReadJSONLines infers the schema from.
+const DefaultSampleSize = 1000
+
+// JSONOrient is how WriteJSON lays out a DataFrame.
+type JSONOrient int
+
+const (
+	// JSONRecords writes an array with an object for each row, e.g. [{"A":1,"B":"x"},{"A":2,"B":"y"}].
+	JSONRecords JSONOrient = iota
+	// JSONColumns writes an object with an array for each column, e.g. {"A":[1,2],"B":["x","y"]}.
+	JSONColumns
+)
+
+func (o JSONOrient) String() string {
+	switch o {
+	case JSONRecords:
+		return "records"
+	case JSONColumns:
+		return "columns"
+	default:
+		return fmt.Sprintf("JSONOrient(%d)", int(o))
+	}
+}
+
+type jsonConfig struct {
+	sampleSize int
+	schema     *arrow.Schema
+	orient     JSONOrient
+}
+
+func newJSONConfig(opts ...Option) (*jsonConfig, error) {
+	cfg := &jsonConfig{sampleSize: DefaultSampleSize}
+	for _, opt := range opts {
+		if err := opt(cfg); err != nil {
+			return nil, err
+		}
+	}
+	return cfg, nil
+}
+
+// WithSampleSize sets the number of records ReadJSONLines infers the schema from.
+// The default is DefaultSampleSize.
+func WithSampleSize(n int) Option {
+	return func(p interface{}) error {
+		cfg, ok := p.(*jsonConfig)
+		if !ok {
+			return errors.Errorf("cannot apply WithSampleSize to: %T", p)
+		}
+		if n < 1 {
+			return errors.Errorf("sample size must be positive: %d", n)
+		}
+		cfg.sampleSize = n
+		return nil
+	}
+}
+
+// WithSchema sets the schema ReadJSONLines reads the records with instead of inferring it.
+func WithSchema(schema *arrow.Schema) Option {
+	return func(p interface{}) error {
+		cfg, ok := p.(*jsonConfig)
+		if !ok {
+			return errors.Errorf("cannot apply WithSchema to: %T", p)
+		}
+		for _, field := range schema.Fields() {
+			if !canReadJSON(field.Type) {
+				return errors.Errorf("cannot read JSON into column %s of type %v", field.Name, field.Type)
+			}
+		}
+		cfg.schema = schema
+		return nil
+	}
+}
+
+// WithJSONOrient sets how WriteJSON lays out the DataFrame. The default is JSONRecords.
+func WithJSONOrient(orient JSONOrient) Option {
+	return func(p interface{}) error {
+		cfg, ok := p.(*jsonConfig)
+		if !ok {
+			return errors.Errorf("cannot apply WithJSONOrient to: %T", p)
+		}
+		if orient < JSONRecords || orient > JSONColumns {
+			return errors.Errorf("invalid JSON orient %v", orient)
+		}
+		cfg.orient = orient
+		return nil
+	}
+}
+
+// ReadJSONLines reads a DataFrame from JSON Lines, where each line of r is a JSON object with the
+// values of a row. There is a column for each key in the order the keys are first seen, and keys
+// that are missing from a record are null.
+//
+// The schema is inferred from the first records, see WithSampleSize, unless it is given with
+// WithSchema. Booleans and strings make Boolean and String columns, numbers make Int64 columns
+// when they are all integers and Float64 columns otherwise, objects make Struct columns and
+// arrays make List columns. Keys that only have null values make String columns. Values of
+// different kinds under the same key are an error, as are later values that don't fit the
+// schema. Keys that are not in the schema are ignored. Strings are read into Date32 and Date64
+// columns of a given schema with the layout "2006-01-02" or time.RFC3339, and into Timestamp
+// columns with time.RFC3339. All of the columns are nullable.
+func ReadJSONLines(mem memory.Allocator, r io.Reader, opts ...Option) (*DataFrame, error) {
+	cfg, err := newJSONConfig(opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		schema  = cfg.schema
+		root    = &jsonType{id: arrow.STRUCT}
+		sampled []*jsonObject
+		values  [][]interface{}
+	)
+	appendRecord := func(n int, record *jsonObject) error {
+		for i, field := range schema.Fields() {
+			v, err := jsonToValue(field.Name, record.values[field.Name], field.Type)
+			if err != nil {
+				return errors.Wrapf(err, "bullseye/dataframe: cannot read record %d", n)
+			}
+			values[i] = append(values[i], v)
+		}
+		return nil
+	}
+	useSample := func() error {
+		schema = root.schema()
+		values = make([][]interface{}, len(schema.Fields()))
+		for i, record := range sampled {
+			if err := appendRecord(i, record); err != nil {
+				return err
+			}
+		}
+		sampled = nil
+		return nil
+	}
+
+	if schema != nil {
+		values = make([][]interface{}, len(schema.Fields()))
+	}
+
+	dec := json.NewDecoder(r)
+	dec.UseNumber()
+	for n := 0; ; n++ {
+		v, err := decodeJSONValue(dec)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, errors.Wrapf(err, "bullseye/dataframe: cannot read record %d", n)
+		}
+		record, ok := v.(*jsonObject)
+		if !ok {
+			return nil, errors.Errorf("bullseye/dataframe: cannot read record %d, it is not an object", n)
+		}
+
+		if values != nil {
+			if err := appendRecord(n, record); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		if err := root.add("", record); err != nil {
+			return nil, errors.Wrapf(err, "bullseye/dataframe: cannot read record %d", n)
+		}
+		sampled = append(sampled, record)
+		if len(sampled) == cfg.sampleSize {
+			if err := useSample(); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if values == nil {
+		if err := useSample(); err != nil {
+			return nil, err
+		}
+	}
+
+	arrs := make([]array.Interface, 0, len(values))
+	defer func() {
+		for _, arr := range arrs {
+			arr.Release()
+		}
+	}()
+	for i, field := range schema.Fields() {
+		arr, err := newArrayFromValues(mem, field.Type, values[i])
+		if err != nil {
+			return nil, err
+		}
+		arrs = append(arrs, arr)
+	}
+
+	return NewDataFrame(mem, schema, arrs)
+}
+
+// WriteJSONLines writes each row of the DataFrame to w as a JSON object on its own line, with a key
+// for each column. Nulls, NaNs and infinities are written as null, Struct values as objects and List
+// values as arrays. Dates are written as strings with the layout "2006-01-02", timestamps with
+// time.RFC3339Nano in their time zone and times of day with the layout "15:04:05.999999999".
+func WriteJSONLines(w io.Writer, df *DataFrame) error {
+	bw := bufio.NewWriter(w)
+	err := writeJSONRows(bw, df, func(row int) string {
+		if row > 0 {
+			return "\n"
+		}
+		return ""
+	})
+	if err != nil {
+		return err
+	}
+	if df.NumRows() > 0 {
+		if err := bw.WriteByte('\n'); err != nil {
+			return err
+		}
+	}
+	return bw.Flush()
+}
+
+// WriteJSON writes the DataFrame to w as a single JSON value laid out following WithJSONOrient,
+// followed by a newline. The values are written the same way as WriteJSONLines.
+func WriteJSON(w io.Writer, df *DataFrame, opts ...Option) error {
+	cfg, err := newJSONConfig(opts...)
+	if err != nil {
+		return err
+	}
+
+	bw := bufio.NewWriter(w)
+	if cfg.orient == JSONColumns {
+		err = writeJSONColumns(bw, df)
+	} else {
+		bw.WriteByte('[')
+		err = writeJSONRows(bw, df, func(row int) string {
+			if row > 0 {
+				return ","
+			}
+			return ""
+		})
+		bw.WriteByte(']')
+	}
+	if err != nil {
+		return err
+	}
+	bw.WriteByte('\n')
+	return bw.Flush()
+}
+
+// writeJSONRows writes each row of the DataFrame as an object, preceded by the separator for the row.
+func writeJSONRows(w *bufio.Writer, df *DataFrame, separator func(row int) string) error {
+	fields := df.Schema().Fields()
+	keys := make([][]byte, len(fields))
+	for i, field := range fields {
+		keys[i] = appendJSONString(nil, field.Name)
+	}
+
+	it := iterator.NewStepIteratorForColumns(df.Columns())
+	defer it.Release()
+
+	var buf []byte
+	for row := 0; it.Next(); row++ {
+		buf = append(buf[:0], separator(row)...)
+		buf = append(buf, '{')
+		for i, v := range it.Values().Values {
+			if i > 0 {
+				buf = append(buf, ',')
+			}
+			buf = append(buf, keys[i]...)
+			buf = append(buf, ':')
+			var err error
+			if buf, err = appendJSONValue(buf, v, fields[i].Type); err != nil {
+				return err
+			}
+		}
+		buf = append(buf, '}')
+		if _, err := w.Write(buf); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeJSONColumns writes the DataFrame as an object with an array of the values of each column.
+func writeJSONColumns(w *bufio.Writer, df *DataFrame) error {
+	w.WriteByte('{')
+	cols := df.Columns()
+	var buf []byte
+	for i := range cols {
+		buf = buf[:0]
+		if i > 0 {
+			buf = append(buf, ',')
+		}
+		buf = appendJSONString(buf, cols[i].Name())
+		buf = append(buf, ':', '[')
+		if _, err := w.Write(buf); err != nil {
+			return err
+		}
+
+		it := iterator.NewValueIterator(&cols[i])
+		for row := 0; it.Next(); row++ {
+			buf = buf[:0]
+			if row > 0 {
+				buf = append(buf, ',')
+			}
+			var err error
+			if buf, err = appendJSONValue(buf, it.ValueInterface(), cols[i].DataType()); err != nil {
+				it.Release()
+				return err
+			}
+			if _, err := w.Write(buf); err != nil {
+				it.Release()
+				return err
+			}
+		}
+		it.Release()
+		w.WriteByte(']')
+	}
+	return w.WriteByte('}')
+}
+
+// jsonObject is a JSON object with its keys in the order they first appear.
+type jsonObject struct {
+	keys   []string
+	values map[string]interface{}
+}
+
+// decodeJSONValue decodes the next JSON value of dec. Objects are decoded as *jsonObject,
+// arrays as []interface{} and numbers as json.Number. It returns io.EOF when there are no
+// more values.
+func decodeJSONValue(dec *json.Decoder) (interface{}, error) {
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+	delim, ok := tok.(json.Delim)
+	if !ok {
+		return tok, nil
+	}
+
+	switch delim {
+	case '{':
+		obj := &jsonObject{values: make(map[string]interface{})}
+		for dec.More() {
+			key, err := dec.Token()
+			if err != nil {
+				return nil, err
+			}
+			v, err := decodeJSONValue(dec)
+			if err != nil {
+				return nil, noEOF(err)
+			}
+			if _, ok := obj.values[key.(string)]; !ok {
+				obj.keys = append(obj.keys, key.(string))
+			}
+			obj.values[key.(string)] = v
+		}
+		_, err = dec.Token()
+		return obj, noEOF(err)
+	case '[':
+		values := []interface{}{}
+		for dec.More() {
+			v, err := decodeJSONValue(dec)
+			if err != nil {
+				return nil, noEOF(err)
+			}
+			values = append(values, v)
+		}
+		_, err = dec.Token()
+		return values, noEOF(err)
+	}
+	return nil, errors.Errorf("unexpected %v", delim)
+}
+
+// noEOF turns io.EOF into io.ErrUnexpectedEOF, for when a value is cut off.
+func noEOF(err error) error {
+	if err == io.EOF {
+		return io.ErrUnexpectedEOF
+	}
+	return err
+}
+
+// jsonType is the type of the values under a key, inferred from the values seen so far.
+type jsonType struct {
+	// id is arrow.NULL until a value that is not null is seen.
+	id arrow.Type
+	// fields are the keys of objects in the order they are first seen, with their types.
+	fields   []string
+	children map[string]*jsonType
+	// elem is the type of the values of arrays.
+	elem *jsonType
+}
+
+// add adds the JSON value v under the key path to the inferred type.
+func (t *jsonType) add(path string, v interface{}) error {
+	var id arrow.Type
+	switch v := v.(type) {
+	case nil:
+		return nil
+	case bool:
+		id = arrow.BOOL
+	case string:
+		id = arrow.STRING
+	case json.Number:
+		id = arrow.INT64
+		if _, err := v.Int64(); err != nil {
+			id = arrow.FLOAT64
+		}
+	case *jsonObject:
+		id = arrow.STRUCT
+	case []interface{}:
+		id = arrow.LIST
+	}
+
+	switch {
+	case t.id == arrow.NULL, t.id == arrow.INT64 && id == arrow.FLOAT64:
+		t.id = id
+	case t.id == arrow.FLOAT64 && id == arrow.INT64:
+	case t.id != id:
+		return errors.Errorf("%s has values of kinds %s and %s", jsonPath(path), jsonKind(t.id), jsonKind(id))
+	}
+
+	switch v := v.(type) {
+	case *jsonObject:
+		if t.children == nil {
+			t.children = make(map[string]*jsonType)
+		}
+		for _, key := range v.keys {
+			child, ok := t.children[key]
+			if !ok {
+				child = &jsonType{}
+				t.children[key] = child
+				t.fields = append(t.fields, key)
+			}
+			if err := child.add(joinJSONPath(path, key), v.values[key]); err != nil {
+				return err
+			}
+		}
+	case []interface{}:
+		if t.elem == nil {
+			t.elem = &jsonType{}
+		}
+		for _, elem := range v {
+			if err := t.elem.add(path+"[]", elem); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// dataType returns the Arrow type of the inferred type.
+func (t *jsonType) dataType() arrow.DataType {
+	switch t.id {
+	case arrow.BOOL:
+		return arrow.FixedWidthTypes.Boolean
+	case arrow.INT64:
+		return arrow.PrimitiveTypes.Int64
+	case arrow.FLOAT64:
+		return arrow.PrimitiveTypes.Float64
+	case arrow.STRUCT:
+		return arrow.StructOf(t.schema().Fields()...)
+	case arrow.LIST:
+		return arrow.ListOf(t.elem.dataType())
+	default:
+		return arrow.BinaryTypes.String
+	}
+}
+
+// schema returns a schema with a field for each key of the inferred object type.
+func (t *jsonType) schema() *arrow.Schema {
+	fields := make([]arrow.Field, len(t.fields))
+	for i, key := range t.fields {
+		fields[i] = arrow.Field{Name: key, Type: t.children[key].dataType(), Nullable: true}
+	}
+	return arrow.NewSchema(fields, nil)
+}
+
+// jsonKind returns the name of the JSON values inferred as id.
+func jsonKind(id arrow.Type) string {
+	switch id {
+	case arrow.BOOL:
+		return "boolean"
+	case arrow.STRING:
+		return "string"
+	case arrow.INT64, arrow.FLOAT64:
+		return "number"
+	case arrow.STRUCT:
+		return "object"
+	case arrow.LIST:
+		return "array"
+	default:
+		return "null"
+	}
+}
+
+func joinJSONPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+func jsonPath(path string) string {
+	if path == "" {
+		return "record"
+	}
+	return "key " + path
+}
+
+// canReadJSON returns true if ReadJSONLines can read values of dtype.
+func canReadJSON(dtype arrow.DataType) bool {
+	switch dt := dtype.(type) {
+	case *arrow.StructType:
+		for _, field := range dt.Fields() {
+			if !canReadJSON(field.Type) {
+				return false
+			}
+		}
+		return true
+	case *arrow.ListType:
+		return canReadJSON(dt.Elem())
+	}
+	return isNumeric(dtype) || isTemporal(dtype) || dtype.ID() == arrow.BOOL || dtype.ID() == arrow.STRING
+}
+
+// jsonToValue converts the JSON value v under the key path to the Go type of dtype.
+// Objects are converted to a []interface{} with the values of the fields of a struct type.
+func jsonToValue(path string, v interface{}, dtype arrow.DataType) (interface{}, error) {
+	if v == nil {
+		return nil, nil
+	}
+
+	cannotRead := func() error {
+		return errors.Errorf("%s: cannot read %s as %v", jsonPath(path), jsonKind(jsonValueType(v)), dtype)
+	}
+	switch dt := dtype.(type) {
+	case *arrow.StructType:
+		obj, ok := v.(*jsonObject)
+		if !ok {
+			return nil, cannotRead()
+		}
+		values := make([]interface{}, len(dt.Fields()))
+		for i, field := range dt.Fields() {
+			var err error
+			if values[i], err = jsonToValue(joinJSONPath(path, field.Name), obj.values[field.Name], field.Type); err != nil {
+				return nil, err
+			}
+		}
+		return values, nil
+
+	case *arrow.ListType:
+		elems, ok := v.([]interface{})
+		if !ok {
+			return nil, cannotRead()
+		}
+		values := make([]interface{}, len(elems))
+		for i, elem := range elems {
+			var err error
+			if values[i], err = jsonToValue(path+"[]", elem, dt.Elem()); err != nil {
+				return nil, err
+			}
+		}
+		return values, nil
+	}
+
+	switch v := v.(type) {
+	case bool:
+		if dtype.ID() == arrow.BOOL {
+			return v, nil
+		}
+	case string:
+		switch {
+		case dtype.ID() == arrow.STRING:
+			return v, nil
+		case isTemporal(dtype):
+			t, err := time.Parse(time.RFC3339Nano, v)
+			if err != nil && isDate(dtype) {
+				t, err = time.Parse("2006-01-02", v)
+			}
+			if err != nil {
+				return nil, errors.Errorf("%s: cannot read %q as %v", jsonPath(path), v, dtype)
+			}
+			return fromTime(t, dtype)
+		}
+	case json.Number:
+		var (
+			n   interface{}
+			err error
+		)
+		switch {
+		case isSignedInteger(dtype):
+			n, err = strconv.ParseInt(string(v), 10, dtype.(arrow.FixedWidthDataType).BitWidth())
+		case isUnsignedInteger(dtype):
+			n, err = strconv.ParseUint(string(v), 10, dtype.(arrow.FixedWidthDataType).BitWidth())
+		case isFloat(dtype):
+			n, err = strconv.ParseFloat(string(v), dtype.(arrow.FixedWidthDataType).BitWidth())
+		default:
+			return nil, cannotRead()
+		}
+		if err != nil {
+			return nil, errors.Errorf("%s: cannot read %v as %v", jsonPath(path), v, dtype)
+		}
+		return castNumericValue(n, dtype)
+	}
+	return nil, cannotRead()
+}
+
+// jsonValueType returns the type a JSON value is inferred as.
+func jsonValueType(v interface{}) arrow.Type {
+	t := &jsonType{}
+	t.add("", v)
+	return t.id
+}
+
+// appendJSONValue appends the JSON encoding of the value v of type dtype to buf.
+func appendJSONValue(buf []byte, v interface{}, dtype arrow.DataType) ([]byte, error) {
+	if v == nil {
+		return append(buf, "null"...), nil
+	}
+
+	switch dt := dtype.(type) {
+	case *arrow.StructType:
+		buf = append(buf, '{')
+		for i, field := range dt.Fields() {
+			if i > 0 {
+				buf = append(buf, ',')
+			}
+			buf = appendJSONString(buf, field.Name)
+			buf = append(buf, ':')
+			var err error
+			if buf, err = appendJSONValue(buf, v.([]interface{})[i], field.Type); err != nil {
+				return nil, err
+			}
+		}
+		return append(buf, '}'), nil
+
+	case *arrow.ListType:
+		buf = append(buf, '[')
+		for i, elem := range v.([]interface{}) {
+			if i > 0 {
+				buf = append(buf, ',')
+			}
+			var err error
+			if buf, err = appendJSONValue(buf, elem, dt.Elem()); err != nil {
+				return nil, err
+			}
+		}
+		return append(buf, ']'), nil
+
+	case *arrow.TimestampType:
+		loc, err := timeops.Location(dt)
+		if err != nil {
+			return nil, err
+		}
+		return appendJSONString(buf, toTime(v, dt).In(loc).Format(time.RFC3339Nano)), nil
+
+	case *arrow.Date32Type, *arrow.Date64Type:
+		return appendJSONString(buf, toTime(v, dt).Format("2006-01-02")), nil
+
+	case *arrow.Time32Type:
+		d := time.Duration(v.(arrow.Time32)) * temporal.UnitDuration(dt.Unit)
+		return appendJSONString(buf, time.Unix(0, 0).UTC().Add(d).Format("15:04:05.999999999")), nil
+
+	case *arrow.Time64Type:
+		d := time.Duration(v.(arrow.Time64)) * temporal.UnitDuration(dt.Unit)
+		return appendJSONString(buf, time.Unix(0, 0).UTC().Add(d).Format("15:04:05.999999999")), nil
+	}
+
+	switch v := v.(type) {
+	case bool:
+		return strconv.AppendBool(buf, v), nil
+	case string:
+		return appendJSONString(buf, v), nil
+	case float32:
+		return appendJSONFloat(buf, float64(v), 32), nil
+	case float64:
+		return appendJSONFloat(buf, v, 64), nil
+	}
+	if isSignedInteger(dtype) {
+		i, err := castNumericValue(v, arrow.PrimitiveTypes.Int64)
+		if err != nil {
+			return nil, err
+		}
+		return strconv.AppendInt(buf, i.(int64), 10), nil
+	}
+	if isUnsignedInteger(dtype) {
+		u, err := castNumericValue(v, arrow.PrimitiveTypes.Uint64)
+		if err != nil {
+			return nil, err
+		}
+		return strconv.AppendUint(buf, u.(uint64), 10), nil
+	}
+	return nil, errors.Errorf("bullseye/dataframe: cannot write %v as JSON", dtype)
+}
+
+func appendJSONFloat(buf []byte, f float64, bits int) []byte {
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return append(buf, "null"...)
+	}
+	return strconv.AppendFloat(buf, f, 'g', -1, bits)
+}
+
+func appendJSONString(buf []byte, s string) []byte {
+	// Marshaling a string can't fail.
+	b, _ := json.Marshal(s)
+	return append(buf, b...)
+}
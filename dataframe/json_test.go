@@ -0,0 +1,180 @@
+package dataframe
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/memory"
+)
+
+const testJSONLines = `{"id": 1, "name": "ann", "score": 9.5, "tags": ["a", "b"], "address": {"city": "NYC", "zip": 10001}}
+{"id": 2, "name": null, "score": 7, "tags": [], "address": {"city": "SF"}, "active": true}
+{"id": 3, "score": null, "tags": null, "address": null, "active": false}
+`
+
+func TestReadJSONLines(t *testing.T) {
+	pool := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer pool.AssertSize(t, 0)
+
+	df, err := ReadJSONLines(pool, strings.NewReader(testJSONLines))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer df.Release()
+
+	got := df.Display(-1)
+	want := `rec[0]["id"]: [1 2 3]
+rec[0]["name"]: ["ann" (null) (null)]
+rec[0]["score"]: [9.5 7 (null)]
+rec[0]["tags"]: [["a" "b"] [] (null)]
+rec[0]["address"]: {["NYC" "SF" (null)] [10001 (null) (null)]}
+rec[0]["active"]: [(null) true false]
+`
+	if got != want {
+		t.Fatalf("\ngot=\n%v\nwant=\n%v", got, want)
+	}
+
+	var buf bytes.Buffer
+	if err := WriteJSONLines(&buf, df); err != nil {
+		t.Fatal(err)
+	}
+	wantJSON := `{"id":1,"name":"ann","score":9.5,"tags":["a","b"],"address":{"city":"NYC","zip":10001},"active":null}
+{"id":2,"name":null,"score":7,"tags":[],"address":{"city":"SF","zip":null},"active":true}
+{"id":3,"name":null,"score":null,"tags":null,"address":null,"active":false}
+`
+	if buf.String() != wantJSON {
+		t.Fatalf("\ngot=\n%v\nwant=\n%v", buf.String(), wantJSON)
+	}
+
+	// Writing and reading the JSON Lines again gives the same DataFrame.
+	roundTrip, err := ReadJSONLines(pool, &buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer roundTrip.Release()
+	if got := roundTrip.Display(-1); got != want {
+		t.Fatalf("\ngot=\n%v\nwant=\n%v", got, want)
+	}
+}
+
+func TestReadJSONLinesSchema(t *testing.T) {
+	pool := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer pool.AssertSize(t, 0)
+
+	schema := arrow.NewSchema([]arrow.Field{
+		{Name: "day", Type: arrow.FixedWidthTypes.Date32, Nullable: true},
+		{Name: "at", Type: &arrow.TimestampType{Unit: arrow.Second}, Nullable: true},
+		{Name: "n", Type: arrow.PrimitiveTypes.Uint8, Nullable: true},
+	}, nil)
+	input := `{"day": "2019-06-01", "at": "2019-06-01T10:00:00Z", "n": 7, "ignored": [1]}
+{"day": "2019-06-02T23:00:00Z", "at": null}
+`
+	df, err := ReadJSONLines(pool, strings.NewReader(input), WithSchema(schema))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer df.Release()
+
+	got := df.Display(-1)
+	want := `rec[0]["day"]: [18048 18049]
+rec[0]["at"]: [1559383200 (null)]
+rec[0]["n"]: [7 (null)]
+`
+	if got != want {
+		t.Fatalf("\ngot=\n%v\nwant=\n%v", got, want)
+	}
+
+	var buf bytes.Buffer
+	if err := WriteJSONLines(&buf, df); err != nil {
+		t.Fatal(err)
+	}
+	wantJSON := `{"day":"2019-06-01","at":"2019-06-01T10:00:00Z","n":7}
+{"day":"2019-06-02","at":null,"n":null}
+`
+	if buf.String() != wantJSON {
+		t.Fatalf("\ngot=\n%v\nwant=\n%v", buf.String(), wantJSON)
+	}
+}
+
+func TestReadJSONLinesErrors(t *testing.T) {
+	pool := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer pool.AssertSize(t, 0)
+
+	for _, tc := range []struct {
+		name  string
+		input string
+		opts  []Option
+		want  string
+	}{
+		{
+			name:  "conflicting kinds",
+			input: `{"a": {"b": 1}}` + "\n" + `{"a": {"b": "x"}}`,
+			want:  "bullseye/dataframe: cannot read record 1: key a.b has values of kinds number and string",
+		},
+		{
+			name:  "after the sample",
+			input: `{"a": 1}` + "\n" + `{"a": 1.5}`,
+			opts:  []Option{WithSampleSize(1)},
+			want:  "bullseye/dataframe: cannot read record 1: key a: cannot read 1.5 as int64",
+		},
+		{
+			name:  "not an object",
+			input: `{"a": 1}` + "\n" + `[1]`,
+			want:  "bullseye/dataframe: cannot read record 1, it is not an object",
+		},
+		{
+			name:  "cut off",
+			input: `{"a": [1, 2`,
+			want:  "bullseye/dataframe: cannot read record 0: unexpected end of JSON input",
+		},
+		{
+			name:  "overflow",
+			input: `{"n": 300}`,
+			opts:  []Option{WithSchema(arrow.NewSchema([]arrow.Field{{Name: "n", Type: arrow.PrimitiveTypes.Int8}}, nil))},
+			want:  "bullseye/dataframe: cannot read record 0: key n: cannot read 300 as int8",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			df, err := ReadJSONLines(pool, strings.NewReader(tc.input), tc.opts...)
+			if err == nil {
+				df.Release()
+			}
+			if err == nil || err.Error() != tc.want {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestWriteJSON(t *testing.T) {
+	pool := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer pool.AssertSize(t, 0)
+
+	df, err := NewDataFrameFromMem(pool, Dict{
+		"A": []interface{}{int32(1), nil, int32(3)},
+		"B": []string{"x", "y \"z\"", ""},
+		"C": []float64{0.5, 1e21, 0},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer df.Release()
+
+	for _, tc := range []struct {
+		orient JSONOrient
+		want   string
+	}{
+		{JSONRecords, `[{"A":1,"B":"x","C":0.5},{"A":null,"B":"y \"z\"","C":1e+21},{"A":3,"B":"","C":0}]` + "\n"},
+		{JSONColumns, `{"A":[1,null,3],"B":["x","y \"z\"",""],"C":[0.5,1e+21,0]}` + "\n"},
+	} {
+		var buf bytes.Buffer
+		if err := WriteJSON(&buf, df, WithJSONOrient(tc.orient)); err != nil {
+			t.Fatal(err)
+		}
+		if buf.String() != tc.want {
+			t.Fatalf("%v:\ngot=\n%v\nwant=\n%v", tc.orient, buf.String(), tc.want)
+		}
+	}
+}
@@ -2,6 +2,7 @@ package dataframe
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/apache/arrow/go/arrow"
 	"github.com/apache/arrow/go/arrow/array"
@@ -43,6 +44,77 @@ func (m *Mutator) Drop(names ...string) MutationFunc {
 	}
 }
 
+// Rename the given DataFrame columns, mapping old names to new names.
+// Only the fields change, the data of the columns is shared.
+// It is an error for an old name to not exist or for two columns to end up with the same name.
+func (m *Mutator) Rename(names map[string]string) MutationFunc {
+	return func(df *DataFrame) (*DataFrame, error) {
+		for oldName := range names {
+			if df.Column(oldName) == nil {
+				return nil, errors.Errorf("bullseye/mutations: column %s does not exist", oldName)
+			}
+		}
+
+		// Check the renamed columns in the order of the columns so the error doesn't depend on the map order.
+		dfCols := df.Columns()
+		renamedFrom := make(map[string]string, len(names))
+		for i := range dfCols {
+			oldName := dfCols[i].Name()
+			newName, ok := names[oldName]
+			if !ok {
+				continue
+			}
+			if _, renamed := names[newName]; !renamed && df.Column(newName) != nil {
+				return nil, errors.Errorf("bullseye/mutations: cannot rename %s to %s, column %s exists", oldName, newName, newName)
+			}
+			if other, ok := renamedFrom[newName]; ok {
+				return nil, errors.Errorf("bullseye/mutations: cannot rename %s and %s to %s", other, oldName, newName)
+			}
+			renamedFrom[newName] = oldName
+		}
+
+		cols := make([]array.Column, 0, len(dfCols))
+		defer func() {
+			for i := range cols {
+				cols[i].Release()
+			}
+		}()
+
+		for i := range dfCols {
+			field := dfCols[i].Field()
+			if newName, ok := names[field.Name]; ok {
+				field.Name = newName
+			}
+			cols = append(cols, *array.NewColumn(field, dfCols[i].Data()))
+		}
+
+		return NewDataFrameFromShape(m.mem, cols, df.NumRows())
+	}
+}
+
+// Reorder moves the given DataFrame columns to the front in the order of names.
+// The other columns follow in their current order. The data of the columns is shared.
+// It is an error for a name to not exist or to be given more than once.
+func (m *Mutator) Reorder(names ...string) MutationFunc {
+	return func(df *DataFrame) (*DataFrame, error) {
+		set := make(map[string]struct{}, len(names))
+		for _, name := range names {
+			if _, ok := set[name]; ok {
+				return nil, errors.Errorf("bullseye/mutations: column %s is given more than once", name)
+			}
+			if df.Column(name) == nil {
+				return nil, errors.Errorf("bullseye/mutations: column %s does not exist", name)
+			}
+			set[name] = struct{}{}
+		}
+
+		cols := df.SelectColumns(names...)
+		cols = append(cols, df.RejectColumns(names...)...)
+
+		return NewDataFrameFromShape(m.mem, cols, df.NumRows())
+	}
+}
+
 // Slice creates a new DataFrame consisting of rows[beg:end].
 func (m *Mutator) Slice(beg, end int64) MutationFunc {
 	return func(df *DataFrame) (*DataFrame, error) {
@@ -68,10 +140,56 @@ func (m *Mutator) Slice(beg, end int64) MutationFunc {
 	}
 }
 
+// JoinValidation is the expected relationship between the keys of two DataFrames being joined.
+type JoinValidation int
+
+const (
+	// ManyToMany does not check the uniqueness of the keys. This is the default.
+	ManyToMany JoinValidation = iota
+	// OneToOne checks that the keys are unique in both the left and right DataFrames.
+	OneToOne
+	// OneToMany checks that the keys are unique in the left DataFrame.
+	OneToMany
+	// ManyToOne checks that the keys are unique in the right DataFrame.
+	ManyToOne
+)
+
+func (v JoinValidation) String() string {
+	switch v {
+	case ManyToMany:
+		return "many_to_many"
+	case OneToOne:
+		return "one_to_one"
+	case OneToMany:
+		return "one_to_many"
+	case ManyToOne:
+		return "many_to_one"
+	default:
+		return fmt.Sprintf("JoinValidation(%d)", int(v))
+	}
+}
+
+// These are the values of the indicator column added by WithIndicator.
+const (
+	// IndicatorLeftOnly marks a row that only came from the left DataFrame.
+	IndicatorLeftOnly = "left_only"
+	// IndicatorRightOnly marks a row that only came from the right DataFrame.
+	IndicatorRightOnly = "right_only"
+	// IndicatorBoth marks a row that came from both DataFrames.
+	IndicatorBoth = "both"
+)
+
 // leftJoinConfig are the config params for LeftJoin.
 type leftJoinConfig struct {
-	lsuffix string
-	rsuffix string
+	lsuffix    string
+	rsuffix    string
+	nullsEqual bool
+	validation JoinValidation
+	indicator  string
+
+	// swapped is true when the left and right DataFrames have been
+	// swapped internally, i.e. a RightJoin being done as a LeftJoin.
+	swapped bool
 }
 
 // newLeftJoinConfig creates a new config using options and validates it.
@@ -90,9 +208,38 @@ func (c *leftJoinConfig) validate() error {
 	if c.lsuffix == c.rsuffix {
 		return errors.Errorf("lsuffix (%s) cannot be the same as rsuffix (%s)", c.lsuffix, c.rsuffix)
 	}
+	switch c.validation {
+	case ManyToMany, OneToOne, OneToMany, ManyToOne:
+	default:
+		return errors.Errorf("invalid join validation: %v", c.validation)
+	}
 	return nil
 }
 
+// swap reverses the sides of the config so a join can be done with the left and right DataFrames swapped.
+func (c *leftJoinConfig) swap() {
+	c.lsuffix, c.rsuffix = c.rsuffix, c.lsuffix
+	c.swapped = !c.swapped
+}
+
+// sideNames returns the names of the left and right DataFrames as the caller of the join sees them.
+func (c *leftJoinConfig) sideNames() (string, string) {
+	if c.swapped {
+		return "right", "left"
+	}
+	return "left", "right"
+}
+
+// uniqueSides returns whether the keys of the left and right DataFrames must be unique.
+func (c *leftJoinConfig) uniqueSides() (bool, bool) {
+	left := c.validation == OneToOne || c.validation == OneToMany
+	right := c.validation == OneToOne || c.validation == ManyToOne
+	if c.swapped {
+		return right, left
+	}
+	return left, right
+}
+
 // defaultLeftJoinConfig returns the default defaultLeftJoinConfig.
 func defaultLeftJoinConfig() *leftJoinConfig {
 	return &leftJoinConfig{
@@ -125,16 +272,59 @@ func WithRsuffix(rsuffix string) Option {
 	}
 }
 
+// WithNullsEqual configures a join to treat nil elements in the key columns as equal
+// to each other, similar to SQL's IS NOT DISTINCT FROM.
+func WithNullsEqual() Option {
+	return func(p interface{}) error {
+		o, ok := p.(*leftJoinConfig)
+		if !ok {
+			return errors.Errorf("cannot apply WithNullsEqual to: %T", p)
+		}
+		o.nullsEqual = true
+		return nil
+	}
+}
+
+// WithValidate configures a join to check that the keys are unique on the side(s)
+// required by validation. The join will return an error naming the duplicate keys
+// when they are not. Like matching, nil keys are only considered duplicates of
+// each other when WithNullsEqual is provided.
+func WithValidate(validation JoinValidation) Option {
+	return func(p interface{}) error {
+		o, ok := p.(*leftJoinConfig)
+		if !ok {
+			return errors.Errorf("cannot apply WithValidate to: %T", p)
+		}
+		o.validation = validation
+		return nil
+	}
+}
+
+// WithIndicator configures a join to add a string column with the provided name
+// that says whether each row came from the left DataFrame only (IndicatorLeftOnly),
+// the right DataFrame only (IndicatorRightOnly) or both (IndicatorBoth).
+func WithIndicator(name string) Option {
+	return func(p interface{}) error {
+		o, ok := p.(*leftJoinConfig)
+		if !ok {
+			return errors.Errorf("cannot apply WithIndicator to: %T", p)
+		}
+		if name == "" {
+			return errors.New("indicator column name cannot be empty")
+		}
+		o.indicator = name
+		return nil
+	}
+}
+
 // RightJoin returns a DataFrame containing the right join of two DataFrames.
-// Acts like SQL in that nil elements are treated as unknown so nil != nil.
+// Acts like SQL in that nil elements are treated as unknown so nil != nil,
+// unless WithNullsEqual is provided.
 func (m *Mutator) RightJoin(rightDf *DataFrame, columnNames []string, opts ...Option) MutationFunc {
 	// RightJoin is just a LeftJoin in reverse order.
 	cfg, err := newLeftJoinConfig(opts...)
 	if err == nil {
-		// Swap lsuffix and rsuffix
-		lsuffix := cfg.lsuffix
-		cfg.lsuffix = cfg.rsuffix
-		cfg.rsuffix = lsuffix
+		cfg.swap()
 	}
 
 	return func(leftDf *DataFrame) (*DataFrame, error) {
@@ -154,7 +344,8 @@ func (m *Mutator) RightJoin(rightDf *DataFrame, columnNames []string, opts ...Op
 }
 
 // LeftJoin returns a DataFrame containing the left join of two DataFrames.
-// Acts like SQL in that nil elements are treated as unknown so nil != nil.
+// Acts like SQL in that nil elements are treated as unknown so nil != nil,
+// unless WithNullsEqual is provided.
 func (m *Mutator) LeftJoin(rightDf *DataFrame, columnNames []string, opts ...Option) MutationFunc {
 	cfg, err := newLeftJoinConfig(opts...)
 	return func(leftDf *DataFrame) (*DataFrame, error) {
@@ -180,11 +371,13 @@ type joinFuncConfig struct {
 	additionalLeftColsLen  int
 	additionalRightColsLen int
 	columnNames            []string
+	nullsEqual             bool
+	swapped                bool
+	indicatorIndex         int // -1 when there is no indicator column
 	leftColumns            []array.Column
 	rightColumns           []array.Column
 	schema                 *arrow.Schema
-	recordBuilder          *array.RecordBuilder
-	smartBuilder           *SmartBuilder
+	builder                *columnBuilder
 }
 
 // newJoinFuncConfig builds up all the data needed to do a join.
@@ -193,6 +386,8 @@ func (m *Mutator) newJoinFuncConfig(cfg *leftJoinConfig, leftDf *DataFrame, righ
 	jc := &joinFuncConfig{
 		mutator:      m,
 		columnNames:  columnNames,
+		nullsEqual:   cfg.nullsEqual,
+		swapped:      cfg.swapped,
 		leftColumns:  make([]array.Column, 0, leftDf.NumCols()),
 		rightColumns: make([]array.Column, 0, rightDf.NumCols()),
 	}
@@ -215,6 +410,10 @@ func (m *Mutator) newJoinFuncConfig(cfg *leftJoinConfig, leftDf *DataFrame, righ
 	jc.matchingLeftColsLen = len(jc.leftColumns)
 	jc.matchingRightColsLen = len(jc.rightColumns)
 
+	if err := validateJoinKeys(cfg, jc); err != nil {
+		return nil, err
+	}
+
 	// We will end up needing to iterate over the columns for left in step so join them back together.
 	jc.leftColumns = append(jc.leftColumns, leftDf.RejectColumns(columnNames...)...)
 	jc.rightColumns = append(jc.rightColumns, rightDf.RejectColumns(columnNames...)...)
@@ -248,25 +447,119 @@ func (m *Mutator) newJoinFuncConfig(cfg *leftJoinConfig, leftDf *DataFrame, righ
 		fields = append(fields, fcopy)
 	}
 
+	jc.indicatorIndex = -1
+	if cfg.indicator != "" {
+		for i := range fields {
+			if fields[i].Name == cfg.indicator {
+				return nil, errors.Errorf("bullseye/mutations: indicator column %s conflicts with an existing column", cfg.indicator)
+			}
+		}
+		jc.indicatorIndex = len(fields)
+		fields = append(fields, arrow.Field{Name: cfg.indicator, Type: arrow.BinaryTypes.String})
+	}
+
 	jc.schema = arrow.NewSchema(fields, nil)
-	jc.recordBuilder = array.NewRecordBuilder(m.mem, jc.schema)
-	jc.smartBuilder = NewSmartBuilder(jc.recordBuilder, jc.schema)
+	builder, err := newColumnBuilder(m.mem, jc.schema)
+	if err != nil {
+		return nil, err
+	}
+	jc.builder = builder
 
 	return jc, nil
 }
 
+// appendIndicator appends the indicator value for a row that was built from the left and/or
+// right DataFrames (as passed to newJoinFuncConfig), if the join has an indicator column.
+func (jc *joinFuncConfig) appendIndicator(fromLeft, fromRight bool) {
+	if jc.indicatorIndex < 0 {
+		return
+	}
+	if jc.swapped {
+		fromLeft, fromRight = fromRight, fromLeft
+	}
+	var v string
+	switch {
+	case fromLeft && fromRight:
+		v = IndicatorBoth
+	case fromLeft:
+		v = IndicatorLeftOnly
+	default:
+		v = IndicatorRightOnly
+	}
+	jc.builder.Append(jc.indicatorIndex, v)
+}
+
+// validateJoinKeys checks the key columns are unique on the sides required by the join validation.
+func validateJoinKeys(cfg *leftJoinConfig, jc *joinFuncConfig) error {
+	if cfg.validation == ManyToMany {
+		return nil
+	}
+	if len(jc.columnNames) == 0 {
+		return errors.Errorf("bullseye/mutations: join validation %s requires key columns", cfg.validation)
+	}
+
+	leftUnique, rightUnique := cfg.uniqueSides()
+	leftName, rightName := cfg.sideNames()
+	if leftUnique {
+		if err := checkUniqueKeys(jc.leftColumns[:jc.matchingLeftColsLen], jc.columnNames, cfg.nullsEqual); err != nil {
+			return errors.Wrapf(err, "bullseye/mutations: join validation %s failed for %s DataFrame", cfg.validation, leftName)
+		}
+	}
+	if rightUnique {
+		if err := checkUniqueKeys(jc.rightColumns[:jc.matchingRightColsLen], jc.columnNames, cfg.nullsEqual); err != nil {
+			return errors.Wrapf(err, "bullseye/mutations: join validation %s failed for %s DataFrame", cfg.validation, rightName)
+		}
+	}
+	return nil
+}
+
+// maxReportedKeys is the max number of offending keys reported in a join validation error.
+const maxReportedKeys = 10
+
+// checkUniqueKeys returns an error listing the duplicate keys found in the key columns.
+func checkUniqueKeys(keyColumns []array.Column, columnNames []string, nullsEqual bool) error {
+	seen := make(map[string]int)
+	var duplicates []string
+
+	it := iterator.NewStepIteratorForColumns(keyColumns)
+	defer it.Release()
+	for it.Next() {
+		values := it.Values().Values
+		if !nullsEqual && hasNil(values) {
+			// nil is unknown so it can't be a duplicate of anything.
+			continue
+		}
+		key := rowKey(values)
+		seen[key]++
+		if seen[key] == 2 {
+			duplicates = append(duplicates, formatKey(columnNames, values))
+		}
+	}
+
+	if len(duplicates) == 0 {
+		return nil
+	}
+
+	reported := duplicates
+	more := ""
+	if len(reported) > maxReportedKeys {
+		reported = reported[:maxReportedKeys]
+		more = fmt.Sprintf(" and %d more", len(duplicates)-maxReportedKeys)
+	}
+	return errors.Errorf("duplicate keys %s%s", strings.Join(reported, ", "), more)
+}
+
 func (jc *joinFuncConfig) Release() {
-	jc.recordBuilder.Release()
+	jc.builder.Release()
 }
 
 func (jc *joinFuncConfig) buildDataFrame() (*DataFrame, error) {
-	rec := jc.recordBuilder.NewRecord()
-	defer rec.Release()
-	return NewDataFrame(jc.mutator.mem, jc.schema, rec.Columns())
+	return jc.builder.NewDataFrame()
 }
 
 // This leftJoin implementation is shared by both LeftJoin and RightJoin.
-// Acts like SQL in that nil elements are treated as unknown so nil != nil.
+// Acts like SQL in that nil elements are treated as unknown so nil != nil,
+// unless the join was configured with WithNullsEqual.
 func (m *Mutator) leftJoin(cfg *leftJoinConfig, leftDf *DataFrame, rightDf *DataFrame, columnNames []string) (*joinFuncConfig, error) {
 	data, err := m.newJoinFuncConfig(cfg, leftDf, rightDf, columnNames, true)
 	if err != nil {
@@ -280,21 +573,23 @@ func (m *Mutator) leftJoin(cfg *leftJoinConfig, leftDf *DataFrame, rightDf *Data
 
 			// Add all the values from left columns
 			for i := range leftStepValues.Values {
-				data.smartBuilder.Append(cIdx, leftStepValues.Values[i])
+				data.builder.Append(cIdx, leftStepValues.Values[i])
 				cIdx++
 			}
 
 			for i := 0; i < data.additionalRightColsLen; i++ {
-				// cIdx is the offset to the start of the additionalRightCols in smartBuilder
-				data.smartBuilder.Append(cIdx+i, nil)
+				// cIdx is the offset to the start of the additionalRightCols in builder
+				data.builder.Append(cIdx+i, nil)
 			}
+			data.appendIndicator(true, false)
 		}
 	})
 
 	return data, nil
 }
 
-// Acts like SQL in that nil elements are treated as unknown so nil != nil.
+// Acts like SQL in that nil elements are treated as unknown so nil != nil,
+// unless the join was configured with WithNullsEqual.
 func sharedLeftJoinLogic(data *joinFuncConfig, iterationEndFunc func(bool, *iterator.StepValue)) {
 	// What I want here is a step iterator for the matchingLeftCols.
 	leftMatchingIterator := iterator.NewStepIteratorForColumns(data.leftColumns)
@@ -316,7 +611,7 @@ func sharedLeftJoinLogic(data *joinFuncConfig, iterationEndFunc func(bool, *iter
 				// check if the row on the left,
 				// matches with the rows on the right.
 				for columnIndex := range data.columnNames {
-					match = match && stepValueEqAt(leftStepValues, rightStepValues, columnIndex)
+					match = match && stepValueEqAt(leftStepValues, rightStepValues, columnIndex, data.nullsEqual)
 				}
 
 				if match {
@@ -329,16 +624,17 @@ func sharedLeftJoinLogic(data *joinFuncConfig, iterationEndFunc func(bool, *iter
 
 					// Add all the values from left columns
 					for i := range leftStepValues.Values {
-						data.smartBuilder.Append(cIdx, leftStepValues.Values[i])
+						data.builder.Append(cIdx, leftStepValues.Values[i])
 						cIdx++
 					}
 
 					// Do the dance we did above and append the elements to each column for additionalRightCols.
 					for i := data.matchingRightColsLen; i < len(data.rightColumns); i++ {
 						value := rightStepValues.Values[i]
-						data.smartBuilder.Append(cIdx, value)
+						data.builder.Append(cIdx, value)
 						cIdx++
 					}
+					data.appendIndicator(true, true)
 				}
 			}
 		}()
@@ -347,7 +643,8 @@ func sharedLeftJoinLogic(data *joinFuncConfig, iterationEndFunc func(bool, *iter
 }
 
 // InnerJoin returns a DataFrame containing the inner join of two DataFrames.
-// Acts like SQL in that nil elements are treated as unknown so nil != nil.
+// Acts like SQL in that nil elements are treated as unknown so nil != nil,
+// unless WithNullsEqual is provided.
 func (m *Mutator) InnerJoin(rightDf *DataFrame, columnNames []string, opts ...Option) MutationFunc {
 	cfg, err := newLeftJoinConfig(opts...)
 	return func(leftDf *DataFrame) (*DataFrame, error) {
@@ -370,7 +667,8 @@ func (m *Mutator) InnerJoin(rightDf *DataFrame, columnNames []string, opts ...Op
 
 // OuterJoin returns a DataFrame containing the outer join of two DataFrames.
 // Use union of keys from both frames, similar to a SQL full outer join.
-// Acts like SQL in that nil elements are treated as unknown so nil != nil.
+// Acts like SQL in that nil elements are treated as unknown so nil != nil,
+// unless WithNullsEqual is provided.
 func (m *Mutator) OuterJoin(rightDf *DataFrame, columnNames []string, opts ...Option) MutationFunc {
 	cfg, err := newLeftJoinConfig(opts...)
 	return func(leftDf *DataFrame) (*DataFrame, error) {
@@ -398,22 +696,23 @@ func (m *Mutator) OuterJoin(rightDf *DataFrame, columnNames []string, opts ...Op
 				// Add all the values from right matching columns
 				for i := 0; i < data.matchingRightColsLen; i++ {
 					value := rightStepValues.Values[i]
-					data.smartBuilder.Append(cIdx, value)
+					data.builder.Append(cIdx, value)
 					cIdx++
 				}
 
 				// Add nil for not matching left columns
 				for i := 0; i < data.additionalLeftColsLen; i++ {
-					data.smartBuilder.Append(cIdx, nil)
+					data.builder.Append(cIdx, nil)
 					cIdx++
 				}
 
 				// Add the additional values from the right.
 				for i := data.matchingRightColsLen; i < data.matchingRightColsLen+data.additionalRightColsLen; i++ {
 					value := rightStepValues.Values[i]
-					data.smartBuilder.Append(cIdx, value)
+					data.builder.Append(cIdx, value)
 					cIdx++
 				}
+				data.appendIndicator(false, true)
 			}
 		}
 
@@ -432,7 +731,7 @@ func outerJoinAnyRowsMatch(rightStepValues *iterator.StepValue, data *joinFuncCo
 		// check if the row on the left,
 		// matches with the rows on the right.
 		for columnIndex := range data.columnNames {
-			match = match && stepValueEqAt(leftStepValues, rightStepValues, columnIndex)
+			match = match && stepValueEqAt(leftStepValues, rightStepValues, columnIndex, data.nullsEqual)
 		}
 
 		if match {
@@ -472,13 +771,14 @@ func (m *Mutator) CrossJoin(rightDf *DataFrame, opts ...Option) MutationFunc {
 
 					// Add all columns from both frames.
 					for i := range leftStepValues.Values {
-						data.smartBuilder.Append(cIdx, leftStepValues.Values[i])
+						data.builder.Append(cIdx, leftStepValues.Values[i])
 						cIdx++
 					}
 					for i := range rightStepValues.Values {
-						data.smartBuilder.Append(cIdx, rightStepValues.Values[i])
+						data.builder.Append(cIdx, rightStepValues.Values[i])
 						cIdx++
 					}
+					data.appendIndicator(true, true)
 				}
 			}()
 		}
@@ -487,11 +787,18 @@ func (m *Mutator) CrossJoin(rightDf *DataFrame, opts ...Option) MutationFunc {
 	}
 }
 
-func stepValueEqAt(left *iterator.StepValue, right *iterator.StepValue, i int) bool {
+// stepValueEqAt compares the elements at i in left and right.
+// When nullsEqual is true, nil elements are considered equal to each other.
+func stepValueEqAt(left *iterator.StepValue, right *iterator.StepValue, i int, nullsEqual bool) bool {
 	lElem := StepValueElementAt(left, i)
 	rElem := StepValueElementAt(right, i)
 
-	v, err := lElem.Eq(rElem)
+	eq := lElem.Eq
+	if nullsEqual {
+		eq = lElem.EqStrict
+	}
+
+	v, err := eq(rElem)
 	if err != nil {
 		panic(err)
 	}
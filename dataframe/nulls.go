@@ -0,0 +1,426 @@
+package dataframe
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"reflect"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/memory"
+	"github.com/go-bullseye/bullseye/internal/arrays"
+	"github.com/go-bullseye/bullseye/iterator"
+	"github.com/pkg/errors"
+)
+
+// NullHow is how DropNulls decides to drop a row.
+type NullHow int
+
+const (
+	// AnyNull drops the rows where any of the columns are null.
+	AnyNull NullHow = iota
+	// AllNull drops the rows where all of the columns are null.
+	AllNull
+)
+
+func (h NullHow) String() string {
+	switch h {
+	case AnyNull:
+		return "any"
+	case AllNull:
+		return "all"
+	default:
+		return fmt.Sprintf("NullHow(%d)", int(h))
+	}
+}
+
+// fillConfig are the config params for FillForward and FillBackward.
+type fillConfig struct {
+	limit int
+}
+
+// newFillConfig creates a new config using options.
+func newFillConfig(opts ...Option) (*fillConfig, error) {
+	cfg := &fillConfig{}
+	for _, opt := range opts {
+		if err := opt(cfg); err != nil {
+			return cfg, err
+		}
+	}
+	return cfg, nil
+}
+
+// WithFillLimit configures FillForward or FillBackward to fill at most limit consecutive nulls.
+// The default is no limit.
+func WithFillLimit(limit int) Option {
+	return func(p interface{}) error {
+		o, ok := p.(*fillConfig)
+		if !ok {
+			return errors.Errorf("cannot apply WithFillLimit to: %T", p)
+		}
+		if limit <= 0 {
+			return errors.Errorf("fill limit must be greater than 0, got %d", limit)
+		}
+		o.limit = limit
+		return nil
+	}
+}
+
+// DropNulls returns a DataFrame without the rows that have nulls in the subset of columns.
+// All of the columns are used when subset is empty. Chunks without any nulls are reused.
+func (m *Mutator) DropNulls(subset []string, how NullHow) MutationFunc {
+	return func(df *DataFrame) (*DataFrame, error) {
+		switch how {
+		case AnyNull, AllNull:
+		default:
+			return nil, errors.Errorf("bullseye/mutations: invalid null how %v", how)
+		}
+
+		cols, err := df.subsetColumns(subset)
+		if err != nil {
+			return nil, err
+		}
+
+		nulls := make([]int, df.NumRows())
+		found := false
+		for i := range cols {
+			if cols[i].NullN() == 0 {
+				continue
+			}
+			found = true
+			countNulls(&cols[i], nulls)
+		}
+		if !found {
+			// Nothing to drop so share the columns.
+			return NewDataFrameFromShape(m.mem, df.Columns(), df.NumRows())
+		}
+
+		keep := make([]bool, len(nulls))
+		for i, n := range nulls {
+			switch how {
+			case AnyNull:
+				keep[i] = n == 0
+			case AllNull:
+				keep[i] = n < len(cols)
+			}
+		}
+		return filterDataFrame(m.mem, df, keep)
+	}
+}
+
+// countNulls adds one to nulls for every null in the column using the validity bitmaps of the chunks.
+func countNulls(col *array.Column, nulls []int) {
+	it := iterator.NewChunkIterator(col)
+	defer it.Release()
+
+	offset := 0
+	for it.Next() {
+		chunk := it.Chunk()
+		if chunk.NullN() > 0 {
+			for i := 0; i < chunk.Len() && offset+i < len(nulls); i++ {
+				if chunk.IsNull(i) {
+					nulls[offset+i]++
+				}
+			}
+		}
+		offset += chunk.Len()
+	}
+}
+
+// FillNull returns a DataFrame with the nulls of each column in values replaced by its value.
+// A value must be the Go type of the column, or for numeric columns any number that can be
+// converted to the column type without changing its value, i.e. 0 for a float64 column.
+// Chunks without any nulls are reused.
+func (m *Mutator) FillNull(values map[string]interface{}) MutationFunc {
+	return func(df *DataFrame) (*DataFrame, error) {
+		fills := make(map[string]interface{}, len(values))
+		for name, v := range values {
+			col := df.Column(name)
+			if col == nil {
+				return nil, errors.Errorf("bullseye/mutations: column %s does not exist", name)
+			}
+			fill, err := fillValue(col.DataType(), v)
+			if err != nil {
+				return nil, errors.Wrapf(err, "bullseye/mutations: cannot fill column %s", name)
+			}
+			fills[name] = fill
+		}
+
+		return m.mapNullColumns(df, func(col *array.Column) (*array.Column, error) {
+			fill, ok := fills[col.Name()]
+			if !ok {
+				return nil, nil
+			}
+			return fillNullColumn(m.mem, col, fill)
+		})
+	}
+}
+
+// FillForward returns a DataFrame with the nulls of the columns replaced by the last value before them.
+// All of the columns are filled when columns is empty. Leading nulls are left as is.
+// Chunks without any nulls are reused.
+func (m *Mutator) FillForward(columns []string, opts ...Option) MutationFunc {
+	return m.fillDirection(columns, false, opts...)
+}
+
+// FillBackward returns a DataFrame with the nulls of the columns replaced by the next value after them.
+// All of the columns are filled when columns is empty. Trailing nulls are left as is.
+// Chunks without any nulls are reused.
+func (m *Mutator) FillBackward(columns []string, opts ...Option) MutationFunc {
+	return m.fillDirection(columns, true, opts...)
+}
+
+func (m *Mutator) fillDirection(columns []string, backward bool, opts ...Option) MutationFunc {
+	cfg, err := newFillConfig(opts...)
+	return func(df *DataFrame) (*DataFrame, error) {
+		if err != nil {
+			return nil, err
+		}
+
+		cols, err := df.subsetColumns(columns)
+		if err != nil {
+			return nil, err
+		}
+		names := make(map[string]struct{}, len(cols))
+		for i := range cols {
+			names[cols[i].Name()] = struct{}{}
+		}
+
+		return m.mapNullColumns(df, func(col *array.Column) (*array.Column, error) {
+			if _, ok := names[col.Name()]; !ok {
+				return nil, nil
+			}
+			return fillDirectionColumn(m.mem, col, cfg.limit, backward)
+		})
+	}
+}
+
+// mapNullColumns creates a new DataFrame by passing the columns with nulls through fn.
+// fn returns nil to keep the column as is.
+func (m *Mutator) mapNullColumns(df *DataFrame, fn func(col *array.Column) (*array.Column, error)) (*DataFrame, error) {
+	dfCols := df.Columns()
+	cols := make([]array.Column, 0, len(dfCols))
+	defer func() {
+		for i := range cols {
+			cols[i].Release()
+		}
+	}()
+
+	for i := range dfCols {
+		col := &dfCols[i]
+		if col.NullN() > 0 {
+			newCol, err := fn(col)
+			if err != nil {
+				return nil, err
+			}
+			if newCol != nil {
+				cols = append(cols, *newCol)
+				continue
+			}
+		}
+		col.Retain()
+		cols = append(cols, *col)
+	}
+
+	return NewDataFrameFromShape(m.mem, cols, df.NumRows())
+}
+
+// fillValue checks that v can be used to fill a column of type dtype
+// and returns it as the Go type of the column.
+func fillValue(dtype arrow.DataType, v interface{}) (interface{}, error) {
+	if v == nil {
+		return nil, errors.New("fill value is nil")
+	}
+
+	t, ok := goType(dtype)
+	if !ok {
+		return nil, errors.Errorf("unsupported column type %v", dtype)
+	}
+	if reflect.TypeOf(v) == t {
+		return v, nil
+	}
+
+	if isNumeric(dtype) && exactNumber(v, dtype) {
+		return castNumericValue(v, dtype)
+	}
+
+	return nil, errors.Errorf("%v (%T) is not a valid %v", v, v, dtype)
+}
+
+// exactNumber returns true if the number v converts to the numeric type dtype without changing
+// its value, i.e. integers must be in range and floats exact. NaN and infinities are only floats.
+func exactNumber(v interface{}, dtype arrow.DataType) bool {
+	switch t := v.(type) {
+	case float32:
+		return exactNumber(float64(t), dtype)
+	case float64:
+		if math.IsNaN(t) || math.IsInf(t, 0) {
+			return isFloat(dtype)
+		}
+	}
+	f, ok := bigNumber(v)
+	if !ok {
+		return false
+	}
+
+	switch {
+	case dtype.ID() == arrow.FLOAT32:
+		_, acc := f.Float32()
+		return acc == big.Exact
+	case dtype.ID() == arrow.FLOAT64:
+		_, acc := f.Float64()
+		return acc == big.Exact
+	case !f.IsInt():
+		return false
+	case isSignedInteger(dtype):
+		i, acc := f.Int64()
+		return acc == big.Exact && checkNumericRange(i, dtype) == nil
+	default:
+		u, acc := f.Uint64()
+		return acc == big.Exact && checkNumericRange(u, dtype) == nil
+	}
+}
+
+// bigNumber returns the Go number v as a big.Float. It returns false if v is not a number,
+// or is NaN or infinite.
+func bigNumber(v interface{}) (*big.Float, bool) {
+	f := new(big.Float)
+	switch t := v.(type) {
+	case int:
+		f.SetInt64(int64(t))
+	case int8:
+		f.SetInt64(int64(t))
+	case int16:
+		f.SetInt64(int64(t))
+	case int32:
+		f.SetInt64(int64(t))
+	case int64:
+		f.SetInt64(t)
+	case uint:
+		f.SetUint64(uint64(t))
+	case uint8:
+		f.SetUint64(uint64(t))
+	case uint16:
+		f.SetUint64(uint64(t))
+	case uint32:
+		f.SetUint64(uint64(t))
+	case uint64:
+		f.SetUint64(t)
+	case float32:
+		return bigNumber(float64(t))
+	case float64:
+		if math.IsNaN(t) || math.IsInf(t, 0) {
+			return nil, false
+		}
+		f.SetFloat64(t)
+	default:
+		return nil, false
+	}
+	return f, true
+}
+
+// fillNullColumn returns a copy of the column with the nulls replaced by fill.
+// Chunks without nulls are reused. The column must be released.
+func fillNullColumn(mem memory.Allocator, col *array.Column, fill interface{}) (*array.Column, error) {
+	chunks := make([]array.Interface, 0, len(col.Data().Chunks()))
+	defer func() {
+		for _, chunk := range chunks {
+			chunk.Release()
+		}
+	}()
+
+	for _, chunk := range col.Data().Chunks() {
+		if chunk.NullN() == 0 {
+			chunk.Retain()
+			chunks = append(chunks, chunk)
+			continue
+		}
+
+		values := arrayValues(chunk)
+		for i := range values {
+			if values[i] == nil {
+				values[i] = fill
+			}
+		}
+		filled, err := newArrayFromValues(mem, chunk.DataType(), values)
+		if err != nil {
+			return nil, err
+		}
+		chunks = append(chunks, filled)
+	}
+
+	return newColumnFromChunks(col.Field(), chunks), nil
+}
+
+// fillDirectionColumn returns a copy of the column with the nulls replaced by the previous value,
+// or the next value when backward is true. At most limit consecutive nulls are filled, unless limit is 0.
+// Chunks that did not change are reused. The column must be released.
+func fillDirectionColumn(mem memory.Allocator, col *array.Column, limit int, backward bool) (*array.Column, error) {
+	dataChunks := col.Data().Chunks()
+	chunks := make([]array.Interface, len(dataChunks))
+	defer func() {
+		for _, chunk := range chunks {
+			if chunk != nil {
+				chunk.Release()
+			}
+		}
+	}()
+
+	var last interface{}
+	filledRun := 0
+	for n := range dataChunks {
+		c := n
+		if backward {
+			c = len(dataChunks) - 1 - n
+		}
+		chunk := dataChunks[c]
+
+		if chunk.NullN() == 0 {
+			if chunk.Len() > 0 {
+				i := chunk.Len() - 1
+				if backward {
+					i = 0
+				}
+				last = arrays.Value(chunk, i)
+				filledRun = 0
+			}
+			chunk.Retain()
+			chunks[c] = chunk
+			continue
+		}
+
+		values := arrayValues(chunk)
+		changed := false
+		for k := range values {
+			i := k
+			if backward {
+				i = len(values) - 1 - k
+			}
+			if values[i] != nil {
+				last = values[i]
+				filledRun = 0
+				continue
+			}
+			if last == nil || (limit > 0 && filledRun >= limit) {
+				continue
+			}
+			values[i] = last
+			filledRun++
+			changed = true
+		}
+
+		if !changed {
+			chunk.Retain()
+			chunks[c] = chunk
+			continue
+		}
+		filled, err := newArrayFromValues(mem, chunk.DataType(), values)
+		if err != nil {
+			return nil, err
+		}
+		chunks[c] = filled
+	}
+
+	return newColumnFromChunks(col.Field(), chunks), nil
+}
@@ -0,0 +1,330 @@
+package dataframe
+
+import (
+	"math"
+	"testing"
+
+	"github.com/apache/arrow/go/arrow/memory"
+)
+
+func buildNullsFrame(pool *memory.CheckedAllocator, t *testing.T) *DataFrame {
+	df, err := NewDataFrameFromMem(pool, Dict{
+		"A": []interface{}{int32(1), nil, nil, nil, int32(5), nil},
+		"B": []interface{}{nil, "b", nil, "d", "e", nil},
+		"C": []float64{1, 2, 3, 4, 5, 6},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return df
+}
+
+func TestDropNulls(t *testing.T) {
+	pool := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer pool.AssertSize(t, 0)
+
+	df := buildNullsFrame(pool, t)
+	defer df.Release()
+
+	testCases := []struct {
+		name   string
+		subset []string
+		how    NullHow
+		want   string
+	}{
+		{
+			name: "any",
+			how:  AnyNull,
+			want: `rec[0]["A"]: [5]
+rec[0]["B"]: ["e"]
+rec[0]["C"]: [5]
+`,
+		},
+		{
+			name:   "all",
+			subset: []string{"A", "B"},
+			how:    AllNull,
+			want: `rec[0]["A"]: [1 (null)]
+rec[0]["B"]: [(null) "b"]
+rec[0]["C"]: [1 2]
+rec[1]["A"]: [(null) 5]
+rec[1]["B"]: ["d" "e"]
+rec[1]["C"]: [4 5]
+`,
+		},
+		{
+			name:   "no nulls",
+			subset: []string{"C"},
+			how:    AnyNull,
+			want: `rec[0]["A"]: [1 (null) (null) (null) 5 (null)]
+rec[0]["B"]: [(null) "b" (null) "d" "e" (null)]
+rec[0]["C"]: [1 2 3 4 5 6]
+`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := df.DropNulls(tc.subset, tc.how)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer got.Release()
+
+			if s := got.Display(-1); s != tc.want {
+				t.Fatalf("\ngot=\n%v\nwant=\n%v", s, tc.want)
+			}
+		})
+	}
+}
+
+func TestFillNull(t *testing.T) {
+	pool := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer pool.AssertSize(t, 0)
+
+	df := buildNullsFrame(pool, t)
+	defer df.Release()
+
+	filledDf, err := df.FillNull(map[string]interface{}{"A": 0, "B": "z"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer filledDf.Release()
+
+	got := filledDf.Display(-1)
+	want := `rec[0]["A"]: [1 0 0 0 5 0]
+rec[0]["B"]: ["z" "b" "z" "d" "e" "z"]
+rec[0]["C"]: [1 2 3 4 5 6]
+`
+	if got != want {
+		t.Fatalf("\ngot=\n%v\nwant=\n%v", got, want)
+	}
+
+	// Columns without nulls are shared.
+	if filledDf.Column("C").Data() != df.Column("C").Data() {
+		t.Fatal("expected column C to be shared")
+	}
+
+	errorCases := []struct {
+		values map[string]interface{}
+		want   string
+	}{
+		{
+			values: map[string]interface{}{"A": 1.5},
+			want:   "bullseye/mutations: cannot fill column A: 1.5 (float64) is not a valid int32",
+		},
+		{
+			values: map[string]interface{}{"B": 1},
+			want:   "bullseye/mutations: cannot fill column B: 1 (int) is not a valid utf8",
+		},
+		{
+			values: map[string]interface{}{"Z": 1},
+			want:   "bullseye/mutations: column Z does not exist",
+		},
+	}
+	for _, tc := range errorCases {
+		_, err := df.FillNull(tc.values)
+		if err == nil {
+			t.Fatalf("expected an error for %v", tc.values)
+		}
+		if got := err.Error(); got != tc.want {
+			t.Fatalf("got=%q, want=%q", got, tc.want)
+		}
+	}
+}
+
+func TestFillNullNumbers(t *testing.T) {
+	pool := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer pool.AssertSize(t, 0)
+
+	df, err := NewDataFrameFromMem(pool, Dict{
+		"F32": []interface{}{float32(1), nil},
+		"F64": []interface{}{float64(1), nil},
+		"I8":  []interface{}{int8(1), nil},
+		"U64": []interface{}{uint64(1), nil},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer df.Release()
+
+	filledDf, err := df.FillNull(map[string]interface{}{
+		"F32": math.NaN(),
+		"F64": int64(1) << 53,
+		"I8":  -128.0,
+		"U64": uint64(math.MaxUint64),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer filledDf.Release()
+
+	got := filledDf.Display(-1)
+	want := `rec[0]["F32"]: [1 NaN]
+rec[0]["F64"]: [1 9.007199254740992e+15]
+rec[0]["I8"]: [1 -128]
+rec[0]["U64"]: [1 18446744073709551615]
+`
+	if got != want {
+		t.Fatalf("\ngot=\n%v\nwant=\n%v", got, want)
+	}
+
+	errorCases := []struct {
+		values map[string]interface{}
+		want   string
+	}{
+		{
+			values: map[string]interface{}{"F64": int64(1)<<53 + 1},
+			want:   "bullseye/mutations: cannot fill column F64: 9007199254740993 (int64) is not a valid float64",
+		},
+		{
+			values: map[string]interface{}{"F32": 1e300},
+			want:   "bullseye/mutations: cannot fill column F32: 1e+300 (float64) is not a valid float32",
+		},
+		{
+			values: map[string]interface{}{"I8": 128},
+			want:   "bullseye/mutations: cannot fill column I8: 128 (int) is not a valid int8",
+		},
+		{
+			values: map[string]interface{}{"I8": math.NaN()},
+			want:   "bullseye/mutations: cannot fill column I8: NaN (float64) is not a valid int8",
+		},
+		{
+			values: map[string]interface{}{"U64": -1},
+			want:   "bullseye/mutations: cannot fill column U64: -1 (int) is not a valid uint64",
+		},
+		{
+			values: map[string]interface{}{"U64": float64(math.MaxUint64)},
+			want:   "bullseye/mutations: cannot fill column U64: 1.8446744073709552e+19 (float64) is not a valid uint64",
+		},
+	}
+	for _, tc := range errorCases {
+		_, err := df.FillNull(tc.values)
+		if err == nil {
+			t.Fatalf("expected an error for %v", tc.values)
+		}
+		if got := err.Error(); got != tc.want {
+			t.Fatalf("got=%q, want=%q", got, tc.want)
+		}
+	}
+}
+
+func TestFillDirection(t *testing.T) {
+	pool := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer pool.AssertSize(t, 0)
+
+	df := buildNullsFrame(pool, t)
+	defer df.Release()
+
+	testCases := []struct {
+		name     string
+		backward bool
+		columns  []string
+		opts     []Option
+		want     string
+	}{
+		{
+			name: "forward",
+			want: `rec[0]["A"]: [1 1 1 1 5 5]
+rec[0]["B"]: [(null) "b" "b" "d" "e" "e"]
+rec[0]["C"]: [1 2 3 4 5 6]
+`,
+		},
+		{
+			name:    "forward with limit",
+			columns: []string{"A"},
+			opts:    []Option{WithFillLimit(2)},
+			want: `rec[0]["A"]: [1 1 1 (null) 5 5]
+rec[0]["B"]: [(null) "b" (null) "d" "e" (null)]
+rec[0]["C"]: [1 2 3 4 5 6]
+`,
+		},
+		{
+			name:     "backward",
+			backward: true,
+			want: `rec[0]["A"]: [1 5 5 5 5 (null)]
+rec[0]["B"]: ["b" "b" "d" "d" "e" (null)]
+rec[0]["C"]: [1 2 3 4 5 6]
+`,
+		},
+		{
+			name:     "backward with limit",
+			backward: true,
+			columns:  []string{"A"},
+			opts:     []Option{WithFillLimit(1)},
+			want: `rec[0]["A"]: [1 (null) (null) 5 5 (null)]
+rec[0]["B"]: [(null) "b" (null) "d" "e" (null)]
+rec[0]["C"]: [1 2 3 4 5 6]
+`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			fill := df.FillForward
+			if tc.backward {
+				fill = df.FillBackward
+			}
+			got, err := fill(tc.columns, tc.opts...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer got.Release()
+
+			if s := got.Display(-1); s != tc.want {
+				t.Fatalf("\ngot=\n%v\nwant=\n%v", s, tc.want)
+			}
+		})
+	}
+}
+
+func TestFillDirectionChunks(t *testing.T) {
+	pool := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer pool.AssertSize(t, 0)
+
+	df := buildNullsFrame(pool, t)
+	defer df.Release()
+
+	// Two chunks so the fills have to carry over from one chunk to the next.
+	chunkedDf, err := Concat(pool, []*DataFrame{df, df})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer chunkedDf.Release()
+
+	forwardDf, err := chunkedDf.FillForward([]string{"B"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer forwardDf.Release()
+
+	got := forwardDf.Display(-1)
+	want := `rec[0]["A"]: [1 (null) (null) (null) 5 (null)]
+rec[0]["B"]: [(null) "b" "b" "d" "e" "e"]
+rec[0]["C"]: [1 2 3 4 5 6]
+rec[1]["A"]: [1 (null) (null) (null) 5 (null)]
+rec[1]["B"]: ["e" "b" "b" "d" "e" "e"]
+rec[1]["C"]: [1 2 3 4 5 6]
+`
+	if got != want {
+		t.Fatalf("\ngot=\n%v\nwant=\n%v", got, want)
+	}
+
+	backwardDf, err := chunkedDf.FillBackward([]string{"B"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer backwardDf.Release()
+
+	got = backwardDf.Display(-1)
+	want = `rec[0]["A"]: [1 (null) (null) (null) 5 (null)]
+rec[0]["B"]: ["b" "b" "d" "d" "e" "b"]
+rec[0]["C"]: [1 2 3 4 5 6]
+rec[1]["A"]: [1 (null) (null) (null) 5 (null)]
+rec[1]["B"]: ["b" "b" "d" "d" "e" (null)]
+rec[1]["C"]: [1 2 3 4 5 6]
+`
+	if got != want {
+		t.Fatalf("\ngot=\n%v\nwant=\n%v", got, want)
+	}
+}
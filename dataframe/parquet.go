@@ -0,0 +1,870 @@
+package dataframe
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"io"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/memory"
+	"github.com/apache/thrift/lib/go/thrift"
+	"github.com/go-bullseye/bullseye/internal/arrays"
+	"github.com/go-bullseye/bullseye/internal/temporal"
+	"github.com/pkg/errors"
+	"github.com/xitongsys/parquet-go/common"
+	"github.com/xitongsys/parquet-go/layout"
+	"github.com/xitongsys/parquet-go/parquet"
+	"github.com/xitongsys/parquet-go/reader"
+	"github.com/xitongsys/parquet-go/source"
+)
+
+// DefaultRowGroupSize is the largest number of rows in each row group written by WriteParquet.
+const DefaultRowGroupSize = 1 << 20
+
+// parquetMagic starts and ends every Parquet file.
+const parquetMagic = "PAR1"
+
+// parquetPageSize is the size in bytes of the values from which WriteParquet starts a new page.
+const parquetPageSize = 8 << 10
+
+// parquetCreatedBy names the writer of the files written by WriteParquet in their metadata.
+const parquetCreatedBy = "bullseye"
+
+// ParquetCompression is the codec of the pages written by WriteParquet.
+type ParquetCompression int
+
+const (
+	// ParquetUncompressed writes the pages as they are.
+	ParquetUncompressed ParquetCompression = iota
+	// ParquetSnappy compresses the pages with Snappy.
+	ParquetSnappy
+)
+
+func (c ParquetCompression) String() string {
+	switch c {
+	case ParquetUncompressed:
+		return "uncompressed"
+	case ParquetSnappy:
+		return "snappy"
+	default:
+		return fmt.Sprintf("ParquetCompression(%d)", int(c))
+	}
+}
+
+// ParquetEncoding is the encoding of the values written by WriteParquet.
+type ParquetEncoding int
+
+const (
+	// ParquetPlain writes the values one after the other.
+	ParquetPlain ParquetEncoding = iota
+	// ParquetDictionary writes the distinct values of each column chunk once, in a dictionary
+	// page, and the values as indexes into the dictionary. Boolean columns are always plain.
+	ParquetDictionary
+)
+
+func (e ParquetEncoding) String() string {
+	switch e {
+	case ParquetPlain:
+		return "plain"
+	case ParquetDictionary:
+		return "dictionary"
+	default:
+		return fmt.Sprintf("ParquetEncoding(%d)", int(e))
+	}
+}
+
+type parquetConfig struct {
+	columns      []string
+	rowGroupSize int
+	compression  ParquetCompression
+	encoding     ParquetEncoding
+}
+
+func newParquetConfig(opts ...Option) (*parquetConfig, error) {
+	cfg := &parquetConfig{
+		rowGroupSize: DefaultRowGroupSize,
+		compression:  ParquetSnappy,
+		encoding:     ParquetDictionary,
+	}
+	for _, opt := range opts {
+		if err := opt(cfg); err != nil {
+			return nil, err
+		}
+	}
+	return cfg, nil
+}
+
+// WithColumns sets the columns read by ReadParquet, in the order of the DataFrame.
+// The other columns of the file are not read.
+func WithColumns(names ...string) Option {
+	return func(p interface{}) error {
+		cfg, ok := p.(*parquetConfig)
+		if !ok {
+			return errors.Errorf("cannot apply WithColumns to: %T", p)
+		}
+		if len(names) == 0 {
+			return errors.New("no columns given")
+		}
+		cfg.columns = names
+		return nil
+	}
+}
+
+// WithRowGroupSize sets the largest number of rows in each row group written by WriteParquet.
+// The default is DefaultRowGroupSize.
+func WithRowGroupSize(rows int) Option {
+	return func(p interface{}) error {
+		cfg, ok := p.(*parquetConfig)
+		if !ok {
+			return errors.Errorf("cannot apply WithRowGroupSize to: %T", p)
+		}
+		if rows < 1 {
+			return errors.Errorf("row group size must be positive: %d", rows)
+		}
+		cfg.rowGroupSize = rows
+		return nil
+	}
+}
+
+// WithParquetCompression sets the codec of the pages written by WriteParquet.
+// The default is ParquetSnappy.
+func WithParquetCompression(compression ParquetCompression) Option {
+	return func(p interface{}) error {
+		cfg, ok := p.(*parquetConfig)
+		if !ok {
+			return errors.Errorf("cannot apply WithParquetCompression to: %T", p)
+		}
+		if compression < ParquetUncompressed || compression > ParquetSnappy {
+			return errors.Errorf("invalid parquet compression %v", compression)
+		}
+		cfg.compression = compression
+		return nil
+	}
+}
+
+// WithParquetEncoding sets the encoding of the values written by WriteParquet.
+// The default is ParquetDictionary.
+func WithParquetEncoding(encoding ParquetEncoding) Option {
+	return func(p interface{}) error {
+		cfg, ok := p.(*parquetConfig)
+		if !ok {
+			return errors.Errorf("cannot apply WithParquetEncoding to: %T", p)
+		}
+		if encoding < ParquetPlain || encoding > ParquetDictionary {
+			return errors.Errorf("invalid parquet encoding %v", encoding)
+		}
+		cfg.encoding = encoding
+		return nil
+	}
+}
+
+// ReadParquet reads the Parquet file of size bytes in r into a DataFrame, with a chunk for each row
+// group. The columns can be chosen with WithColumns. Plain and dictionary encoded values are read,
+// from pages that are uncompressed or compressed with Snappy.
+//
+// The file must have a flat schema of columns that are not repeated. BOOLEAN, FLOAT and DOUBLE columns
+// make Boolean, Float32 and Float64 columns, INT32 and INT64 columns make the integer column of their
+// INT_x or UINT_x annotation, or Int32 and Int64 without one, DATE columns make Date32 columns and
+// BYTE_ARRAY columns make String columns. INT64 TIMESTAMP columns make Timestamp columns of their
+// unit, in the UTC time zone when they are adjusted to UTC, as TIMESTAMP_MILLIS and TIMESTAMP_MICROS
+// columns are. TIME and TIME_MILLIS columns in milliseconds make Time32 columns, and those in micro-
+// or nanoseconds make Time64 columns. Columns are nullable when they are optional.
+func ReadParquet(mem memory.Allocator, r io.ReaderAt, size int64, opts ...Option) (*DataFrame, error) {
+	cfg, err := newParquetConfig(opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := checkParquetMagic(r, size); err != nil {
+		return nil, err
+	}
+	pr, err := reader.NewParquetColumnReader(&parquetFile{r: r, size: size}, 1)
+	if err != nil {
+		return nil, errors.Wrap(err, "bullseye/dataframe: cannot read the Parquet footer")
+	}
+	defer pr.ReadStop()
+
+	// The reader renames the elements of the schema, so the names of the
+	// columns are those of the infos of the schema handler.
+	elements := pr.SchemaHandler.SchemaElements
+	infos := pr.SchemaHandler.Infos
+	if len(elements) == 0 || int(elements[0].GetNumChildren()) != len(elements)-1 {
+		return nil, errors.New("bullseye/dataframe: cannot read Parquet files with nested columns")
+	}
+	indexes := make(map[string]int, len(elements)-1)
+	for i := 1; i < len(elements); i++ {
+		if _, ok := indexes[infos[i].ExName]; ok {
+			return nil, errors.Errorf("bullseye/dataframe: cannot read Parquet file with more than one column named %s", infos[i].ExName)
+		}
+		indexes[infos[i].ExName] = i - 1
+	}
+
+	names := cfg.columns
+	if names == nil {
+		names = make([]string, len(elements)-1)
+		for i := range names {
+			names[i] = infos[i+1].ExName
+		}
+	}
+
+	fields := make([]arrow.Field, len(names))
+	columns := make([]int, len(names))
+	selected := make(map[string]struct{}, len(names))
+	for i, name := range names {
+		index, ok := indexes[name]
+		if !ok {
+			return nil, errors.Errorf("bullseye/dataframe: Parquet file has no column %s", name)
+		}
+		if _, ok := selected[name]; ok {
+			return nil, errors.Errorf("bullseye/dataframe: column %s is given more than once", name)
+		}
+		selected[name] = struct{}{}
+		element := elements[index+1]
+		dtype, ok := parquetDataType(element)
+		if !ok {
+			return nil, errors.Errorf("bullseye/dataframe: cannot read Parquet column %s of type %v", name, parquetTypeName(element))
+		}
+		if element.GetRepetitionType() == parquet.FieldRepetitionType_REPEATED {
+			return nil, errors.Errorf("bullseye/dataframe: cannot read repeated Parquet column %s", name)
+		}
+		fields[i] = arrow.Field{Name: name, Type: dtype, Nullable: element.GetRepetitionType() == parquet.FieldRepetitionType_OPTIONAL}
+		columns[i] = index
+	}
+
+	chunks := make([][]array.Interface, len(fields))
+	defer func() {
+		for i := range chunks {
+			for _, chunk := range chunks[i] {
+				chunk.Release()
+			}
+		}
+	}()
+	rowGroups := pr.Footer.GetRowGroups()
+	for i := range fields {
+		for _, rowGroup := range rowGroups {
+			values, err := readParquetRowGroup(pr, columns[i], rowGroup.GetNumRows(), fields[i].Type)
+			if err != nil {
+				return nil, errors.Wrapf(err, "bullseye/dataframe: cannot read Parquet column %s", fields[i].Name)
+			}
+			arr, err := newArrayFromValues(mem, fields[i].Type, values)
+			if err != nil {
+				return nil, err
+			}
+			chunks[i] = append(chunks[i], arr)
+		}
+		if len(chunks[i]) == 0 {
+			arr, err := newArrayFromValues(mem, fields[i].Type, nil)
+			if err != nil {
+				return nil, err
+			}
+			chunks[i] = append(chunks[i], arr)
+		}
+	}
+
+	cols := make([]array.Column, len(fields))
+	for i := range fields {
+		cols[i] = *newColumnFromChunks(fields[i], chunks[i])
+	}
+	defer func() {
+		for i := range cols {
+			cols[i].Release()
+		}
+	}()
+	return NewDataFrameFromColumns(mem, cols)
+}
+
+// readParquetRowGroup reads the values of the next row group of the column, of numRows rows.
+func readParquetRowGroup(pr *reader.ParquetReader, column int, numRows int64, dtype arrow.DataType) ([]interface{}, error) {
+	values := make([]interface{}, 0, numRows)
+	if numRows == 0 {
+		return values, nil
+	}
+	read, _, _, err := pr.ReadColumnByIndex(int64(column), numRows)
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(read)) != numRows {
+		return nil, errors.Errorf("read %d values of a row group of %d rows", len(read), numRows)
+	}
+	for _, v := range read {
+		v, err := fromParquetValue(v, dtype)
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, v)
+	}
+	return values, nil
+}
+
+// checkParquetMagic returns an error if the file does not start and end with parquetMagic.
+func checkParquetMagic(r io.ReaderAt, size int64) error {
+	n := int64(len(parquetMagic))
+	if size < 2*n+4 {
+		return errors.New("bullseye/dataframe: cannot read Parquet file, it is too short")
+	}
+	head, tail := make([]byte, n), make([]byte, n)
+	if _, err := r.ReadAt(head, 0); err != nil {
+		return errors.Wrap(err, "bullseye/dataframe: cannot read Parquet file")
+	}
+	if _, err := r.ReadAt(tail, size-n); err != nil && err != io.EOF {
+		return errors.Wrap(err, "bullseye/dataframe: cannot read Parquet file")
+	}
+	if string(head) != parquetMagic || string(tail) != parquetMagic {
+		return errors.New("bullseye/dataframe: cannot read Parquet file, it is not a Parquet file")
+	}
+	return nil
+}
+
+// WriteParquet writes the DataFrame to w as a Parquet file, with a row group for each chunk of rows
+// the columns have in common, split into row groups of at most WithRowGroupSize rows. The values are
+// encoded following WithParquetEncoding and compressed following WithParquetCompression.
+//
+// Boolean, integer, float, String, Date32, Date64, Timestamp, Time32 and Time64 columns can be written.
+// Integers are written as INT32 or INT64 with their INT_x or UINT_x annotation, floats as FLOAT or
+// DOUBLE, strings as UTF8 BYTE_ARRAY and dates as DATE, so Date64 columns are read back as Date32
+// columns. Timestamps are written as INT64 TIMESTAMP, adjusted to UTC when they have a time zone and
+// then also annotated TIMESTAMP_MILLIS or TIMESTAMP_MICROS. Times are written as INT32 TIME_MILLIS,
+// INT64 TIME_MICROS or INT64 TIME in nanoseconds. Timestamps and times in seconds are written in
+// milliseconds. Nullable columns are optional and the others required.
+func WriteParquet(w io.Writer, df *DataFrame, opts ...Option) error {
+	cfg, err := newParquetConfig(opts...)
+	if err != nil {
+		return err
+	}
+
+	fields := df.Schema().Fields()
+	if len(fields) == 0 {
+		return errors.New("bullseye/dataframe: cannot write a DataFrame without columns to Parquet")
+	}
+	cols := df.Columns()
+	for i, field := range fields {
+		if !field.Nullable && cols[i].NullN() > 0 {
+			return errors.Errorf("bullseye/dataframe: cannot write column %s to Parquet, it has nulls but is not nullable", field.Name)
+		}
+	}
+	elements, err := newParquetSchema(fields)
+	if err != nil {
+		return err
+	}
+
+	pw := &parquetWriter{
+		w:        w,
+		elements: elements,
+		encoding: cfg.encoding,
+		codec:    parquet.CompressionCodec_SNAPPY,
+		footer:   parquet.NewFileMetaData(),
+	}
+	if cfg.compression == ParquetUncompressed {
+		pw.codec = parquet.CompressionCodec_UNCOMPRESSED
+	}
+	if err := pw.write([]byte(parquetMagic)); err != nil {
+		return errors.Wrap(err, "bullseye/dataframe: cannot write Parquet file")
+	}
+
+	tr := array.NewTableReader(NewTableFacade(df), int64(cfg.rowGroupSize))
+	defer tr.Release()
+	for tr.Next() {
+		if err := pw.writeRowGroup(tr.Record()); err != nil {
+			return errors.Wrap(err, "bullseye/dataframe: cannot write Parquet file")
+		}
+	}
+	if err := pw.writeFooter(); err != nil {
+		return errors.Wrap(err, "bullseye/dataframe: cannot write Parquet file")
+	}
+	return nil
+}
+
+// parquetWriter writes a Parquet file to an io.Writer, keeping the offset of what it
+// wrote so far for the metadata of the column chunks.
+type parquetWriter struct {
+	w        io.Writer
+	offset   int64
+	elements []*parquet.SchemaElement
+	encoding ParquetEncoding
+	codec    parquet.CompressionCodec
+	footer   *parquet.FileMetaData
+}
+
+func (pw *parquetWriter) write(p []byte) error {
+	n, err := pw.w.Write(p)
+	pw.offset += int64(n)
+	return err
+}
+
+// writeRowGroup writes the rows of the record as a row group.
+func (pw *parquetWriter) writeRowGroup(rec array.Record) error {
+	if rec.NumRows() == 0 {
+		return nil
+	}
+
+	rowGroup := parquet.NewRowGroup()
+	rowGroup.NumRows = rec.NumRows()
+	for i, arr := range rec.Columns() {
+		element := pw.elements[i+1]
+		chunk := pw.newColumnChunk(element, arr)
+
+		header := chunk.ChunkHeader
+		header.MetaData.PathInSchema = []string{element.Name}
+		header.MetaData.DataPageOffset = -1
+		header.FileOffset = pw.offset
+		for _, page := range chunk.Pages {
+			offset := pw.offset
+			if page.Header.Type == parquet.PageType_DICTIONARY_PAGE {
+				header.MetaData.DictionaryPageOffset = &offset
+			} else if header.MetaData.DataPageOffset < 0 {
+				header.MetaData.DataPageOffset = offset
+			}
+			if err := pw.write(page.RawData); err != nil {
+				return err
+			}
+		}
+		rowGroup.TotalByteSize += header.MetaData.TotalUncompressedSize
+		rowGroup.Columns = append(rowGroup.Columns, header)
+	}
+	pw.footer.RowGroups = append(pw.footer.RowGroups, rowGroup)
+	pw.footer.NumRows += rowGroup.NumRows
+	return nil
+}
+
+// newColumnChunk returns the pages of the values of arr, a column of the schema element.
+func (pw *parquetWriter) newColumnChunk(element *parquet.SchemaElement, arr array.Interface) *layout.Chunk {
+	table := layout.NewEmptyTable()
+	table.Path = []string{pw.elements[0].Name, element.Name}
+	table.Schema = element
+	table.RepetitionType = element.GetRepetitionType()
+	if table.RepetitionType == parquet.FieldRepetitionType_OPTIONAL {
+		table.MaxDefinitionLevel = 1
+	}
+	table.Values = make([]interface{}, arr.Len())
+	table.DefinitionLevels = make([]int32, arr.Len())
+	table.RepetitionLevels = make([]int32, arr.Len())
+	for i := range table.Values {
+		if arr.IsNull(i) {
+			continue
+		}
+		table.Values[i] = toParquetValue(arrays.Value(arr, i), arr.DataType())
+		table.DefinitionLevels[i] = table.MaxDefinitionLevel
+	}
+
+	if pw.encoding == ParquetDictionary && element.GetType() != parquet.Type_BOOLEAN {
+		table.Info.Encoding = parquet.Encoding_PLAIN_DICTIONARY
+		dict := layout.NewDictRec(element.GetType())
+		pages, _ := layout.TableToDictDataPages(dict, table, parquetPageSize, 32, pw.codec)
+		dictPage, _ := layout.DictRecToDictPage(dict, parquetPageSize, pw.codec)
+		return layout.PagesToDictChunk(append([]*layout.Page{dictPage}, pages...))
+	}
+	pages, _ := layout.TableToDataPages(table, parquetPageSize, pw.codec)
+	return layout.PagesToChunk(pages)
+}
+
+// writeFooter writes the metadata of the file and the magic number that ends it.
+func (pw *parquetWriter) writeFooter() error {
+	createdBy := parquetCreatedBy
+	pw.footer.Version = 1
+	pw.footer.Schema = pw.elements
+	pw.footer.CreatedBy = &createdBy
+
+	ts := thrift.NewTSerializer()
+	ts.Protocol = thrift.NewTCompactProtocolFactory().GetProtocol(ts.Transport)
+	footer, err := ts.Write(context.Background(), pw.footer)
+	if err != nil {
+		return err
+	}
+	size := make([]byte, 4)
+	binary.LittleEndian.PutUint32(size, uint32(len(footer)))
+	for _, p := range [][]byte{footer, size, []byte(parquetMagic)} {
+		if err := pw.write(p); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// newParquetSchema returns the elements of the schema of a Parquet file with a column for each field.
+func newParquetSchema(fields []arrow.Field) ([]*parquet.SchemaElement, error) {
+	root := parquet.NewSchemaElement()
+	root.Name = "schema"
+	root.NumChildren = parquetInt32(int32(len(fields)))
+	root.RepetitionType = parquetRepetition(parquet.FieldRepetitionType_REQUIRED)
+	elements := []*parquet.SchemaElement{root}
+
+	inNames := make(map[string]string, len(fields))
+	for _, field := range fields {
+		element, ok := newParquetSchemaElement(field)
+		if !ok {
+			return nil, errors.Errorf("bullseye/dataframe: cannot write column %s of type %v to Parquet", field.Name, field.Type)
+		}
+		elements = append(elements, element)
+
+		// The Parquet reader keys the columns by a Go identifier made from their names.
+		inName := common.StringToVariableName(field.Name)
+		if other, ok := inNames[inName]; ok {
+			return nil, errors.Errorf("bullseye/dataframe: cannot write columns %s and %s to Parquet, their names are too alike", other, field.Name)
+		}
+		inNames[inName] = field.Name
+	}
+	return elements, nil
+}
+
+// newParquetSchemaElement returns the Parquet schema element of the column of field.
+func newParquetSchemaElement(field arrow.Field) (*parquet.SchemaElement, bool) {
+	element := parquet.NewSchemaElement()
+	element.Name = field.Name
+	element.NumChildren = parquetInt32(0)
+	element.RepetitionType = parquetRepetition(parquet.FieldRepetitionType_REQUIRED)
+	if field.Nullable {
+		element.RepetitionType = parquetRepetition(parquet.FieldRepetitionType_OPTIONAL)
+	}
+
+	physical := func(t parquet.Type) {
+		element.Type = parquet.TypePtr(t)
+	}
+	converted := func(t parquet.Type, c parquet.ConvertedType) {
+		element.Type = parquet.TypePtr(t)
+		element.ConvertedType = parquet.ConvertedTypePtr(c)
+	}
+	switch field.Type.ID() {
+	case arrow.BOOL:
+		physical(parquet.Type_BOOLEAN)
+	case arrow.INT8:
+		converted(parquet.Type_INT32, parquet.ConvertedType_INT_8)
+	case arrow.INT16:
+		converted(parquet.Type_INT32, parquet.ConvertedType_INT_16)
+	case arrow.INT32:
+		converted(parquet.Type_INT32, parquet.ConvertedType_INT_32)
+	case arrow.INT64:
+		converted(parquet.Type_INT64, parquet.ConvertedType_INT_64)
+	case arrow.UINT8:
+		converted(parquet.Type_INT32, parquet.ConvertedType_UINT_8)
+	case arrow.UINT16:
+		converted(parquet.Type_INT32, parquet.ConvertedType_UINT_16)
+	case arrow.UINT32:
+		converted(parquet.Type_INT32, parquet.ConvertedType_UINT_32)
+	case arrow.UINT64:
+		converted(parquet.Type_INT64, parquet.ConvertedType_UINT_64)
+	case arrow.FLOAT32:
+		physical(parquet.Type_FLOAT)
+	case arrow.FLOAT64:
+		physical(parquet.Type_DOUBLE)
+	case arrow.STRING:
+		converted(parquet.Type_BYTE_ARRAY, parquet.ConvertedType_UTF8)
+	case arrow.DATE32, arrow.DATE64:
+		converted(parquet.Type_INT32, parquet.ConvertedType_DATE)
+	case arrow.TIMESTAMP:
+		dtype := field.Type.(*arrow.TimestampType)
+		utc := dtype.TimeZone != ""
+		physical(parquet.Type_INT64)
+		element.LogicalType = &parquet.LogicalType{
+			TIMESTAMP: &parquet.TimestampType{IsAdjustedToUTC: utc, Unit: newParquetTimeUnit(dtype.Unit)},
+		}
+		// The TIMESTAMP annotations are for timestamps adjusted to UTC.
+		switch {
+		case utc && (dtype.Unit == arrow.Second || dtype.Unit == arrow.Millisecond):
+			element.ConvertedType = parquet.ConvertedTypePtr(parquet.ConvertedType_TIMESTAMP_MILLIS)
+		case utc && dtype.Unit == arrow.Microsecond:
+			element.ConvertedType = parquet.ConvertedTypePtr(parquet.ConvertedType_TIMESTAMP_MICROS)
+		}
+	case arrow.TIME32:
+		// Times are adjusted to UTC, as the TIME_MILLIS and TIME_MICROS annotations are.
+		converted(parquet.Type_INT32, parquet.ConvertedType_TIME_MILLIS)
+		element.LogicalType = &parquet.LogicalType{
+			TIME: &parquet.TimeType{IsAdjustedToUTC: true, Unit: newParquetTimeUnit(arrow.Millisecond)},
+		}
+	case arrow.TIME64:
+		unit := field.Type.(*arrow.Time64Type).Unit
+		physical(parquet.Type_INT64)
+		if unit == arrow.Microsecond {
+			element.ConvertedType = parquet.ConvertedTypePtr(parquet.ConvertedType_TIME_MICROS)
+		}
+		element.LogicalType = &parquet.LogicalType{
+			TIME: &parquet.TimeType{IsAdjustedToUTC: true, Unit: newParquetTimeUnit(unit)},
+		}
+	default:
+		return nil, false
+	}
+	return element, true
+}
+
+// newParquetTimeUnit returns the Parquet time unit of the values of unit,
+// with seconds written as milliseconds.
+func newParquetTimeUnit(unit arrow.TimeUnit) *parquet.TimeUnit {
+	switch unit {
+	case arrow.Microsecond:
+		return &parquet.TimeUnit{MICROS: parquet.NewMicroSeconds()}
+	case arrow.Nanosecond:
+		return &parquet.TimeUnit{NANOS: parquet.NewNanoSeconds()}
+	default:
+		return &parquet.TimeUnit{MILLIS: parquet.NewMilliSeconds()}
+	}
+}
+
+// parquetTimeUnit returns the unit of a Parquet time unit.
+func parquetTimeUnit(unit *parquet.TimeUnit) (arrow.TimeUnit, bool) {
+	switch {
+	case unit == nil:
+		return 0, false
+	case unit.IsSetMILLIS():
+		return arrow.Millisecond, true
+	case unit.IsSetMICROS():
+		return arrow.Microsecond, true
+	case unit.IsSetNANOS():
+		return arrow.Nanosecond, true
+	default:
+		return 0, false
+	}
+}
+
+// parquetDataType returns the type of the column read from a Parquet schema element.
+func parquetDataType(element *parquet.SchemaElement) (arrow.DataType, bool) {
+	if element.Type == nil {
+		return nil, false
+	}
+	if logical := element.LogicalType; logical != nil && (logical.IsSetTIMESTAMP() || logical.IsSetTIME()) {
+		return parquetTimeDataType(element)
+	}
+	if element.ConvertedType == nil {
+		switch element.GetType() {
+		case parquet.Type_BOOLEAN:
+			return arrow.FixedWidthTypes.Boolean, true
+		case parquet.Type_INT32:
+			return arrow.PrimitiveTypes.Int32, true
+		case parquet.Type_INT64:
+			return arrow.PrimitiveTypes.Int64, true
+		case parquet.Type_FLOAT:
+			return arrow.PrimitiveTypes.Float32, true
+		case parquet.Type_DOUBLE:
+			return arrow.PrimitiveTypes.Float64, true
+		case parquet.Type_BYTE_ARRAY:
+			return arrow.BinaryTypes.String, true
+		}
+		return nil, false
+	}
+
+	var dtype arrow.DataType
+	physical := parquet.Type_INT32
+	switch element.GetConvertedType() {
+	case parquet.ConvertedType_INT_8:
+		dtype = arrow.PrimitiveTypes.Int8
+	case parquet.ConvertedType_INT_16:
+		dtype = arrow.PrimitiveTypes.Int16
+	case parquet.ConvertedType_INT_32:
+		dtype = arrow.PrimitiveTypes.Int32
+	case parquet.ConvertedType_UINT_8:
+		dtype = arrow.PrimitiveTypes.Uint8
+	case parquet.ConvertedType_UINT_16:
+		dtype = arrow.PrimitiveTypes.Uint16
+	case parquet.ConvertedType_UINT_32:
+		dtype = arrow.PrimitiveTypes.Uint32
+	case parquet.ConvertedType_DATE:
+		dtype = arrow.FixedWidthTypes.Date32
+	case parquet.ConvertedType_INT_64:
+		dtype, physical = arrow.PrimitiveTypes.Int64, parquet.Type_INT64
+	case parquet.ConvertedType_UINT_64:
+		dtype, physical = arrow.PrimitiveTypes.Uint64, parquet.Type_INT64
+	case parquet.ConvertedType_UTF8:
+		dtype, physical = arrow.BinaryTypes.String, parquet.Type_BYTE_ARRAY
+	case parquet.ConvertedType_TIMESTAMP_MILLIS:
+		dtype, physical = &arrow.TimestampType{Unit: arrow.Millisecond, TimeZone: "UTC"}, parquet.Type_INT64
+	case parquet.ConvertedType_TIMESTAMP_MICROS:
+		dtype, physical = &arrow.TimestampType{Unit: arrow.Microsecond, TimeZone: "UTC"}, parquet.Type_INT64
+	case parquet.ConvertedType_TIME_MILLIS:
+		dtype = arrow.FixedWidthTypes.Time32ms
+	case parquet.ConvertedType_TIME_MICROS:
+		dtype, physical = arrow.FixedWidthTypes.Time64us, parquet.Type_INT64
+	default:
+		return nil, false
+	}
+	if element.GetType() != physical {
+		return nil, false
+	}
+	return dtype, true
+}
+
+// parquetTimeDataType returns the type of the column read from a Parquet schema element of
+// logical type TIMESTAMP or TIME. Timestamps adjusted to UTC are in the UTC time zone.
+func parquetTimeDataType(element *parquet.SchemaElement) (arrow.DataType, bool) {
+	logical := element.GetLogicalType()
+	if logical.IsSetTIMESTAMP() {
+		unit, ok := parquetTimeUnit(logical.TIMESTAMP.GetUnit())
+		if !ok || element.GetType() != parquet.Type_INT64 {
+			return nil, false
+		}
+		dtype := &arrow.TimestampType{Unit: unit}
+		if logical.TIMESTAMP.GetIsAdjustedToUTC() {
+			dtype.TimeZone = "UTC"
+		}
+		return dtype, true
+	}
+
+	unit, ok := parquetTimeUnit(logical.TIME.GetUnit())
+	switch {
+	case !ok:
+		return nil, false
+	case unit == arrow.Millisecond && element.GetType() == parquet.Type_INT32:
+		return arrow.FixedWidthTypes.Time32ms, true
+	case unit == arrow.Microsecond && element.GetType() == parquet.Type_INT64:
+		return arrow.FixedWidthTypes.Time64us, true
+	case unit == arrow.Nanosecond && element.GetType() == parquet.Type_INT64:
+		return arrow.FixedWidthTypes.Time64ns, true
+	default:
+		return nil, false
+	}
+}
+
+// parquetTypeName returns the name of the type of a Parquet schema element, e.g. "INT32 (DATE)".
+func parquetTypeName(element *parquet.SchemaElement) string {
+	if element.Type == nil {
+		return "group"
+	}
+	if element.ConvertedType == nil {
+		return element.GetType().String()
+	}
+	return fmt.Sprintf("%v (%v)", element.GetType(), element.GetConvertedType())
+}
+
+// toParquetValue returns the value of a column of type dtype as the Parquet writer takes it.
+func toParquetValue(v interface{}, dtype arrow.DataType) interface{} {
+	switch v := v.(type) {
+	case nil, bool, int32, int64, float32, float64, string:
+		return v
+	case int8:
+		return int32(v)
+	case int16:
+		return int32(v)
+	case uint8:
+		return int32(v)
+	case uint16:
+		return int32(v)
+	case uint32:
+		return int32(v)
+	case uint64:
+		return int64(v)
+	case arrow.Date32:
+		return int32(v)
+	case arrow.Date64:
+		return int32(temporal.FloorDiv(int64(v), temporal.MillisecondsPerDay))
+	case arrow.Timestamp:
+		if dtype.(*arrow.TimestampType).Unit == arrow.Second {
+			return int64(v) * 1000
+		}
+		return int64(v)
+	case arrow.Time32:
+		if dtype.(*arrow.Time32Type).Unit == arrow.Second {
+			return int32(v) * 1000
+		}
+		return int32(v)
+	case arrow.Time64:
+		return int64(v)
+	default:
+		panic(fmt.Errorf("bullseye/dataframe: cannot write %T of column type %v to Parquet", v, dtype))
+	}
+}
+
+// fromParquetValue returns a value read by the Parquet reader as the value of a column of type dtype.
+func fromParquetValue(v interface{}, dtype arrow.DataType) (interface{}, error) {
+	if v == nil {
+		return nil, nil
+	}
+	switch v := v.(type) {
+	case bool, float32, float64, string:
+		return v, nil
+	case int32:
+		switch dtype.ID() {
+		case arrow.INT8:
+			return int8(v), nil
+		case arrow.INT16:
+			return int16(v), nil
+		case arrow.INT32:
+			return v, nil
+		case arrow.UINT8:
+			return uint8(v), nil
+		case arrow.UINT16:
+			return uint16(v), nil
+		case arrow.UINT32:
+			return uint32(v), nil
+		case arrow.DATE32:
+			return arrow.Date32(v), nil
+		case arrow.TIME32:
+			return arrow.Time32(v), nil
+		}
+	case int64:
+		switch dtype.ID() {
+		case arrow.UINT64:
+			return uint64(v), nil
+		case arrow.TIMESTAMP:
+			return arrow.Timestamp(v), nil
+		case arrow.TIME64:
+			return arrow.Time64(v), nil
+		}
+		return v, nil
+	}
+	return nil, errors.Errorf("unexpected value %v of type %T", v, v)
+}
+
+func parquetInt32(v int32) *int32 {
+	return &v
+}
+
+func parquetRepetition(t parquet.FieldRepetitionType) *parquet.FieldRepetitionType {
+	return &t
+}
+
+// parquetFile reads a Parquet file from an io.ReaderAt. The Parquet reader opens
+// the file again for each column it reads, which shares r with its own offset.
+type parquetFile struct {
+	r      io.ReaderAt
+	size   int64
+	offset int64
+}
+
+var _ source.ParquetFile = (*parquetFile)(nil)
+
+func (f *parquetFile) Open(name string) (source.ParquetFile, error) {
+	return &parquetFile{r: f.r, size: f.size}, nil
+}
+
+func (f *parquetFile) Create(name string) (source.ParquetFile, error) {
+	return nil, errors.New("bullseye/dataframe: cannot create a Parquet file for reading")
+}
+
+func (f *parquetFile) Seek(offset int64, whence int) (int64, error) {
+	switch whence {
+	case io.SeekStart:
+	case io.SeekCurrent:
+		offset += f.offset
+	case io.SeekEnd:
+		offset += f.size
+	default:
+		return 0, errors.Errorf("bullseye/dataframe: invalid whence %d", whence)
+	}
+	if offset < 0 {
+		return 0, errors.New("bullseye/dataframe: seek before the start of the Parquet file")
+	}
+	f.offset = offset
+	return offset, nil
+}
+
+func (f *parquetFile) Read(p []byte) (int, error) {
+	if f.offset >= f.size {
+		return 0, io.EOF
+	}
+	if rest := f.size - f.offset; int64(len(p)) > rest {
+		p = p[:rest]
+	}
+	n, err := f.r.ReadAt(p, f.offset)
+	f.offset += int64(n)
+	if err == io.EOF && n > 0 {
+		err = nil
+	}
+	return n, err
+}
+
+func (f *parquetFile) Write(p []byte) (int, error) {
+	return 0, errors.New("bullseye/dataframe: cannot write a Parquet file for reading")
+}
+
+func (f *parquetFile) Close() error {
+	return nil
+}
@@ -0,0 +1,474 @@
+package dataframe
+
+import (
+	"bytes"
+	"flag"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/memory"
+	"github.com/xitongsys/parquet-go/parquet"
+	"github.com/xitongsys/parquet-go/reader"
+)
+
+var updateParquet = flag.Bool("update-parquet", false, "rewrite the Parquet fixtures in testdata")
+
+var testParquetFiles = []struct {
+	file        string
+	encoding    ParquetEncoding
+	compression ParquetCompression
+}{
+	{"plain_uncompressed.parquet", ParquetPlain, ParquetUncompressed},
+	{"plain_snappy.parquet", ParquetPlain, ParquetSnappy},
+	{"dictionary_uncompressed.parquet", ParquetDictionary, ParquetUncompressed},
+	{"dictionary_snappy.parquet", ParquetDictionary, ParquetSnappy},
+}
+
+// testParquetDisplay is the Display of the DataFrame of testParquetFrame read back from Parquet,
+// where the Date64 column is read as a Date32 column and the columns in seconds in milliseconds.
+const testParquetDisplay = `rec[0]["bool"]: [true (null) false]
+rec[0]["int8"]: [-8 (null) 8]
+rec[0]["int16"]: [-16 (null) 16]
+rec[0]["int32"]: [-32 (null) 32]
+rec[0]["int64"]: [-64 (null) 64]
+rec[0]["uint8"]: [255 (null) 8]
+rec[0]["uint16"]: [65535 (null) 16]
+rec[0]["uint32"]: [4294967295 (null) 32]
+rec[0]["uint64"]: [18446744073709551615 (null) 64]
+rec[0]["float32"]: [1.5 (null) -1.5]
+rec[0]["float64"]: [2.5 (null) -2.5]
+rec[0]["string"]: ["a" (null) "b"]
+rec[0]["date32"]: [18000 (null) -1]
+rec[0]["date64"]: [18000 (null) -1]
+rec[0]["id"]: [1 2 3]
+rec[0]["timestamp_s"]: [1555000000000 (null) -1000]
+rec[0]["timestamp_ms"]: [1555000000123 (null) -1]
+rec[0]["timestamp_us"]: [1555000000123456 (null) -1]
+rec[0]["timestamp_ns"]: [1555000000123456789 (null) -1]
+rec[0]["time32_s"]: [3723000 (null) 0]
+rec[0]["time32_ms"]: [3723456 (null) 0]
+rec[0]["time64_us"]: [3723456789 (null) 0]
+rec[0]["time64_ns"]: [3723456789012 (null) 0]
+rec[1]["bool"]: [true true]
+rec[1]["int8"]: [-8 -8]
+rec[1]["int16"]: [-16 -16]
+rec[1]["int32"]: [-32 -32]
+rec[1]["int64"]: [-64 -64]
+rec[1]["uint8"]: [255 255]
+rec[1]["uint16"]: [65535 65535]
+rec[1]["uint32"]: [4294967295 4294967295]
+rec[1]["uint64"]: [18446744073709551615 18446744073709551615]
+rec[1]["float32"]: [1.5 1.5]
+rec[1]["float64"]: [2.5 2.5]
+rec[1]["string"]: ["a" "a"]
+rec[1]["date32"]: [18000 18000]
+rec[1]["date64"]: [18000 18000]
+rec[1]["id"]: [4 5]
+rec[1]["timestamp_s"]: [1555000000000 1555000000000]
+rec[1]["timestamp_ms"]: [1555000000123 1555000000123]
+rec[1]["timestamp_us"]: [1555000000123456 1555000000123456]
+rec[1]["timestamp_ns"]: [1555000000123456789 1555000000123456789]
+rec[1]["time32_s"]: [3723000 3723000]
+rec[1]["time32_ms"]: [3723456 3723456]
+rec[1]["time64_us"]: [3723456789 3723456789]
+rec[1]["time64_ns"]: [3723456789012 3723456789012]
+`
+
+// testParquetFrame returns a DataFrame with a column of each type WriteParquet can write,
+// in a chunk of 3 rows and a chunk of 2 rows that repeat the first row.
+func testParquetFrame(t *testing.T, mem memory.Allocator) *DataFrame {
+	t.Helper()
+
+	const day = 86400000
+	values := []struct {
+		name  string
+		dtype arrow.DataType
+		first []interface{}
+	}{
+		{"bool", arrow.FixedWidthTypes.Boolean, []interface{}{true, nil, false}},
+		{"int8", arrow.PrimitiveTypes.Int8, []interface{}{int8(-8), nil, int8(8)}},
+		{"int16", arrow.PrimitiveTypes.Int16, []interface{}{int16(-16), nil, int16(16)}},
+		{"int32", arrow.PrimitiveTypes.Int32, []interface{}{int32(-32), nil, int32(32)}},
+		{"int64", arrow.PrimitiveTypes.Int64, []interface{}{int64(-64), nil, int64(64)}},
+		{"uint8", arrow.PrimitiveTypes.Uint8, []interface{}{uint8(255), nil, uint8(8)}},
+		{"uint16", arrow.PrimitiveTypes.Uint16, []interface{}{uint16(65535), nil, uint16(16)}},
+		{"uint32", arrow.PrimitiveTypes.Uint32, []interface{}{uint32(4294967295), nil, uint32(32)}},
+		{"uint64", arrow.PrimitiveTypes.Uint64, []interface{}{uint64(18446744073709551615), nil, uint64(64)}},
+		{"float32", arrow.PrimitiveTypes.Float32, []interface{}{float32(1.5), nil, float32(-1.5)}},
+		{"float64", arrow.PrimitiveTypes.Float64, []interface{}{2.5, nil, -2.5}},
+		{"string", arrow.BinaryTypes.String, []interface{}{"a", nil, "b"}},
+		{"date32", arrow.FixedWidthTypes.Date32, []interface{}{arrow.Date32(18000), nil, arrow.Date32(-1)}},
+		{"date64", arrow.FixedWidthTypes.Date64, []interface{}{arrow.Date64(18000 * day), nil, arrow.Date64(-day)}},
+		{"id", arrow.PrimitiveTypes.Int64, []interface{}{int64(1), int64(2), int64(3)}},
+		{"timestamp_s", &arrow.TimestampType{Unit: arrow.Second, TimeZone: "UTC"}, []interface{}{arrow.Timestamp(1555000000), nil, arrow.Timestamp(-1)}},
+		{"timestamp_ms", &arrow.TimestampType{Unit: arrow.Millisecond, TimeZone: "UTC"}, []interface{}{arrow.Timestamp(1555000000123), nil, arrow.Timestamp(-1)}},
+		{"timestamp_us", &arrow.TimestampType{Unit: arrow.Microsecond}, []interface{}{arrow.Timestamp(1555000000123456), nil, arrow.Timestamp(-1)}},
+		{"timestamp_ns", &arrow.TimestampType{Unit: arrow.Nanosecond, TimeZone: "UTC"}, []interface{}{arrow.Timestamp(1555000000123456789), nil, arrow.Timestamp(-1)}},
+		{"time32_s", arrow.FixedWidthTypes.Time32s, []interface{}{arrow.Time32(3723), nil, arrow.Time32(0)}},
+		{"time32_ms", arrow.FixedWidthTypes.Time32ms, []interface{}{arrow.Time32(3723456), nil, arrow.Time32(0)}},
+		{"time64_us", arrow.FixedWidthTypes.Time64us, []interface{}{arrow.Time64(3723456789), nil, arrow.Time64(0)}},
+		{"time64_ns", arrow.FixedWidthTypes.Time64ns, []interface{}{arrow.Time64(3723456789012), nil, arrow.Time64(0)}},
+	}
+
+	cols := make([]array.Column, len(values))
+	for i, v := range values {
+		second := []interface{}{v.first[0], v.first[0]}
+		if v.name == "id" {
+			second = []interface{}{int64(4), int64(5)}
+		}
+		first, err := newArrayFromValues(mem, v.dtype, v.first)
+		if err != nil {
+			t.Fatal(err)
+		}
+		next, err := newArrayFromValues(mem, v.dtype, second)
+		if err != nil {
+			t.Fatal(err)
+		}
+		field := arrow.Field{Name: v.name, Type: v.dtype, Nullable: v.name != "id"}
+		cols[i] = *newColumnFromChunks(field, []array.Interface{first, next})
+		first.Release()
+		next.Release()
+	}
+	defer func() {
+		for i := range cols {
+			cols[i].Release()
+		}
+	}()
+
+	df, err := NewDataFrameFromColumns(mem, cols)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return df
+}
+
+func readParquetBytes(mem memory.Allocator, data []byte, opts ...Option) (*DataFrame, error) {
+	return ReadParquet(mem, bytes.NewReader(data), int64(len(data)), opts...)
+}
+
+func TestWriteParquet(t *testing.T) {
+	pool := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer pool.AssertSize(t, 0)
+
+	df := testParquetFrame(t, pool)
+	defer df.Release()
+
+	for _, tc := range testParquetFiles {
+		t.Run(tc.file, func(t *testing.T) {
+			var buf bytes.Buffer
+			err := WriteParquet(&buf, df, WithParquetEncoding(tc.encoding), WithParquetCompression(tc.compression))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			path := filepath.Join("testdata", tc.file)
+			if *updateParquet {
+				if err := ioutil.WriteFile(path, buf.Bytes(), 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			want, err := ioutil.ReadFile(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(buf.Bytes(), want) {
+				t.Fatalf("written file differs from %s, run the tests with -update-parquet if the change is expected", path)
+			}
+		})
+	}
+}
+
+func TestReadParquet(t *testing.T) {
+	pool := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer pool.AssertSize(t, 0)
+
+	for _, tc := range testParquetFiles {
+		t.Run(tc.file, func(t *testing.T) {
+			data, err := ioutil.ReadFile(filepath.Join("testdata", tc.file))
+			if err != nil {
+				t.Fatal(err)
+			}
+			checkParquetColumnChunks(t, data, tc.encoding, tc.compression)
+
+			df, err := readParquetBytes(pool, data)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer df.Release()
+
+			if got, want := df.Display(-1), testParquetDisplay; got != want {
+				t.Fatalf("\ngot=\n%v\nwant=\n%v", got, want)
+			}
+			fields := df.Schema().Fields()
+			if got, want := fields[13].Type, arrow.FixedWidthTypes.Date32; got != want {
+				t.Fatalf("got=%v, want=%v", got, want)
+			}
+			if !fields[0].Nullable || fields[14].Nullable {
+				t.Fatalf("got nullable %v and %v, want true and false", fields[0].Nullable, fields[14].Nullable)
+			}
+			for i, want := range []arrow.DataType{
+				&arrow.TimestampType{Unit: arrow.Millisecond, TimeZone: "UTC"},
+				&arrow.TimestampType{Unit: arrow.Millisecond, TimeZone: "UTC"},
+				&arrow.TimestampType{Unit: arrow.Microsecond},
+				&arrow.TimestampType{Unit: arrow.Nanosecond, TimeZone: "UTC"},
+				arrow.FixedWidthTypes.Time32ms,
+				arrow.FixedWidthTypes.Time32ms,
+				arrow.FixedWidthTypes.Time64us,
+				arrow.FixedWidthTypes.Time64ns,
+			} {
+				if got := fields[15+i].Type; !arrow.TypeEquals(got, want) {
+					t.Fatalf("column %s: got=%v, want=%v", fields[15+i].Name, got, want)
+				}
+			}
+		})
+	}
+}
+
+// checkParquetColumnChunks fails if the column chunks of the file do not have the encoding and compression.
+func checkParquetColumnChunks(t *testing.T, data []byte, encoding ParquetEncoding, compression ParquetCompression) {
+	t.Helper()
+
+	pr, err := reader.NewParquetColumnReader(&parquetFile{r: bytes.NewReader(data), size: int64(len(data))}, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer pr.ReadStop()
+
+	codec := parquet.CompressionCodec_UNCOMPRESSED
+	if compression == ParquetSnappy {
+		codec = parquet.CompressionCodec_SNAPPY
+	}
+	rowGroups := pr.Footer.GetRowGroups()
+	if len(rowGroups) != 2 {
+		t.Fatalf("got %d row groups, want 2", len(rowGroups))
+	}
+	for _, rowGroup := range rowGroups {
+		for _, chunk := range rowGroup.GetColumns() {
+			md := chunk.GetMetaData()
+			if md.GetCodec() != codec {
+				t.Fatalf("column %v: got codec %v, want %v", md.GetPathInSchema(), md.GetCodec(), codec)
+			}
+			dictionary := false
+			for _, e := range md.GetEncodings() {
+				if e == parquet.Encoding_PLAIN_DICTIONARY || e == parquet.Encoding_RLE_DICTIONARY {
+					dictionary = true
+				}
+			}
+			want := encoding == ParquetDictionary && md.GetType() != parquet.Type_BOOLEAN
+			if dictionary != want || (md.DictionaryPageOffset != nil) != want {
+				t.Fatalf("column %v: got dictionary encoding %v, want %v", md.GetPathInSchema(), dictionary, want)
+			}
+		}
+	}
+}
+
+func TestReadParquetColumns(t *testing.T) {
+	pool := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer pool.AssertSize(t, 0)
+
+	data, err := ioutil.ReadFile(filepath.Join("testdata", "dictionary_snappy.parquet"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	df, err := readParquetBytes(pool, data, WithColumns("id", "string", "uint64"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer df.Release()
+
+	got := df.Display(-1)
+	want := `rec[0]["id"]: [1 2 3]
+rec[0]["string"]: ["a" (null) "b"]
+rec[0]["uint64"]: [18446744073709551615 (null) 64]
+rec[1]["id"]: [4 5]
+rec[1]["string"]: ["a" "a"]
+rec[1]["uint64"]: [18446744073709551615 18446744073709551615]
+`
+	if got != want {
+		t.Fatalf("\ngot=\n%v\nwant=\n%v", got, want)
+	}
+}
+
+func TestReadParquetImpala(t *testing.T) {
+	pool := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer pool.AssertSize(t, 0)
+
+	// The INT96 timestamp_col of the files can't be read.
+	columns := WithColumns("id", "bool_col", "tinyint_col", "smallint_col", "int_col", "bigint_col",
+		"float_col", "double_col", "date_string_col", "string_col")
+	for _, tc := range []struct {
+		file string
+		want string
+	}{
+		{"alltypes_plain.parquet", `rec[0]["id"]: [4 5 6 7 2 3 0 1]
+rec[0]["bool_col"]: [true false true false true false true false]
+rec[0]["tinyint_col"]: [0 1 0 1 0 1 0 1]
+rec[0]["smallint_col"]: [0 1 0 1 0 1 0 1]
+rec[0]["int_col"]: [0 1 0 1 0 1 0 1]
+rec[0]["bigint_col"]: [0 10 0 10 0 10 0 10]
+rec[0]["float_col"]: [0 1.1 0 1.1 0 1.1 0 1.1]
+rec[0]["double_col"]: [0 10.1 0 10.1 0 10.1 0 10.1]
+rec[0]["date_string_col"]: ["03/01/09" "03/01/09" "04/01/09" "04/01/09" "02/01/09" "02/01/09" "01/01/09" "01/01/09"]
+rec[0]["string_col"]: ["0" "1" "0" "1" "0" "1" "0" "1"]
+`},
+		{"alltypes_dictionary.parquet", `rec[0]["id"]: [0 1]
+rec[0]["bool_col"]: [true false]
+rec[0]["tinyint_col"]: [0 1]
+rec[0]["smallint_col"]: [0 1]
+rec[0]["int_col"]: [0 1]
+rec[0]["bigint_col"]: [0 10]
+rec[0]["float_col"]: [0 1.1]
+rec[0]["double_col"]: [0 10.1]
+rec[0]["date_string_col"]: ["01/01/09" "01/01/09"]
+rec[0]["string_col"]: ["0" "1"]
+`},
+		{"alltypes_plain.snappy.parquet", `rec[0]["id"]: [6 7]
+rec[0]["bool_col"]: [true false]
+rec[0]["tinyint_col"]: [0 1]
+rec[0]["smallint_col"]: [0 1]
+rec[0]["int_col"]: [0 1]
+rec[0]["bigint_col"]: [0 10]
+rec[0]["float_col"]: [0 1.1]
+rec[0]["double_col"]: [0 10.1]
+rec[0]["date_string_col"]: ["04/01/09" "04/01/09"]
+rec[0]["string_col"]: ["0" "1"]
+`},
+	} {
+		t.Run(tc.file, func(t *testing.T) {
+			data, err := ioutil.ReadFile(filepath.Join("testdata", tc.file))
+			if err != nil {
+				t.Fatal(err)
+			}
+			df, err := readParquetBytes(pool, data, columns)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer df.Release()
+
+			if got, want := df.Display(-1), tc.want; got != want {
+				t.Fatalf("\ngot=\n%v\nwant=\n%v", got, want)
+			}
+			if !df.Schema().Field(0).Nullable {
+				t.Fatal("got a column that is not nullable, want optional columns to be nullable")
+			}
+		})
+	}
+}
+
+func TestParquetRoundTrip(t *testing.T) {
+	pool := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer pool.AssertSize(t, 0)
+
+	df := testParquetFrame(t, pool)
+	defer df.Release()
+
+	var buf bytes.Buffer
+	if err := WriteParquet(&buf, df, WithRowGroupSize(2)); err != nil {
+		t.Fatal(err)
+	}
+	got, err := readParquetBytes(pool, buf.Bytes())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer got.Release()
+
+	// The chunks of 3 and 2 rows are written as row groups of 2, 1 and 2 rows.
+	if n := len(got.Columns()[0].Data().Chunks()); n != 3 {
+		t.Fatalf("got %d chunks, want 3", n)
+	}
+	// The JSON of the dates is the same for the Date64 column and the Date32 column read back.
+	var gotJSON, wantJSON bytes.Buffer
+	if err := WriteJSONLines(&gotJSON, got); err != nil {
+		t.Fatal(err)
+	}
+	if err := WriteJSONLines(&wantJSON, df); err != nil {
+		t.Fatal(err)
+	}
+	if gotJSON.String() != wantJSON.String() {
+		t.Fatalf("\ngot=\n%v\nwant=\n%v", gotJSON.String(), wantJSON.String())
+	}
+
+	empty, err := NewDataFrameFromMem(pool, Dict{"a": []int64{}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer empty.Release()
+	buf.Reset()
+	if err := WriteParquet(&buf, empty); err != nil {
+		t.Fatal(err)
+	}
+	back, err := readParquetBytes(pool, buf.Bytes())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer back.Release()
+	if cols, rows := back.Dims(); cols != 1 || rows != 0 || back.Schema().Field(0).Type != arrow.PrimitiveTypes.Int64 {
+		t.Fatalf("got %d columns of %d rows with schema %v, want an empty Int64 column", cols, rows, back.Schema())
+	}
+}
+
+func TestParquetErrors(t *testing.T) {
+	pool := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer pool.AssertSize(t, 0)
+
+	data, err := ioutil.ReadFile(filepath.Join("testdata", "plain_uncompressed.parquet"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dtype := arrow.ListOf(arrow.PrimitiveTypes.Int64)
+	arr, err := newArrayFromValues(pool, dtype, []interface{}{[]interface{}{int64(1)}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer arr.Release()
+	lists, err := NewDataFrame(pool, arrow.NewSchema([]arrow.Field{{Name: "l", Type: dtype}}, nil), []array.Interface{arr})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer lists.Release()
+
+	for _, tc := range []struct {
+		name string
+		err  func() error
+		want string
+	}{
+		{"not parquet", func() error {
+			_, err := readParquetBytes(pool, []byte("not a parquet file"))
+			return err
+		}, "it is not a Parquet file"},
+		{"missing column", func() error {
+			_, err := readParquetBytes(pool, data, WithColumns("id", "missing"))
+			return err
+		}, "Parquet file has no column missing"},
+		{"int96 column", func() error {
+			data, err := ioutil.ReadFile(filepath.Join("testdata", "alltypes_plain.parquet"))
+			if err != nil {
+				return err
+			}
+			_, err = readParquetBytes(pool, data)
+			return err
+		}, "cannot read Parquet column timestamp_col of type INT96"},
+		{"repeated column", func() error {
+			_, err := readParquetBytes(pool, data, WithColumns("id", "id"))
+			return err
+		}, "column id is given more than once"},
+		{"unsupported type", func() error {
+			return WriteParquet(ioutil.Discard, lists)
+		}, "cannot write column l of type list<item: int64> to Parquet"},
+		{"wrong option", func() error {
+			return WriteParquet(ioutil.Discard, lists, WithChunkSize(1))
+		}, "cannot apply WithChunkSize to: *dataframe.parquetConfig"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.err()
+			if err == nil || !strings.Contains(err.Error(), tc.want) {
+				t.Fatalf("got error %v, want %q", err, tc.want)
+			}
+		})
+	}
+}
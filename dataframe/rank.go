@@ -0,0 +1,188 @@
+package dataframe
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/pkg/errors"
+)
+
+// RankMethod is how Rank ranks values that are equal.
+type RankMethod int
+
+const (
+	// RankAverage gives equal values the average of the ranks they span.
+	RankAverage RankMethod = iota
+	// RankMin gives equal values the lowest of the ranks they span.
+	RankMin
+	// RankMax gives equal values the highest of the ranks they span.
+	RankMax
+	// RankDense gives equal values the same rank and the next value the next rank, without gaps.
+	RankDense
+	// RankFirst gives equal values distinct ranks in the order of their rows.
+	RankFirst
+)
+
+func (r RankMethod) String() string {
+	switch r {
+	case RankAverage:
+		return "average"
+	case RankMin:
+		return "min"
+	case RankMax:
+		return "max"
+	case RankDense:
+		return "dense"
+	case RankFirst:
+		return "first"
+	default:
+		return fmt.Sprintf("RankMethod(%d)", int(r))
+	}
+}
+
+// Rank appends the rank of each value of the column within its partition, named e.g. "A_rank".
+// Values are ordered with the same rules as Element.Less, from smallest to largest when
+// ascending and from largest to smallest otherwise, and the first value has rank 1.
+// The rank is a float64 for RankAverage and an int64 for the other methods.
+// Null values are not ranked, their rank is null and they don't count towards the ranks of other values.
+// WithColumnName can be given as an option.
+func (m *Mutator) Rank(column string, method RankMethod, ascending bool, partitionBy []string, opts ...Option) MutationFunc {
+	resultType := arrow.DataType(arrow.PrimitiveTypes.Int64)
+	if method == RankAverage {
+		resultType = arrow.PrimitiveTypes.Float64
+	}
+
+	return m.window(column, append([]Option{WithPartitionBy(partitionBy...)}, opts...), windowFunc{
+		name: "rank",
+		resultType: func(dtype arrow.DataType) (arrow.DataType, error) {
+			if method < RankAverage || method > RankFirst {
+				return nil, errors.Errorf("invalid rank method %v", method)
+			}
+			if !isOrdered(dtype) {
+				return nil, errors.Errorf("%v cannot be ordered", dtype)
+			}
+			return resultType, nil
+		},
+		apply: func(dtype arrow.DataType, values []interface{}) ([]interface{}, error) {
+			result := make([]interface{}, len(values))
+			err := rankValues(dtype, values, ascending, func(rows []int, lowest, dense int) {
+				for i, row := range rows {
+					switch method {
+					case RankAverage:
+						result[row] = float64(2*lowest+len(rows)-1) / 2
+					case RankMin:
+						result[row] = int64(lowest)
+					case RankMax:
+						result[row] = int64(lowest + len(rows) - 1)
+					case RankDense:
+						result[row] = int64(dense)
+					case RankFirst:
+						result[row] = int64(lowest + i)
+					}
+				}
+			})
+			return result, err
+		},
+	})
+}
+
+// PercentRank appends the relative rank of each value of the column within its partition,
+// named e.g. "A_percent_rank". It is (rank - 1) / (n - 1) using the RankMin rank,
+// where n is the number of values that are not null, and is 0 when there is only one value.
+// The values are ordered and nulls are handled the same way as Rank.
+// WithColumnName can be given as an option.
+func (m *Mutator) PercentRank(column string, ascending bool, partitionBy []string, opts ...Option) MutationFunc {
+	return m.window(column, append([]Option{WithPartitionBy(partitionBy...)}, opts...), windowFunc{
+		name: "percent_rank",
+		resultType: func(dtype arrow.DataType) (arrow.DataType, error) {
+			if !isOrdered(dtype) {
+				return nil, errors.Errorf("%v cannot be ordered", dtype)
+			}
+			return arrow.PrimitiveTypes.Float64, nil
+		},
+		apply: func(dtype arrow.DataType, values []interface{}) ([]interface{}, error) {
+			n := 0
+			for _, v := range values {
+				if v != nil {
+					n++
+				}
+			}
+
+			result := make([]interface{}, len(values))
+			err := rankValues(dtype, values, ascending, func(rows []int, lowest, dense int) {
+				percent := 0.0
+				if n > 1 {
+					percent = float64(lowest-1) / float64(n-1)
+				}
+				for _, row := range rows {
+					result[row] = percent
+				}
+			})
+			return result, err
+		},
+	})
+}
+
+// RowNumber appends the number of each row within its partition, starting at 1, as an int64
+// column named "row_number". The rows are numbered in the order of the orderBy columns,
+// ordered the same way as WithOrderBy, or in the order of the rows when orderBy is empty.
+// WithColumnName can be given as an option.
+func (m *Mutator) RowNumber(partitionBy, orderBy []string, opts ...Option) MutationFunc {
+	opts = append([]Option{WithPartitionBy(partitionBy...), WithOrderBy(orderBy...)}, opts...)
+	return m.window("", opts, windowFunc{
+		name: "row_number",
+		resultType: func(arrow.DataType) (arrow.DataType, error) {
+			return arrow.PrimitiveTypes.Int64, nil
+		},
+		apply: func(_ arrow.DataType, values []interface{}) ([]interface{}, error) {
+			result := make([]interface{}, len(values))
+			for i := range result {
+				result[i] = int64(i + 1)
+			}
+			return result, nil
+		},
+	})
+}
+
+// rankValues sorts the values that are not null and calls fn with each group of
+// equal values, in order. rows are the indexes of the values in the order they appear,
+// lowest is the lowest rank of the group and dense is the number of the group.
+func rankValues(dtype arrow.DataType, values []interface{}, ascending bool, fn func(rows []int, lowest, dense int)) error {
+	rows := make([]int, 0, len(values))
+	for i, v := range values {
+		if v != nil {
+			rows = append(rows, i)
+		}
+	}
+
+	var sortErr error
+	compare := func(i, j int) int {
+		cmp, err := compareValues(dtype, values[i], values[j])
+		if err != nil && sortErr == nil {
+			sortErr = err
+		}
+		if !ascending {
+			cmp = -cmp
+		}
+		return cmp
+	}
+	sort.SliceStable(rows, func(i, j int) bool {
+		return compare(rows[i], rows[j]) < 0
+	})
+	if sortErr != nil {
+		return sortErr
+	}
+
+	dense := 0
+	for start := 0; start < len(rows); {
+		end := start + 1
+		for end < len(rows) && compare(rows[start], rows[end]) == 0 {
+			end++
+		}
+		dense++
+		fn(rows[start:end], start+1, dense)
+		start = end
+	}
+	return sortErr
+}
@@ -0,0 +1,110 @@
+package dataframe
+
+import (
+	"testing"
+
+	"github.com/apache/arrow/go/arrow/memory"
+)
+
+func TestRank(t *testing.T) {
+	pool := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer pool.AssertSize(t, 0)
+
+	df, err := NewDataFrameFromMem(pool, Dict{
+		"A": []interface{}{int64(3), int64(1), nil, int64(3), int64(2), int64(3)},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer df.Release()
+
+	m := NewMutator(pool)
+	fns := []MutationFunc{}
+	for _, method := range []RankMethod{RankAverage, RankMin, RankMax, RankDense, RankFirst} {
+		fns = append(fns, m.Rank("A", method, true, nil, WithColumnName(method.String())))
+	}
+	fns = append(fns,
+		m.Rank("A", RankMin, false, nil, WithColumnName("desc")),
+		m.PercentRank("A", true, nil),
+	)
+	resultDf, err := df.Apply(fns...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resultDf.Release()
+
+	got := resultDf.Display(-1)
+	want := `rec[0]["A"]: [3 1 (null) 3 2 3]
+rec[0]["average"]: [4 1 (null) 4 2 4]
+rec[0]["min"]: [3 1 (null) 3 2 3]
+rec[0]["max"]: [5 1 (null) 5 2 5]
+rec[0]["dense"]: [3 1 (null) 3 2 3]
+rec[0]["first"]: [3 1 (null) 4 2 5]
+rec[0]["desc"]: [1 5 (null) 1 4 1]
+rec[0]["A_percent_rank"]: [0.5 0 (null) 0.5 0.25 0.5]
+`
+	if got != want {
+		t.Fatalf("\ngot=\n%v\nwant=\n%v", got, want)
+	}
+}
+
+func TestRankPartition(t *testing.T) {
+	pool := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer pool.AssertSize(t, 0)
+
+	df, err := NewDataFrameFromMem(pool, Dict{
+		"Player": []string{"a", "b", "c", "d", "e"},
+		"Team":   []string{"x", "y", "x", "x", "y"},
+		"Score":  []float64{10, 7, 12, 10, 9},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer df.Release()
+
+	m := NewMutator(pool)
+	resultDf, err := df.Apply(
+		m.Rank("Score", RankDense, false, []string{"Team"}),
+		m.RowNumber([]string{"Team"}, []string{"Score"}),
+		m.RowNumber(nil, nil, WithColumnName("n")),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resultDf.Release()
+
+	got := resultDf.Display(-1)
+	want := `rec[0]["Player"]: ["a" "b" "c" "d" "e"]
+rec[0]["Score"]: [10 7 12 10 9]
+rec[0]["Team"]: ["x" "y" "x" "x" "y"]
+rec[0]["Score_rank"]: [2 2 1 2 1]
+rec[0]["row_number"]: [1 1 3 2 2]
+rec[0]["n"]: [1 2 3 4 5]
+`
+	if got != want {
+		t.Fatalf("\ngot=\n%v\nwant=\n%v", got, want)
+	}
+}
+
+func TestRankErrors(t *testing.T) {
+	pool := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer pool.AssertSize(t, 0)
+
+	df, err := NewDataFrameFromMem(pool, Dict{
+		"A": []int64{1, 2},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer df.Release()
+
+	if _, err := df.Rank("X", RankMin, true, nil); err == nil || err.Error() != "bullseye/mutations: column X does not exist" {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := df.Rank("A", RankMethod(10), true, nil); err == nil || err.Error() != "bullseye/mutations: cannot compute rank of column A: invalid rank method RankMethod(10)" {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := df.RowNumber(nil, []string{"X"}); err == nil || err.Error() != "bullseye/mutations: column X does not exist" {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
@@ -0,0 +1,338 @@
+package dataframe
+
+import (
+	"fmt"
+	"math/bits"
+	"sort"
+	"time"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/go-bullseye/bullseye/timeops"
+	"github.com/pkg/errors"
+)
+
+// ResampleRule is the width of the buckets Resample groups rows into.
+type ResampleRule struct {
+	every    time.Duration
+	unit     timeops.Unit
+	calendar bool
+}
+
+// Every creates a rule for fixed buckets of the duration, aligned to the Unix epoch.
+func Every(d time.Duration) ResampleRule {
+	return ResampleRule{every: d}
+}
+
+// EveryCalendar creates a rule for calendar buckets of the unit, like days or months, starting
+// at midnight in the time zone of the column. See timeops.Truncate for where the units start.
+func EveryCalendar(unit timeops.Unit) ResampleRule {
+	return ResampleRule{unit: unit, calendar: true}
+}
+
+func (r ResampleRule) String() string {
+	if r.calendar {
+		return r.unit.String()
+	}
+	return r.every.String()
+}
+
+// start returns the start of the bucket t is in.
+func (r ResampleRule) start(t time.Time) time.Time {
+	if r.calendar {
+		return timeops.TruncateTime(t, r.unit)
+	}
+	return t.Add(-sinceStart(t, r.every))
+}
+
+// sinceStart returns the time from the start of the bucket of duration d that t is in,
+// where buckets are aligned to the Unix epoch. t is read as seconds and nanoseconds,
+// not UnixNano, so it works for every year.
+func sinceStart(t time.Time, d time.Duration) time.Duration {
+	sec, nsec := t.Unix(), int64(t.Nanosecond())
+	neg := sec < 0
+	if neg {
+		// -t is -sec seconds minus nsec nanoseconds.
+		sec, nsec = -(sec + 1), int64(time.Second)-nsec
+	}
+	// The remainder of the nanoseconds from the epoch, which can take up to 94 bits.
+	hi, lo := bits.Mul64(uint64(sec), uint64(time.Second))
+	lo, carry := bits.Add64(lo, uint64(nsec), 0)
+	_, rem := bits.Div64((hi+carry)%uint64(d), lo, uint64(d))
+	if neg && rem != 0 {
+		rem = uint64(d) - rem
+	}
+	return time.Duration(rem)
+}
+
+// bucketKey is the start of a bucket as seconds and nanoseconds from the Unix epoch.
+type bucketKey struct {
+	sec  int64
+	nsec int
+}
+
+func newBucketKey(t time.Time) bucketKey {
+	return bucketKey{sec: t.Unix(), nsec: t.Nanosecond()}
+}
+
+func (k bucketKey) less(other bucketKey) bool {
+	return k.sec < other.sec || (k.sec == other.sec && k.nsec < other.nsec)
+}
+
+func (k bucketKey) time(loc *time.Location) time.Time {
+	return time.Unix(k.sec, int64(k.nsec)).In(loc)
+}
+
+// next returns the start of the bucket after the bucket starting at t.
+func (r ResampleRule) next(t time.Time) time.Time {
+	return r.nth(t, t, 1)
+}
+
+// nth returns start moved forward by k steps of the rule, where prev is start moved forward
+// by k-1 steps. Durations are added to prev, since k times the duration can overflow a
+// time.Duration. Calendar days and longer are counted from start, so the day of the month
+// of start is kept, and months and years are added with timeops.AddMonthsTime so it doesn't overflow.
+func (r ResampleRule) nth(start, prev time.Time, k int) time.Time {
+	if !r.calendar {
+		return prev.Add(r.every)
+	}
+	switch r.unit {
+	case timeops.UnitHour:
+		return prev.Add(time.Hour)
+	case timeops.UnitWeek:
+		return start.AddDate(0, 0, 7*k)
+	case timeops.UnitMonth:
+		return timeops.AddMonthsTime(start, k)
+	case timeops.UnitYear:
+		return timeops.AddMonthsTime(start, 12*k)
+	default:
+		return start.AddDate(0, 0, k)
+	}
+}
+
+// validate returns an error if the rule can't be used for a column of type dtype.
+// The error names what the rule makes, e.g. "buckets".
+func (r ResampleRule) validate(dtype arrow.DataType, what string) error {
+	day := 24 * time.Hour
+	switch {
+	case r.calendar && (r.unit < timeops.UnitHour || r.unit > timeops.UnitYear):
+		return errors.Errorf("invalid unit %v", r.unit)
+	case !r.calendar && r.every <= 0:
+		return errors.Errorf("the duration must be positive: %v", r.every)
+	case isDate(dtype) && r.calendar && r.unit == timeops.UnitHour,
+		isDate(dtype) && !r.calendar && r.every%day != 0:
+		return errors.Errorf("%s of %v are shorter than the days of %v", what, r, dtype)
+	}
+	return nil
+}
+
+// Aggregation is an aggregate of a column. The aggregate is named Name,
+// or after the column and the function, e.g. "A_sum", when Name is empty.
+type Aggregation struct {
+	Column string
+	Func   AggFunc
+	Name   string
+}
+
+func (a Aggregation) name() string {
+	if a.Name != "" {
+		return a.Name
+	}
+	return fmt.Sprintf("%s_%v", a.Column, a.Func)
+}
+
+// ResampleFill is how Resample fills buckets without any rows.
+type ResampleFill int
+
+const (
+	// ResampleFillNull makes the aggregates of empty buckets null, except for counts which are 0.
+	ResampleFillNull ResampleFill = iota
+	// ResampleFillForward copies the aggregates of the previous bucket into empty buckets.
+	ResampleFillForward
+	// ResampleDropEmpty leaves out empty buckets.
+	ResampleDropEmpty
+)
+
+func (f ResampleFill) String() string {
+	switch f {
+	case ResampleFillNull:
+		return "null"
+	case ResampleFillForward:
+		return "forward"
+	case ResampleDropEmpty:
+		return "drop"
+	default:
+		return fmt.Sprintf("ResampleFill(%d)", int(f))
+	}
+}
+
+type resampleConfig struct {
+	fill ResampleFill
+}
+
+func newResampleConfig(opts ...Option) (*resampleConfig, error) {
+	cfg := &resampleConfig{}
+	for _, opt := range opts {
+		if err := opt(cfg); err != nil {
+			return nil, err
+		}
+	}
+	return cfg, nil
+}
+
+// WithResampleFill sets how Resample fills buckets without any rows. The default is ResampleFillNull.
+func WithResampleFill(fill ResampleFill) Option {
+	return func(p interface{}) error {
+		cfg, ok := p.(*resampleConfig)
+		if !ok {
+			return errors.Errorf("cannot apply WithResampleFill to: %T", p)
+		}
+		if fill < ResampleFillNull || fill > ResampleDropEmpty {
+			return errors.Errorf("invalid resample fill %v", fill)
+		}
+		cfg.fill = fill
+		return nil
+	}
+}
+
+// Resample groups the rows into buckets of the rule by the Date32, Date64 or Timestamp column timeCol
+// and aggregates each bucket. The result has a row for each bucket from the first to the last, sorted
+// by the start of the bucket in a column named timeCol of the same type, followed by a column for each
+// aggregation. Rows where timeCol is null are left out. Buckets without any rows are filled following
+// WithResampleFill. Timestamps with a time zone are bucketed in that time zone.
+func (m *Mutator) Resample(timeCol string, rule ResampleRule, aggs []Aggregation, opts ...Option) MutationFunc {
+	return func(df *DataFrame) (*DataFrame, error) {
+		cfg, err := newResampleConfig(opts...)
+		if err != nil {
+			return nil, err
+		}
+
+		col := df.Column(timeCol)
+		if col == nil {
+			return nil, errors.Errorf("bullseye/mutations: column %s does not exist", timeCol)
+		}
+		dtype := col.DataType()
+		if !isTemporal(dtype) {
+			return nil, errors.Errorf("bullseye/mutations: cannot resample by column %s of type %v", timeCol, dtype)
+		}
+		if err := rule.validate(dtype, "buckets"); err != nil {
+			return nil, errors.Wrapf(err, "bullseye/mutations: cannot resample column %s", timeCol)
+		}
+		loc, err := timeops.Location(dtype)
+		if err != nil {
+			return nil, err
+		}
+
+		fields := []arrow.Field{{Name: timeCol, Type: dtype}}
+		aggValues := make([][]interface{}, len(aggs))
+		aggTypes := make([]arrow.DataType, len(aggs))
+		taken := map[string]struct{}{timeCol: {}}
+		for i, agg := range aggs {
+			aggCol := df.Column(agg.Column)
+			if aggCol == nil {
+				return nil, errors.Errorf("bullseye/mutations: column %s does not exist", agg.Column)
+			}
+			resultType, err := agg.Func.resultType(aggCol.DataType())
+			if err != nil {
+				return nil, errors.Wrapf(err, "bullseye/mutations: cannot resample column %s", agg.Column)
+			}
+			name := agg.name()
+			if _, ok := taken[name]; ok {
+				return nil, errors.Errorf("bullseye/mutations: column %s is given more than once", name)
+			}
+			taken[name] = struct{}{}
+
+			fields = append(fields, arrow.Field{Name: name, Type: resultType, Nullable: true})
+			aggValues[i] = columnValues(aggCol)
+			aggTypes[i] = aggCol.DataType()
+		}
+
+		buckets := make(map[bucketKey][]aggregator)
+		for row, v := range columnValues(col) {
+			if v == nil {
+				continue
+			}
+			start := newBucketKey(rule.start(toTime(v, dtype).In(loc)))
+			aggregators, ok := buckets[start]
+			if !ok {
+				aggregators = make([]aggregator, len(aggs))
+				for i, agg := range aggs {
+					aggregators[i] = agg.Func.newAggregator(aggTypes[i])
+				}
+				buckets[start] = aggregators
+			}
+			for i := range aggs {
+				if err := aggregators[i].add(aggValues[i][row]); err != nil {
+					return nil, err
+				}
+			}
+		}
+
+		builder, err := newColumnBuilder(m.mem, arrow.NewSchema(fields, nil))
+		if err != nil {
+			return nil, err
+		}
+		defer builder.Release()
+
+		starts := make([]bucketKey, 0, len(buckets))
+		for start := range buckets {
+			starts = append(starts, start)
+		}
+		sort.Slice(starts, func(i, j int) bool { return starts[i].less(starts[j]) })
+		if cfg.fill == ResampleDropEmpty {
+			for _, start := range starts {
+				if err := appendBucket(builder, start.time(loc), dtype, buckets[start], nil); err != nil {
+					return nil, err
+				}
+			}
+			return builder.NewDataFrame()
+		}
+
+		var previous []aggregator
+		for i := 0; i < len(starts); i++ {
+			// Walk the buckets from the first start, filling the buckets in between.
+			if i > 0 {
+				for t := rule.next(starts[i-1].time(loc)); newBucketKey(t).less(starts[i]); t = rule.next(t) {
+					aggregators, empty := previous, []Aggregation(nil)
+					if cfg.fill == ResampleFillNull {
+						aggregators, empty = nil, aggs
+					}
+					if err := appendBucket(builder, t, dtype, aggregators, empty); err != nil {
+						return nil, err
+					}
+				}
+			}
+			previous = buckets[starts[i]]
+			if err := appendBucket(builder, starts[i].time(loc), dtype, previous, nil); err != nil {
+				return nil, err
+			}
+		}
+
+		return builder.NewDataFrame()
+	}
+}
+
+// appendBucket appends a row with the start of the bucket and the values of the aggregators.
+// When empty is not nil the bucket is empty and the aggregations are appended as nulls,
+// or zero counts, instead.
+func appendBucket(builder *columnBuilder, start time.Time, dtype arrow.DataType, aggregators []aggregator, empty []Aggregation) error {
+	v, err := fromTime(start, dtype)
+	if err != nil {
+		return err
+	}
+	builder.Append(0, v)
+
+	if empty != nil {
+		for i, agg := range empty {
+			var v interface{}
+			if agg.Func == AggCount {
+				v = int64(0)
+			}
+			builder.Append(i+1, v)
+		}
+		return nil
+	}
+	for i, a := range aggregators {
+		builder.Append(i+1, a.value())
+	}
+	return nil
+}
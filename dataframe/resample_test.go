@@ -0,0 +1,240 @@
+package dataframe
+
+import (
+	"testing"
+	"time"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/memory"
+	"github.com/go-bullseye/bullseye/timeops"
+)
+
+// newTimestampDataFrame creates a DataFrame with the RFC 3339 times cast to dtype as column T and the values as column V.
+func newTimestampDataFrame(t *testing.T, mem memory.Allocator, dtype arrow.DataType, times []interface{}, values []interface{}) *DataFrame {
+	t.Helper()
+	df, err := NewDataFrameFromMem(mem, Dict{"T": times, "V": values})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer df.Release()
+
+	castDf, err := df.Cast(map[string]arrow.DataType{"T": dtype}, WithLayout(time.RFC3339))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return castDf
+}
+
+func TestResample(t *testing.T) {
+	pool := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer pool.AssertSize(t, 0)
+
+	df := newTimestampDataFrame(t, pool, &arrow.TimestampType{Unit: arrow.Second},
+		[]interface{}{"2019-06-01T10:07:00Z", "2019-06-01T10:01:00Z", nil, "2019-06-01T10:21:30Z", "2019-06-01T10:04:59Z"},
+		[]interface{}{int64(1), int64(2), int64(100), nil, int64(4)},
+	)
+	defer df.Release()
+
+	aggs := []Aggregation{
+		{Column: "V", Func: AggSum},
+		{Column: "V", Func: AggCount, Name: "n"},
+	}
+	tests := []struct {
+		name string
+		fill ResampleFill
+		want string
+	}{
+		{
+			name: "null",
+			fill: ResampleFillNull,
+			want: `rec[0]["T"]: ["2019-06-01T10:00:00Z" "2019-06-01T10:05:00Z" "2019-06-01T10:10:00Z" "2019-06-01T10:15:00Z" "2019-06-01T10:20:00Z"]
+rec[0]["V_sum"]: [6 1 (null) (null) (null)]
+rec[0]["n"]: [2 1 0 0 0]
+`,
+		},
+		{
+			name: "forward",
+			fill: ResampleFillForward,
+			want: `rec[0]["T"]: ["2019-06-01T10:00:00Z" "2019-06-01T10:05:00Z" "2019-06-01T10:10:00Z" "2019-06-01T10:15:00Z" "2019-06-01T10:20:00Z"]
+rec[0]["V_sum"]: [6 1 1 1 (null)]
+rec[0]["n"]: [2 1 1 1 0]
+`,
+		},
+		{
+			name: "drop",
+			fill: ResampleDropEmpty,
+			want: `rec[0]["T"]: ["2019-06-01T10:00:00Z" "2019-06-01T10:05:00Z" "2019-06-01T10:20:00Z"]
+rec[0]["V_sum"]: [6 1 (null)]
+rec[0]["n"]: [2 1 0]
+`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			resampledDf, err := df.Resample("T", Every(5*time.Minute), aggs, WithResampleFill(tc.fill))
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer resampledDf.Release()
+
+			stringDf, err := resampledDf.Cast(map[string]arrow.DataType{"T": arrow.BinaryTypes.String})
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer stringDf.Release()
+
+			if got := stringDf.Display(-1); got != tc.want {
+				t.Fatalf("\ngot=\n%v\nwant=\n%v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestResampleCalendar(t *testing.T) {
+	pool := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer pool.AssertSize(t, 0)
+
+	dates := newTimestampDataFrame(t, pool, arrow.FixedWidthTypes.Date32,
+		[]interface{}{"2019-01-31T00:00:00Z", "2019-03-02T00:00:00Z", "2019-01-01T00:00:00Z"},
+		[]interface{}{1.5, 2.5, 3.5},
+	)
+	defer dates.Release()
+
+	resampledDf, err := dates.Resample("T", EveryCalendar(timeops.UnitMonth), []Aggregation{{Column: "V", Func: AggMean}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resampledDf.Release()
+
+	stringDf, err := resampledDf.Cast(map[string]arrow.DataType{"T": arrow.BinaryTypes.String})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer stringDf.Release()
+
+	got := stringDf.Display(-1)
+	want := `rec[0]["T"]: ["2019-01-01" "2019-02-01" "2019-03-01"]
+rec[0]["V_mean"]: [2.5 (null) 2.5]
+`
+	if got != want {
+		t.Fatalf("\ngot=\n%v\nwant=\n%v", got, want)
+	}
+
+	// Days start at midnight in New York, 04:00 UTC in the summer.
+	stamps := newTimestampDataFrame(t, pool, &arrow.TimestampType{Unit: arrow.Second, TimeZone: "America/New_York"},
+		[]interface{}{"2019-06-01T03:00:00Z", "2019-06-01T05:00:00Z"},
+		[]interface{}{int64(1), int64(2)},
+	)
+	defer stamps.Release()
+
+	resampledDf2, err := stamps.Resample("T", EveryCalendar(timeops.UnitDay), []Aggregation{{Column: "V", Func: AggFirst}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resampledDf2.Release()
+
+	stringDf2, err := resampledDf2.Cast(map[string]arrow.DataType{"T": arrow.BinaryTypes.String})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer stringDf2.Release()
+
+	got = stringDf2.Display(-1)
+	want = `rec[0]["T"]: ["2019-05-31T04:00:00Z" "2019-06-01T04:00:00Z"]
+rec[0]["V_first"]: [1 2]
+`
+	if got != want {
+		t.Fatalf("\ngot=\n%v\nwant=\n%v", got, want)
+	}
+}
+
+func TestResampleOutOfNanoseconds(t *testing.T) {
+	pool := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer pool.AssertSize(t, 0)
+
+	// Nanosecond times only reach from 1678 to 2262.
+	tests := []struct {
+		name  string
+		dtype arrow.DataType
+		times []interface{}
+		rule  ResampleRule
+		fill  ResampleFill
+		want  string
+	}{
+		{
+			name:  "timestamp",
+			dtype: &arrow.TimestampType{Unit: arrow.Millisecond},
+			times: []interface{}{"1000-06-15T12:30:00.5Z", "1000-06-15T12:30:01Z", "1000-06-15T12:30:04Z"},
+			rule:  Every(1500 * time.Millisecond),
+			fill:  ResampleFillNull,
+			want: `rec[0]["T"]: ["1000-06-15T12:30:00Z" "1000-06-15T12:30:01.5Z" "1000-06-15T12:30:03Z"]
+rec[0]["V_sum"]: [3 (null) 4]
+`,
+		},
+		{
+			name:  "date",
+			dtype: arrow.FixedWidthTypes.Date64,
+			times: []interface{}{"1000-06-15T00:00:00Z", "1000-06-18T00:00:00Z", "3000-01-01T00:00:00Z"},
+			rule:  Every(7 * 24 * time.Hour),
+			fill:  ResampleDropEmpty,
+			want: `rec[0]["T"]: ["1000-06-12" "2999-12-26"]
+rec[0]["V_sum"]: [3 4]
+`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			df := newTimestampDataFrame(t, pool, tc.dtype, tc.times, []interface{}{int64(1), int64(2), int64(4)})
+			defer df.Release()
+
+			resampledDf, err := df.Resample("T", tc.rule, []Aggregation{{Column: "V", Func: AggSum}}, WithResampleFill(tc.fill))
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer resampledDf.Release()
+
+			stringDf, err := resampledDf.Cast(map[string]arrow.DataType{"T": arrow.BinaryTypes.String})
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer stringDf.Release()
+
+			if got := stringDf.Display(-1); got != tc.want {
+				t.Fatalf("\ngot=\n%v\nwant=\n%v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestResampleErrors(t *testing.T) {
+	pool := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer pool.AssertSize(t, 0)
+
+	df := newTimestampDataFrame(t, pool, arrow.FixedWidthTypes.Date32,
+		[]interface{}{"2019-01-31T00:00:00Z"},
+		[]interface{}{"a"},
+	)
+	defer df.Release()
+
+	tests := []struct {
+		name string
+		fn   MutationFunc
+		want string
+	}{
+		{"not temporal", NewMutator(pool).Resample("V", Every(time.Hour), nil), "bullseye/mutations: cannot resample by column V of type utf8"},
+		{"shorter than days", NewMutator(pool).Resample("T", Every(time.Hour), nil), "bullseye/mutations: cannot resample column T: buckets of 1h0m0s are shorter than the days of date32"},
+		{"bad aggregate", NewMutator(pool).Resample("T", Every(24*time.Hour), []Aggregation{{Column: "V", Func: AggSum}}), "bullseye/mutations: cannot resample column V: cannot sum a utf8"},
+		{"duplicate", NewMutator(pool).Resample("T", Every(24*time.Hour), []Aggregation{{Column: "V", Func: AggCount}, {Column: "V", Func: AggCount}}), "bullseye/mutations: column V_count is given more than once"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := tc.fn(df)
+			if err == nil || err.Error() != tc.want {
+				t.Fatalf("got=%v, want=%v", err, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,321 @@
+package dataframe
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/go-bullseye/bullseye/iterator"
+	"github.com/pkg/errors"
+)
+
+const (
+	// DefaultMeltVarName is the name of the column holding the names of the melted columns.
+	DefaultMeltVarName = "variable"
+	// DefaultMeltValueName is the name of the column holding the values of the melted columns.
+	DefaultMeltValueName = "value"
+)
+
+// Pivot returns a DataFrame reshaped from long to wide. There is one row for each distinct combination
+// of the index columns, in the order they first appear, followed by one column for each distinct value
+// of the columns column. Those columns are sorted by value and named after it, with anything other than
+// letters, digits, '_', '-' and '.' replaced by '_'. Each cell is the aggregate of the values column for
+// the rows with that index and column value, or null if there weren't any. Rows where the columns column
+// is null are ignored.
+func (m *Mutator) Pivot(index []string, columns string, values string, agg AggFunc) MutationFunc {
+	return func(df *DataFrame) (*DataFrame, error) {
+		names := append(append([]string{}, index...), columns, values)
+		cols := make([]array.Column, len(names))
+		for i, name := range names {
+			col := df.Column(name)
+			if col == nil {
+				return nil, errors.Errorf("bullseye/mutations: column %s does not exist", name)
+			}
+			cols[i] = *col
+		}
+		pivotIdx, valueIdx := len(index), len(index)+1
+
+		pivotType := cols[pivotIdx].DataType()
+		if !isOrdered(pivotType) {
+			return nil, errors.Errorf("bullseye/mutations: cannot pivot on column %s of type %v", columns, pivotType)
+		}
+		valueType := cols[valueIdx].DataType()
+		resultType, err := agg.resultType(valueType)
+		if err != nil {
+			return nil, errors.Wrapf(err, "bullseye/mutations: cannot pivot column %s", values)
+		}
+
+		type cell struct {
+			row, col int
+		}
+		var (
+			rows        [][]interface{}
+			rowKeys     = make(map[string]int)
+			pivotValues []interface{}
+			pivotKeys   = make(map[string]int)
+			cells       = make(map[cell]aggregator)
+		)
+
+		it := iterator.NewStepIteratorForColumns(cols)
+		defer it.Release()
+		for it.Next() {
+			stepValues := it.Values().Values
+			pivotValue := stepValues[pivotIdx]
+			if pivotValue == nil {
+				continue
+			}
+
+			key := rowKey(stepValues[:pivotIdx])
+			r, ok := rowKeys[key]
+			if !ok {
+				r = len(rows)
+				rowKeys[key] = r
+				rows = append(rows, stepValues[:pivotIdx])
+			}
+
+			key = rowKey(stepValues[pivotIdx : pivotIdx+1])
+			c, ok := pivotKeys[key]
+			if !ok {
+				c = len(pivotValues)
+				pivotKeys[key] = c
+				pivotValues = append(pivotValues, pivotValue)
+			}
+
+			a, ok := cells[cell{r, c}]
+			if !ok {
+				a = agg.newAggregator(valueType)
+				cells[cell{r, c}] = a
+			}
+			if err := a.add(stepValues[valueIdx]); err != nil {
+				return nil, err
+			}
+		}
+
+		// Sort the new columns by their value so the order doesn't depend on the order of the rows.
+		order := make([]int, len(pivotValues))
+		for i := range order {
+			order[i] = i
+		}
+		var sortErr error
+		sort.SliceStable(order, func(i, j int) bool {
+			cmp, err := compareValues(pivotType, pivotValues[order[i]], pivotValues[order[j]])
+			if err != nil && sortErr == nil {
+				sortErr = err
+			}
+			return cmp < 0
+		})
+		if sortErr != nil {
+			return nil, sortErr
+		}
+
+		fields := make([]arrow.Field, 0, len(index)+len(order))
+		taken := make(map[string]struct{}, cap(fields))
+		for i := range index {
+			fields = append(fields, cols[i].Field())
+			taken[index[i]] = struct{}{}
+		}
+		for _, c := range order {
+			name := uniqueName(pivotColumnName(pivotValues[c], pivotType), taken)
+			fields = append(fields, arrow.Field{Name: name, Type: resultType, Nullable: true})
+		}
+
+		builder, err := newColumnBuilder(m.mem, arrow.NewSchema(fields, nil))
+		if err != nil {
+			return nil, err
+		}
+		defer builder.Release()
+
+		for r, row := range rows {
+			for i, v := range row {
+				builder.Append(i, v)
+			}
+			for i, c := range order {
+				var v interface{}
+				if a, ok := cells[cell{r, c}]; ok {
+					v = a.value()
+				}
+				builder.Append(len(index)+i, v)
+			}
+		}
+
+		return builder.NewDataFrame()
+	}
+}
+
+// Melt returns a DataFrame reshaped from wide to long, the reverse of Pivot. Each of the valueVars
+// columns becomes rows with the idVars columns, a varName column holding the name of the column and
+// a valueName column holding its values. All of the other columns are used when valueVars is empty.
+// The rows of the first column come first, followed by the rows of the second column and so on.
+// When the value columns have different types, numeric columns are converted to a type that can
+// hold all of them and anything else is converted to a string. varName and valueName default to
+// DefaultMeltVarName and DefaultMeltValueName when they are empty.
+func (m *Mutator) Melt(idVars, valueVars []string, varName, valueName string) MutationFunc {
+	return func(df *DataFrame) (*DataFrame, error) {
+		if varName == "" {
+			varName = DefaultMeltVarName
+		}
+		if valueName == "" {
+			valueName = DefaultMeltValueName
+		}
+
+		idCols := make([]array.Column, len(idVars))
+		ids := make(map[string]struct{}, len(idVars))
+		for i, name := range idVars {
+			col := df.Column(name)
+			if col == nil {
+				return nil, errors.Errorf("bullseye/mutations: column %s does not exist", name)
+			}
+			idCols[i] = *col
+			ids[name] = struct{}{}
+		}
+
+		var valueCols []array.Column
+		if len(valueVars) == 0 {
+			valueCols = df.RejectColumns(idVars...)
+		} else {
+			for _, name := range valueVars {
+				col := df.Column(name)
+				if col == nil {
+					return nil, errors.Errorf("bullseye/mutations: column %s does not exist", name)
+				}
+				valueCols = append(valueCols, *col)
+			}
+		}
+		if len(valueCols) == 0 {
+			return nil, errors.New("bullseye/mutations: Melt requires at least one value column")
+		}
+
+		for _, name := range []string{varName, valueName} {
+			if _, ok := ids[name]; ok {
+				return nil, errors.Errorf("bullseye/mutations: melt column %s conflicts with an id column", name)
+			}
+		}
+		if varName == valueName {
+			return nil, errors.Errorf("bullseye/mutations: melt var name and value name cannot both be %s", varName)
+		}
+
+		valueType, nullable, err := unifyTypes(valueCols)
+		if err != nil {
+			return nil, err
+		}
+
+		fields := make([]arrow.Field, 0, len(idCols)+2)
+		for i := range idCols {
+			fields = append(fields, idCols[i].Field())
+		}
+		fields = append(fields,
+			arrow.Field{Name: varName, Type: arrow.BinaryTypes.String},
+			arrow.Field{Name: valueName, Type: valueType, Nullable: nullable},
+		)
+
+		builder, err := newColumnBuilder(m.mem, arrow.NewSchema(fields, nil))
+		if err != nil {
+			return nil, err
+		}
+		defer builder.Release()
+
+		cfg := &castConfig{}
+		for i := range valueCols {
+			valueCol := &valueCols[i]
+			from := valueCol.DataType()
+			cast := !reflect.DeepEqual(from, valueType)
+
+			err := func() error {
+				it := iterator.NewStepIteratorForColumns(append(append([]array.Column{}, idCols...), *valueCol))
+				defer it.Release()
+				for it.Next() {
+					stepValues := it.Values().Values
+					for j := range idCols {
+						builder.Append(j, stepValues[j])
+					}
+					builder.Append(len(idCols), valueCol.Name())
+
+					v := stepValues[len(idCols)]
+					if cast && v != nil {
+						var err error
+						if v, err = cfg.castValue(v, from, valueType); err != nil {
+							return errors.Wrapf(err, "bullseye/mutations: cannot melt column %s", valueCol.Name())
+						}
+					}
+					builder.Append(len(idCols)+1, v)
+				}
+				return nil
+			}()
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		return builder.NewDataFrame()
+	}
+}
+
+// isOrdered returns true if values of dtype can be compared with compareValues.
+func isOrdered(dtype arrow.DataType) bool {
+	return isNumeric(dtype) || isDate(dtype) || dtype.ID() == arrow.STRING || dtype.ID() == arrow.BOOL
+}
+
+// unifyTypes returns a type that can hold the values of all of the columns and whether
+// any of them are nullable. Numeric columns are unified with commonNumericType and
+// anything else is unified as a string.
+func unifyTypes(cols []array.Column) (arrow.DataType, bool, error) {
+	dtype := cols[0].DataType()
+	nullable := false
+	for i := range cols {
+		nullable = nullable || cols[i].Field().Nullable
+		other := cols[i].DataType()
+		if reflect.DeepEqual(dtype, other) {
+			continue
+		}
+		if common, ok := commonNumericType(dtype, other); ok {
+			dtype = common
+			continue
+		}
+		dtype = arrow.BinaryTypes.String
+	}
+
+	for i := range cols {
+		if !canCast(cols[i].DataType(), dtype) {
+			return nil, false, errors.Errorf("bullseye/mutations: cannot convert column %s from %v to %v", cols[i].Name(), cols[i].DataType(), dtype)
+		}
+	}
+	return dtype, nullable, nil
+}
+
+// pivotColumnName returns the column name for a value of the columns column of a Pivot.
+func pivotColumnName(v interface{}, dtype arrow.DataType) string {
+	var name string
+	if isDate(dtype) {
+		name = toTime(v, dtype).Format(DefaultDateLayout)
+	} else {
+		name = fmt.Sprint(v)
+	}
+
+	name = strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_' || r == '-' || r == '.' {
+			return r
+		}
+		return '_'
+	}, strings.TrimSpace(name))
+	if name == "" {
+		return "_"
+	}
+	return name
+}
+
+// uniqueName returns name, or name with a numbered suffix if it is already taken, and marks it as taken.
+func uniqueName(name string, taken map[string]struct{}) string {
+	unique := name
+	for i := 2; ; i++ {
+		if _, ok := taken[unique]; !ok {
+			break
+		}
+		unique = fmt.Sprintf("%s_%d", name, i)
+	}
+	taken[unique] = struct{}{}
+	return unique
+}
@@ -0,0 +1,169 @@
+package dataframe
+
+import (
+	"testing"
+
+	"github.com/apache/arrow/go/arrow/memory"
+)
+
+func TestPivot(t *testing.T) {
+	pool := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer pool.AssertSize(t, 0)
+
+	df, err := NewDataFrameFromMem(pool, Dict{
+		"City":    []string{"Paris", "Rome", "Paris", "Paris", "Rome", "Oslo"},
+		"Product": []string{"b", "a", "a", "b", "new a", "c d"},
+		"Sales":   []interface{}{int32(1), int32(2), int32(3), int32(4), nil, int32(6)},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer df.Release()
+
+	testCases := []struct {
+		agg  AggFunc
+		want string
+	}{
+		{
+			agg: AggSum,
+			want: `rec[0]["City"]: ["Paris" "Rome" "Oslo"]
+rec[0]["a"]: [3 2 (null)]
+rec[0]["b"]: [5 (null) (null)]
+rec[0]["c_d"]: [(null) (null) 6]
+rec[0]["new_a"]: [(null) (null) (null)]
+`,
+		},
+		{
+			agg: AggMean,
+			want: `rec[0]["City"]: ["Paris" "Rome" "Oslo"]
+rec[0]["a"]: [3 2 (null)]
+rec[0]["b"]: [2.5 (null) (null)]
+rec[0]["c_d"]: [(null) (null) 6]
+rec[0]["new_a"]: [(null) (null) (null)]
+`,
+		},
+		{
+			agg: AggCount,
+			want: `rec[0]["City"]: ["Paris" "Rome" "Oslo"]
+rec[0]["a"]: [1 1 (null)]
+rec[0]["b"]: [2 (null) (null)]
+rec[0]["c_d"]: [(null) (null) 1]
+rec[0]["new_a"]: [(null) 0 (null)]
+`,
+		},
+		{
+			agg: AggLast,
+			want: `rec[0]["City"]: ["Paris" "Rome" "Oslo"]
+rec[0]["a"]: [3 2 (null)]
+rec[0]["b"]: [4 (null) (null)]
+rec[0]["c_d"]: [(null) (null) 6]
+rec[0]["new_a"]: [(null) (null) (null)]
+`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.agg.String(), func(t *testing.T) {
+			pivotDf, err := df.Pivot([]string{"City"}, "Product", "Sales", tc.agg)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer pivotDf.Release()
+
+			if got := pivotDf.Display(-1); got != tc.want {
+				t.Fatalf("\ngot=\n%v\nwant=\n%v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestPivotNames(t *testing.T) {
+	pool := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer pool.AssertSize(t, 0)
+
+	df, err := NewDataFrameFromMem(pool, Dict{
+		"A": []int64{10, 2, 10},
+		"B": []string{"x", "y", "x"},
+		"C": []float64{1, 2, 3},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer df.Release()
+
+	// The columns are numerically sorted and B is not renamed to "10".
+	pivotDf, err := df.Pivot([]string{"B"}, "A", "C", AggMax)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer pivotDf.Release()
+
+	got := pivotDf.Display(-1)
+	want := `rec[0]["B"]: ["x" "y"]
+rec[0]["2"]: [(null) 2]
+rec[0]["10"]: [3 (null)]
+`
+	if got != want {
+		t.Fatalf("\ngot=\n%v\nwant=\n%v", got, want)
+	}
+
+	if _, err := df.Pivot([]string{"A"}, "C", "B", AggSum); err == nil || err.Error() != "bullseye/mutations: cannot pivot column B: cannot sum a utf8" {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestMelt(t *testing.T) {
+	pool := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer pool.AssertSize(t, 0)
+
+	df, err := NewDataFrameFromMem(pool, Dict{
+		"A": []string{"a", "b"},
+		"B": []int32{1, 2},
+		"C": []interface{}{1.5, nil},
+		"D": []bool{true, false},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer df.Release()
+
+	testCases := []struct {
+		name      string
+		valueVars []string
+		want      string
+	}{
+		{
+			name:      "numeric",
+			valueVars: []string{"B", "C"},
+			want: `rec[0]["A"]: ["a" "b" "a" "b"]
+rec[0]["variable"]: ["B" "B" "C" "C"]
+rec[0]["value"]: [1 2 1.5 (null)]
+`,
+		},
+		{
+			name: "all",
+			want: `rec[0]["A"]: ["a" "b" "a" "b" "a" "b"]
+rec[0]["variable"]: ["B" "B" "C" "C" "D" "D"]
+rec[0]["value"]: ["1" "2" "1.5" (null) "true" "false"]
+`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			meltDf, err := df.Melt([]string{"A"}, tc.valueVars, "", "")
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer meltDf.Release()
+
+			if got := meltDf.Display(-1); got != tc.want {
+				t.Fatalf("\ngot=\n%v\nwant=\n%v", got, tc.want)
+			}
+		})
+	}
+
+	if _, err := df.Melt([]string{"A"}, nil, "A", ""); err == nil || err.Error() != "bullseye/mutations: melt column A conflicts with an id column" {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
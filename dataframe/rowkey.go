@@ -0,0 +1,65 @@
+package dataframe
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// rowKey encodes values into a string that can be used as a map key to
+// group or deduplicate rows. Two rows produce the same key only when every
+// value is the same, with nil elements being the same as each other.
+func rowKey(values []interface{}) string {
+	var b strings.Builder
+	for _, v := range values {
+		writeKey(&b, v)
+	}
+	return b.String()
+}
+
+// writeKey writes the key of a value. The values of List and Struct
+// columns are written element by element so that nulls within them
+// can't collide with values that print the same.
+func writeKey(b *strings.Builder, v interface{}) {
+	switch v := v.(type) {
+	case nil:
+		b.WriteString("n;")
+	case []interface{}:
+		b.WriteString(strconv.Itoa(len(v)))
+		b.WriteByte('[')
+		for _, e := range v {
+			writeKey(b, e)
+		}
+		b.WriteByte(']')
+	default:
+		// Length prefix the values so that separators within a value can't collide.
+		s := fmt.Sprint(v)
+		b.WriteString(strconv.Itoa(len(s)))
+		b.WriteByte(':')
+		b.WriteString(s)
+	}
+}
+
+// hasNil returns true if any of the values are nil.
+func hasNil(values []interface{}) bool {
+	for _, v := range values {
+		if v == nil {
+			return true
+		}
+	}
+	return false
+}
+
+// formatKey formats the values for the named columns so they can be reported in errors.
+func formatKey(names []string, values []interface{}) string {
+	parts := make([]string, len(names))
+	for i, name := range names {
+		v := values[i]
+		if v == nil {
+			parts[i] = fmt.Sprintf("%s=(null)", name)
+			continue
+		}
+		parts[i] = fmt.Sprintf("%s=%v", name, v)
+	}
+	return "(" + strings.Join(parts, ", ") + ")"
+}
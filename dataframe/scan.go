@@ -0,0 +1,312 @@
+package dataframe
+
+import (
+	"reflect"
+	"sync/atomic"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/go-bullseye/bullseye/internal/debug"
+	"github.com/go-bullseye/bullseye/iterator"
+	"github.com/go-bullseye/bullseye/timeops"
+	"github.com/pkg/errors"
+)
+
+type scanConfig struct {
+	nullsAsZero bool
+}
+
+func newScanConfig(opts ...Option) (*scanConfig, error) {
+	cfg := &scanConfig{}
+	for _, opt := range opts {
+		if err := opt(cfg); err != nil {
+			return nil, err
+		}
+	}
+	return cfg, nil
+}
+
+// WithNullsAsZero makes scanning a null value into a field that is not a pointer
+// set the zero value of the field instead of failing.
+func WithNullsAsZero() Option {
+	return func(p interface{}) error {
+		cfg, ok := p.(*scanConfig)
+		if !ok {
+			return errors.Errorf("cannot apply WithNullsAsZero to: %T", p)
+		}
+		cfg.nullsAsZero = true
+		return nil
+	}
+}
+
+// scanField is a column scanned into a field of a struct.
+type scanField struct {
+	column int
+	name   string
+	dtype  arrow.DataType
+	// fieldName is the name of the field in its struct.
+	fieldName string
+	// index is the sequence of field indexes of the field, like reflect.StructField.Index.
+	index []int
+	// typ is the type of the field, after any pointer.
+	typ     reflect.Type
+	pointer bool
+}
+
+// RowScanner scans the rows of a StepIterator into structs one at a time. The columns are
+// matched to the fields of the structs by name, with the same names that FromStructs gives
+// the columns of the fields, and nested structs flattened. Columns without a field are skipped
+// and fields without a column are left as they are.
+//
+// Columns are scanned into fields of the same kind that can hold all of their values:
+// booleans into bool fields, integers into integer fields of the same signedness and at
+// least as many bits, floats into float fields of at least as many bits, strings into
+// string fields and Date32, Date64 and Timestamp values into time.Time fields. Other
+// combinations are an error.
+//
+// Null values make pointer fields nil. They are an error for other fields, unless
+// WithNullsAsZero is given, in which case the fields are set to their zero value.
+type RowScanner struct {
+	refCount int64
+	it       iterator.StepIterator
+	fields   []arrow.Field
+	cfg      *scanConfig
+	plans    map[reflect.Type][]scanField
+	row      int
+}
+
+// NewRowScanner creates a RowScanner of the rows of it, where fields are the fields of the
+// columns iterated by it. The RowScanner retains it and must be released.
+// WithNullsAsZero can be given as an option.
+func NewRowScanner(fields []arrow.Field, it iterator.StepIterator, opts ...Option) (*RowScanner, error) {
+	cfg, err := newScanConfig(opts...)
+	if err != nil {
+		return nil, err
+	}
+	it.Retain()
+	return &RowScanner{
+		refCount: 1,
+		it:       it,
+		fields:   fields,
+		cfg:      cfg,
+		plans:    make(map[reflect.Type][]scanField),
+		row:      -1,
+	}, nil
+}
+
+// RowScanner creates a RowScanner of the rows of the DataFrame, which must be released.
+// WithNullsAsZero can be given as an option.
+func (df *DataFrame) RowScanner(opts ...Option) (*RowScanner, error) {
+	it := iterator.NewStepIteratorForColumns(df.cols)
+	defer it.Release()
+	return NewRowScanner(df.schema.Fields(), it, opts...)
+}
+
+// Next moves to the next row. It returns false when there are no more rows.
+func (s *RowScanner) Next() bool {
+	if !s.it.Next() {
+		return false
+	}
+	s.row++
+	return true
+}
+
+// Scan scans the current row into dst, which must be a pointer to a struct.
+func (s *RowScanner) Scan(dst interface{}) error {
+	v := reflect.ValueOf(dst)
+	if v.Kind() != reflect.Ptr || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return errors.Errorf("bullseye/dataframe: cannot scan into %T, it must be a pointer to a struct", dst)
+	}
+	if s.row < 0 {
+		return errors.New("bullseye/dataframe: Scan called before Next")
+	}
+	return s.scan(v.Elem())
+}
+
+func (s *RowScanner) scan(v reflect.Value) error {
+	plan, err := s.plan(v.Type())
+	if err != nil {
+		return err
+	}
+
+	values := s.it.Values().Values
+	for i := range plan {
+		f := &plan[i]
+		if err := f.set(v, values[f.column], s.cfg.nullsAsZero); err != nil {
+			return errors.Wrapf(err, "bullseye/dataframe: cannot scan row %d", s.row)
+		}
+	}
+	return nil
+}
+
+// plan returns the fields of the struct type t that the columns are scanned into.
+func (s *RowScanner) plan(t reflect.Type) ([]scanField, error) {
+	if plan, ok := s.plans[t]; ok {
+		return plan, nil
+	}
+
+	cols, err := structColumns(t, "", nil, false, StructFlatten, map[reflect.Type]bool{})
+	if err != nil {
+		return nil, err
+	}
+	byName := make(map[string]*structColumn, len(cols))
+	for i := range cols {
+		byName[cols[i].field.Name] = &cols[i]
+	}
+
+	var plan []scanField
+	for i, field := range s.fields {
+		col, ok := byName[field.Name]
+		if !ok {
+			continue
+		}
+		sf := t.FieldByIndex(col.index)
+		f := scanField{column: i, name: field.Name, dtype: field.Type, fieldName: sf.Name, index: col.index, typ: sf.Type}
+		if f.typ.Kind() == reflect.Ptr {
+			f.typ, f.pointer = f.typ.Elem(), true
+		}
+		if !canScan(field.Type, f.typ) {
+			return nil, errors.Errorf("bullseye/dataframe: cannot scan column %s of type %v into field %v.%s of type %v", field.Name, field.Type, t, sf.Name, sf.Type)
+		}
+		plan = append(plan, f)
+	}
+
+	s.plans[t] = plan
+	return plan, nil
+}
+
+// Retain increases the reference count by 1.
+func (s *RowScanner) Retain() {
+	atomic.AddInt64(&s.refCount, 1)
+}
+
+// Release decreases the reference count by 1.
+// When the reference count goes to zero, the iterator is released.
+func (s *RowScanner) Release() {
+	refs := atomic.AddInt64(&s.refCount, -1)
+	debug.Assert(refs >= 0, "too many releases")
+	if refs == 0 {
+		s.it.Release()
+		s.it = nil
+	}
+}
+
+// ScanStructs scans all of the rows of the DataFrame into dst, which must be a pointer to a slice of
+// structs or of pointers to structs, following the rules of RowScanner. The slice is replaced by a
+// slice with a struct for each row. WithNullsAsZero can be given as an option.
+func (df *DataFrame) ScanStructs(dst interface{}, opts ...Option) error {
+	v := reflect.ValueOf(dst)
+	if v.Kind() != reflect.Ptr || v.IsNil() || v.Elem().Kind() != reflect.Slice {
+		return errors.Errorf("bullseye/dataframe: cannot scan into %T, it must be a pointer to a slice of structs", dst)
+	}
+	sliceType := v.Elem().Type()
+	elemType, pointers := sliceType.Elem(), false
+	if elemType.Kind() == reflect.Ptr {
+		elemType, pointers = elemType.Elem(), true
+	}
+	if elemType.Kind() != reflect.Struct {
+		return errors.Errorf("bullseye/dataframe: cannot scan into %T, it must be a pointer to a slice of structs", dst)
+	}
+
+	scanner, err := df.RowScanner(opts...)
+	if err != nil {
+		return err
+	}
+	defer scanner.Release()
+
+	rows := reflect.MakeSlice(sliceType, int(df.NumRows()), int(df.NumRows()))
+	for i := 0; scanner.Next(); i++ {
+		elem := rows.Index(i)
+		if pointers {
+			elem.Set(reflect.New(elemType))
+			elem = elem.Elem()
+		}
+		if err := scanner.scan(elem); err != nil {
+			return err
+		}
+	}
+
+	v.Elem().Set(rows)
+	return nil
+}
+
+// canScan returns true if the values of dtype can be scanned into fields of type t.
+func canScan(dtype arrow.DataType, t reflect.Type) bool {
+	if t == timeType {
+		return isTemporal(dtype)
+	}
+
+	bits := func() int {
+		return dtype.(arrow.FixedWidthDataType).BitWidth()
+	}
+	switch t.Kind() {
+	case reflect.Bool:
+		return dtype.ID() == arrow.BOOL
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return isSignedInteger(dtype) && bits() <= t.Bits()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return isUnsignedInteger(dtype) && bits() <= t.Bits()
+	case reflect.Float32, reflect.Float64:
+		return isFloat(dtype) && bits() <= t.Bits()
+	case reflect.String:
+		return dtype.ID() == arrow.STRING
+	}
+	return false
+}
+
+// set sets the field in the struct v to the value of the column, allocating
+// the structs on the way to the field when they are nil pointers.
+func (f *scanField) set(v reflect.Value, value interface{}, nullsAsZero bool) error {
+	for _, i := range f.index {
+		if v.Kind() == reflect.Ptr {
+			if v.IsNil() {
+				if value == nil {
+					// Leave the struct nil rather than allocating it for a null.
+					return nil
+				}
+				if !v.CanSet() {
+					return errors.Errorf("cannot set embedded pointer to unexported struct %v", v.Type().Elem())
+				}
+				v.Set(reflect.New(v.Type().Elem()))
+			}
+			v = v.Elem()
+		}
+		v = v.Field(i)
+	}
+
+	if value == nil {
+		if !f.pointer && !nullsAsZero {
+			return errors.Errorf("column %s is null and field %s is not a pointer", f.name, f.fieldName)
+		}
+		v.Set(reflect.Zero(v.Type()))
+		return nil
+	}
+	if f.pointer {
+		if v.IsNil() {
+			v.Set(reflect.New(f.typ))
+		}
+		v = v.Elem()
+	}
+
+	switch {
+	case f.typ == timeType:
+		loc, err := timeops.Location(f.dtype)
+		if err != nil {
+			return err
+		}
+		v.Set(reflect.ValueOf(toTime(value, f.dtype).In(loc)))
+	case f.typ.Kind() == reflect.Bool:
+		v.SetBool(value.(bool))
+	case f.typ.Kind() == reflect.String:
+		v.SetString(value.(string))
+	case isSignedInteger(f.dtype):
+		i, _ := castNumericValue(value, arrow.PrimitiveTypes.Int64)
+		v.SetInt(i.(int64))
+	case isUnsignedInteger(f.dtype):
+		u, _ := castNumericValue(value, arrow.PrimitiveTypes.Uint64)
+		v.SetUint(u.(uint64))
+	default:
+		x, _ := castNumericValue(value, arrow.PrimitiveTypes.Float64)
+		v.SetFloat(x.(float64))
+	}
+	return nil
+}
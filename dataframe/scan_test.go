@@ -0,0 +1,165 @@
+package dataframe
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/memory"
+	"github.com/go-bullseye/bullseye/iterator"
+)
+
+func TestScanStructs(t *testing.T) {
+	pool := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer pool.AssertSize(t, 0)
+
+	qty, zip := int32(3), "10001"
+	placed := time.Date(2019, 6, 1, 0, 0, 0, 0, time.UTC)
+	orders := []testOrder{
+		{
+			testAudit: testAudit{CreatedBy: "ann"},
+			ID:        1, Quantity: &qty, Price: 9.5, Paid: true, Placed: placed,
+			Ship: testAddress{City: "NYC", Zip: &zip},
+			Bill: &testAddress{City: "LA"},
+		},
+		{
+			testAudit: testAudit{CreatedBy: "bob"},
+			ID:        2, Placed: placed.Add(time.Second),
+			Ship: testAddress{City: "SF"},
+		},
+	}
+
+	df, err := FromStructs(pool, orders)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer df.Release()
+
+	var got []*testOrder
+	err = df.ScanStructs(&got)
+	if want := "bullseye/dataframe: cannot scan row 1: column price is null and field Price is not a pointer"; err == nil || err.Error() != want {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := df.ScanStructs(&got, WithNullsAsZero()); err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != len(orders) {
+		t.Fatalf("got=%d rows, want=%d", len(got), len(orders))
+	}
+	for i := range orders {
+		if !reflect.DeepEqual(*got[i], orders[i]) {
+			t.Fatalf("row %d:\ngot=\n%+v\nwant=\n%+v", i, *got[i], orders[i])
+		}
+	}
+
+	// Only the fields with a column are scanned.
+	type partial struct {
+		ID    int64   `bullseye:"id"`
+		City  *string `bullseye:"Ship.City"`
+		Other string
+	}
+	var partials []partial
+	if err := df.ScanStructs(&partials); err != nil {
+		t.Fatal(err)
+	}
+	if partials[1].ID != 2 || *partials[1].City != "SF" || partials[1].Other != "" {
+		t.Fatalf("got=%+v", partials[1])
+	}
+}
+
+func TestRowScanner(t *testing.T) {
+	pool := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer pool.AssertSize(t, 0)
+
+	df, err := NewDataFrameFromMem(pool, Dict{
+		"A": []interface{}{int8(1), nil, int8(3)},
+		"B": []float32{0.5, 1.5, 2.5},
+		"C": []string{"x", "y", "z"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer df.Release()
+
+	type row struct {
+		A *int16
+		B float64
+		C string
+	}
+
+	it := iterator.NewStepIteratorForColumns(df.Columns())
+	scanner, err := NewRowScanner(df.Schema().Fields(), it)
+	it.Release()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer scanner.Release()
+
+	var got []row
+	for scanner.Next() {
+		var r row
+		if err := scanner.Scan(&r); err != nil {
+			t.Fatal(err)
+		}
+		got = append(got, r)
+	}
+	if len(got) != 3 || *got[0].A != 1 || got[1].A != nil || *got[2].A != 3 ||
+		got[1].B != 1.5 || got[2].C != "z" {
+		t.Fatalf("got=%+v", got)
+	}
+
+	tss, err := NewDataFrameFromMem(pool, Dict{"T": []string{"2019-06-01T10:00:00Z"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer tss.Release()
+	tokyo := &arrow.TimestampType{Unit: arrow.Second, TimeZone: "Asia/Tokyo"}
+	castTss, err := tss.Cast(map[string]arrow.DataType{"T": tokyo}, WithLayout(time.RFC3339))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer castTss.Release()
+
+	var times []struct{ T time.Time }
+	if err := castTss.ScanStructs(&times); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := times[0].T.Format(time.RFC3339), "2019-06-01T19:00:00+09:00"; got != want {
+		t.Fatalf("got=%v, want=%v", got, want)
+	}
+}
+
+func TestScanStructsErrors(t *testing.T) {
+	pool := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer pool.AssertSize(t, 0)
+
+	df, err := NewDataFrameFromMem(pool, Dict{
+		"A": []int64{1, 2},
+		"B": []float64{1, 2},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer df.Release()
+
+	var narrow []struct{ A int32 }
+	var kind []struct{ B string }
+	var unsigned []struct{ A uint64 }
+	var notStructs []int
+	for _, tc := range []struct {
+		dst  interface{}
+		want string
+	}{
+		{&narrow, "bullseye/dataframe: cannot scan column A of type int64 into field struct { A int32 }.A of type int32"},
+		{&kind, "bullseye/dataframe: cannot scan column B of type float64 into field struct { B string }.B of type string"},
+		{&unsigned, "bullseye/dataframe: cannot scan column A of type int64 into field struct { A uint64 }.A of type uint64"},
+		{&notStructs, "bullseye/dataframe: cannot scan into *[]int, it must be a pointer to a slice of structs"},
+		{narrow, "bullseye/dataframe: cannot scan into []struct { A int32 }, it must be a pointer to a slice of structs"},
+	} {
+		if err := df.ScanStructs(tc.dst); err == nil || err.Error() != tc.want {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+}
@@ -0,0 +1,240 @@
+package dataframe
+
+import (
+	"math"
+	"math/big"
+	"time"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/memory"
+	"github.com/go-bullseye/bullseye/internal/constructors"
+	"github.com/go-bullseye/bullseye/timeops"
+	"github.com/pkg/errors"
+)
+
+// DefaultChunkSize is the number of values in each chunk of the columns created by DateRange and Sequence.
+const DefaultChunkSize = 1 << 20
+
+type rangeConfig struct {
+	chunkSize int
+}
+
+func newRangeConfig(opts ...Option) (*rangeConfig, error) {
+	cfg := &rangeConfig{chunkSize: DefaultChunkSize}
+	for _, opt := range opts {
+		if err := opt(cfg); err != nil {
+			return nil, err
+		}
+	}
+	return cfg, nil
+}
+
+// WithChunkSize sets the largest number of values in each chunk of the columns
+// created by DateRange and Sequence. The default is DefaultChunkSize.
+func WithChunkSize(size int) Option {
+	return func(p interface{}) error {
+		cfg, ok := p.(*rangeConfig)
+		if !ok {
+			return errors.Errorf("cannot apply WithChunkSize to: %T", p)
+		}
+		if size < 1 {
+			return errors.Errorf("chunk size must be positive: %d", size)
+		}
+		cfg.chunkSize = size
+		return nil
+	}
+}
+
+// DateRange creates a Date32, Date64 or Timestamp column named name with the times from start to end,
+// including end, in steps of the rule, e.g. Every(time.Hour) or EveryCalendar(timeops.UnitMonth).
+// The k-th value is start moved forward by k steps, so calendar steps keep the day of the month of
+// start where they can and clamp it to the end of shorter months. Timestamps with a time zone are
+// stepped in that time zone. A value dtype can't hold is an error. The column is empty when end
+// is before start and must be released.
+// WithChunkSize can be given as an option.
+func DateRange(mem memory.Allocator, name string, start, end time.Time, step ResampleRule, dtype arrow.DataType, opts ...Option) (*array.Column, error) {
+	cfg, err := newRangeConfig(opts...)
+	if err != nil {
+		return nil, err
+	}
+	if !isTemporal(dtype) {
+		return nil, errors.Errorf("bullseye/dataframe: cannot create a date range of type %v", dtype)
+	}
+	if err := step.validate(dtype, "steps"); err != nil {
+		return nil, errors.Wrap(err, "bullseye/dataframe: cannot create a date range")
+	}
+	loc, err := timeops.Location(dtype)
+	if err != nil {
+		return nil, err
+	}
+	start = start.In(loc)
+
+	t := start
+	return newRangeColumn(mem, arrow.Field{Name: name, Type: dtype}, cfg.chunkSize, func(k int) (interface{}, bool, error) {
+		if k > 0 {
+			t = step.nth(start, t, k)
+		}
+		if t.After(end) {
+			return nil, false, nil
+		}
+		v, err := fromTime(t, dtype)
+		if err != nil {
+			return nil, false, errors.Wrap(err, "bullseye/dataframe: cannot create a date range")
+		}
+		return v, true, nil
+	})
+}
+
+// Sequence creates a numeric column of type dtype named name with the values from start up to,
+// but not including, stop in steps of step, like the ranges of Python. The k-th value is
+// start + k*step. start, stop and step are Go numbers, e.g. 1, 0.5 or int64(1)<<60, and are
+// used exactly: for integer types they must be whole numbers, and for float types they must
+// be exact in a float64. All of the values must fit in dtype. The column is empty when step goes
+// away from stop and must be released. WithChunkSize can be given as an option.
+func Sequence(mem memory.Allocator, name string, start, stop, step interface{}, dtype arrow.DataType, opts ...Option) (*array.Column, error) {
+	cfg, err := newRangeConfig(opts...)
+	if err != nil {
+		return nil, err
+	}
+	if !isNumeric(dtype) {
+		return nil, errors.Errorf("bullseye/dataframe: cannot create a sequence of type %v", dtype)
+	}
+	var bounds [3]*big.Float
+	for i, v := range []interface{}{start, stop, step} {
+		f, ok := bigNumber(v)
+		if !ok {
+			return nil, errors.Errorf("bullseye/dataframe: sequence bounds and step must be finite numbers: %v (%T)", v, v)
+		}
+		bounds[i] = f
+	}
+	if bounds[2].Sign() == 0 {
+		return nil, errors.New("bullseye/dataframe: sequence step must not be 0")
+	}
+
+	var (
+		n     int
+		value func(k int) interface{}
+	)
+	if isFloat(dtype) {
+		n, value, err = floatSequence(bounds)
+	} else {
+		n, value, err = integerSequence(bounds, dtype)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return newRangeColumn(mem, arrow.Field{Name: name, Type: dtype}, cfg.chunkSize, func(k int) (interface{}, bool, error) {
+		if k >= n {
+			return nil, false, nil
+		}
+		v, err := castNumericValue(value(k), dtype)
+		return v, err == nil, err
+	})
+}
+
+// floatSequence returns the length and the values of a sequence from the start, stop and step in bounds.
+func floatSequence(bounds [3]*big.Float) (int, func(k int) interface{}, error) {
+	var fs [3]float64
+	for i, f := range bounds {
+		var acc big.Accuracy
+		if fs[i], acc = f.Float64(); acc != big.Exact {
+			return 0, nil, errors.Errorf("bullseye/dataframe: sequence bound %s is not exact in a float64", f.Text('f', -1))
+		}
+	}
+	start, stop, step := fs[0], fs[1], fs[2]
+
+	count := math.Ceil((stop - start) / step)
+	if count <= 0 {
+		return 0, nil, nil
+	}
+	if count >= float64(int(^uint(0)>>1)) {
+		return 0, nil, errors.Errorf("bullseye/dataframe: sequence of %v values is too long", count)
+	}
+	return int(count), func(k int) interface{} { return start + float64(k)*step }, nil
+}
+
+// integerSequence returns the length and the values of a sequence of the integer type dtype from
+// the start, stop and step in bounds. The values are computed modulo 2^64, which is exact since
+// the first and the last value are checked to fit in dtype.
+func integerSequence(bounds [3]*big.Float, dtype arrow.DataType) (int, func(k int) interface{}, error) {
+	var ints [3]*big.Int
+	for i, f := range bounds {
+		if !f.IsInt() {
+			return 0, nil, errors.Errorf("bullseye/dataframe: sequence of %v must have whole numbers for start, stop and step", dtype)
+		}
+		ints[i], _ = f.Int(nil)
+	}
+	start, stop, step := ints[0], ints[1], ints[2]
+
+	// The length is ceil((stop - start) / step), and Quo rounds toward zero.
+	count := new(big.Int).Sub(stop, start)
+	count.Add(count, step)
+	count.Sub(count, big.NewInt(int64(step.Sign())))
+	count.Quo(count, step)
+	if count.Sign() <= 0 {
+		return 0, nil, nil
+	}
+	if !count.IsInt64() || count.Int64() >= int64(int(^uint(0)>>1)) {
+		return 0, nil, errors.Errorf("bullseye/dataframe: sequence of %v values is too long", count)
+	}
+	n := int(count.Int64())
+
+	last := new(big.Int).Mul(step, big.NewInt(int64(n-1)))
+	last.Add(last, start)
+	for _, v := range []*big.Int{start, last} {
+		fits := (v.IsInt64() && exactNumber(v.Int64(), dtype)) || (v.IsUint64() && exactNumber(v.Uint64(), dtype))
+		if !fits {
+			return 0, nil, errors.Errorf("bullseye/dataframe: sequence value %v: overflows %v", v, dtype)
+		}
+	}
+
+	begin, delta := low64(start), low64(step)
+	if isUnsignedInteger(dtype) {
+		return n, func(k int) interface{} { return begin + uint64(k)*delta }, nil
+	}
+	return n, func(k int) interface{} { return int64(begin + uint64(k)*delta) }, nil
+}
+
+// low64 returns the low 64 bits of v in two's complement.
+func low64(v *big.Int) uint64 {
+	return new(big.Int).And(v, new(big.Int).SetUint64(math.MaxUint64)).Uint64()
+}
+
+// newRangeColumn creates a column of the values returned by next for k = 0, 1, 2, ... until it returns
+// false, in chunks of at most chunkSize values. The column must be released.
+func newRangeColumn(mem memory.Allocator, field arrow.Field, chunkSize int, next func(k int) (interface{}, bool, error)) (*array.Column, error) {
+	builder, err := constructors.NewBuilder(mem, field.Type)
+	if err != nil {
+		return nil, err
+	}
+	defer builder.Release()
+
+	var chunks []array.Interface
+	defer func() {
+		for _, chunk := range chunks {
+			chunk.Release()
+		}
+	}()
+
+	appender := initFieldAppender(&field)
+	for k := 0; ; k++ {
+		v, ok, err := next(k)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			break
+		}
+		appender(builder, v)
+		if builder.Len() == chunkSize {
+			chunks = append(chunks, builder.NewArray())
+		}
+	}
+	if builder.Len() > 0 || len(chunks) == 0 {
+		chunks = append(chunks, builder.NewArray())
+	}
+
+	return newColumnFromChunks(field, chunks), nil
+}
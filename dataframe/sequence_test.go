@@ -0,0 +1,238 @@
+package dataframe
+
+import (
+	"fmt"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/memory"
+	"github.com/go-bullseye/bullseye/timeops"
+)
+
+// displayColumn returns the Display of a DataFrame with only the column and releases the column.
+func displayColumn(t *testing.T, mem memory.Allocator, col *array.Column) string {
+	t.Helper()
+	defer col.Release()
+
+	df, err := NewDataFrameFromColumns(mem, []array.Column{*col})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer df.Release()
+	return df.Display(-1)
+}
+
+func TestDateRange(t *testing.T) {
+	pool := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer pool.AssertSize(t, 0)
+
+	tokyo := &arrow.TimestampType{Unit: arrow.Second, TimeZone: "Asia/Tokyo"}
+	tests := []struct {
+		name       string
+		start, end string
+		step       ResampleRule
+		dtype      arrow.DataType
+		chunkSize  int
+		want       string
+	}{
+		{
+			name:  "hours",
+			start: "2019-06-01T22:00:00Z",
+			end:   "2019-06-02T01:00:00Z",
+			step:  Every(time.Hour),
+			dtype: &arrow.TimestampType{Unit: arrow.Millisecond},
+			want: `rec[0]["T"]: [1559426400000 1559430000000 1559433600000 1559437200000]
+`,
+		},
+		{
+			name:  "months",
+			start: "2019-01-31T00:00:00Z",
+			end:   "2019-05-01T00:00:00Z",
+			step:  EveryCalendar(timeops.UnitMonth),
+			dtype: arrow.FixedWidthTypes.Date32,
+			want: `rec[0]["T"]: [17927 17955 17986 18016]
+`,
+		},
+		{
+			name:      "chunked days",
+			start:     "2019-06-01T00:00:00Z",
+			end:       "2019-06-05T12:00:00Z",
+			step:      EveryCalendar(timeops.UnitDay),
+			dtype:     arrow.FixedWidthTypes.Date64,
+			chunkSize: 2,
+			want: `rec[0]["T"]: [1559347200000 1559433600000]
+rec[1]["T"]: [1559520000000 1559606400000]
+rec[2]["T"]: [1559692800000]
+`,
+		},
+		{
+			name:  "time zone",
+			start: "2019-06-01T00:00:00Z",
+			end:   "2019-06-03T00:00:00Z",
+			step:  EveryCalendar(timeops.UnitDay),
+			dtype: tokyo,
+			want: `rec[0]["T"]: [1559347200 1559433600 1559520000]
+`,
+		},
+		{
+			name:  "empty",
+			start: "2019-06-02T00:00:00Z",
+			end:   "2019-06-01T00:00:00Z",
+			step:  Every(time.Hour),
+			dtype: &arrow.TimestampType{Unit: arrow.Second},
+			want:  "",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			start, err := time.Parse(time.RFC3339, tc.start)
+			if err != nil {
+				t.Fatal(err)
+			}
+			end, err := time.Parse(time.RFC3339, tc.end)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var opts []Option
+			if tc.chunkSize > 0 {
+				opts = append(opts, WithChunkSize(tc.chunkSize))
+			}
+
+			col, err := DateRange(pool, "T", start, end, tc.step, tc.dtype, opts...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := displayColumn(t, pool, col); got != tc.want {
+				t.Fatalf("\ngot=\n%v\nwant=\n%v", got, tc.want)
+			}
+		})
+	}
+
+	// 400 years of days is longer than a time.Duration can hold.
+	col, err := DateRange(pool, "T", time.Date(1900, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2300, 1, 1, 0, 0, 0, 0, time.UTC),
+		Every(24*time.Hour), arrow.FixedWidthTypes.Date32)
+	if err != nil {
+		t.Fatal(err)
+	}
+	chunk := col.Data().Chunk(0).(*array.Date32)
+	if got, want := []interface{}{col.Len(), chunk.Value(0), chunk.Value(chunk.Len() - 1)}, "[146098 -25567 120530]"; fmt.Sprint(got) != want {
+		t.Fatalf("got=%v, want=%v", got, want)
+	}
+	col.Release()
+
+	// Nanosecond timestamps end on 2262-04-11.
+	if _, err := DateRange(pool, "T", time.Date(2262, 4, 10, 0, 0, 0, 0, time.UTC), time.Date(2262, 4, 13, 0, 0, 0, 0, time.UTC),
+		EveryCalendar(timeops.UnitDay), &arrow.TimestampType{Unit: arrow.Nanosecond}); err == nil ||
+		err.Error() != "bullseye/dataframe: cannot create a date range: time 2262-04-12 00:00:00 +0000 UTC is out of the range of timestamp[ns]" {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	start := time.Date(2019, 6, 1, 0, 0, 0, 0, time.UTC)
+	if _, err := DateRange(pool, "T", start, start, Every(time.Hour), arrow.FixedWidthTypes.Date32); err == nil ||
+		err.Error() != "bullseye/dataframe: cannot create a date range: steps of 1h0m0s are shorter than the days of date32" {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := DateRange(pool, "T", start, start, Every(time.Hour), arrow.PrimitiveTypes.Int64); err == nil ||
+		err.Error() != "bullseye/dataframe: cannot create a date range of type int64" {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestSequence(t *testing.T) {
+	pool := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer pool.AssertSize(t, 0)
+
+	tests := []struct {
+		name              string
+		start, stop, step interface{}
+		dtype             arrow.DataType
+		chunkSize         int
+		want              string
+	}{
+		{
+			name: "int64", start: 0, stop: 5, step: 1, dtype: arrow.PrimitiveTypes.Int64,
+			want: `rec[0]["S"]: [0 1 2 3 4]
+`,
+		},
+		{
+			name: "descending int8", start: 10, stop: 0, step: -3, dtype: arrow.PrimitiveTypes.Int8,
+			want: `rec[0]["S"]: [10 7 4 1]
+`,
+		},
+		{
+			name: "chunked uint16", start: 1, stop: 8, step: 2, dtype: arrow.PrimitiveTypes.Uint16, chunkSize: 3,
+			want: `rec[0]["S"]: [1 3 5]
+rec[1]["S"]: [7]
+`,
+		},
+		{
+			name: "float64", start: 0, stop: 1, step: 0.25, dtype: arrow.PrimitiveTypes.Float64,
+			want: `rec[0]["S"]: [0 0.25 0.5 0.75]
+`,
+		},
+		{
+			name: "float32", start: -1, stop: 0.5, step: 0.5, dtype: arrow.PrimitiveTypes.Float32,
+			want: `rec[0]["S"]: [-1 -0.5 0]
+`,
+		},
+		{
+			name: "empty", start: 0, stop: 5, step: -1, dtype: arrow.PrimitiveTypes.Int32,
+			want: "",
+		},
+		{
+			// Bounds past 2^53 are exact when given as integers.
+			name: "large int64", start: int64(1)<<60 + 1, stop: int64(1)<<60 + 4, step: int8(1), dtype: arrow.PrimitiveTypes.Int64,
+			want: `rec[0]["S"]: [1152921504606846977 1152921504606846978 1152921504606846979]
+`,
+		},
+		{
+			name: "descending uint64", start: uint64(math.MaxUint64), stop: uint64(math.MaxUint64 - 5), step: -2, dtype: arrow.PrimitiveTypes.Uint64,
+			want: `rec[0]["S"]: [18446744073709551615 18446744073709551613 18446744073709551611]
+`,
+		},
+		{
+			name: "full int8", start: math.MinInt8, stop: math.MaxInt8, step: math.MaxInt8 - math.MinInt8 - 1, dtype: arrow.PrimitiveTypes.Int8,
+			want: `rec[0]["S"]: [-128 126]
+`,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var opts []Option
+			if tc.chunkSize > 0 {
+				opts = append(opts, WithChunkSize(tc.chunkSize))
+			}
+
+			col, err := Sequence(pool, "S", tc.start, tc.stop, tc.step, tc.dtype, opts...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := displayColumn(t, pool, col); got != tc.want {
+				t.Fatalf("\ngot=\n%v\nwant=\n%v", got, tc.want)
+			}
+		})
+	}
+
+	for _, tc := range []struct {
+		start, stop, step interface{}
+		dtype             arrow.DataType
+		want              string
+	}{
+		{0, 5, 0, arrow.PrimitiveTypes.Int64, "bullseye/dataframe: sequence step must not be 0"},
+		{0, 5, 0.5, arrow.PrimitiveTypes.Int64, "bullseye/dataframe: sequence of int64 must have whole numbers for start, stop and step"},
+		{0, 5.5, 1, arrow.PrimitiveTypes.Int64, "bullseye/dataframe: sequence of int64 must have whole numbers for start, stop and step"},
+		{0, math.Inf(1), 1, arrow.PrimitiveTypes.Int64, "bullseye/dataframe: sequence bounds and step must be finite numbers: +Inf (float64)"},
+		{"0", 5, 1, arrow.PrimitiveTypes.Int64, "bullseye/dataframe: sequence bounds and step must be finite numbers: 0 (string)"},
+		{0, int64(1)<<53 + 1, 1, arrow.PrimitiveTypes.Float64, "bullseye/dataframe: sequence bound 9007199254740993 is not exact in a float64"},
+		{int64(math.MaxInt64), uint64(math.MaxUint64), 1 << 62, arrow.PrimitiveTypes.Int64, "bullseye/dataframe: sequence value 13835058055282163711: overflows int64"},
+		{250, 260, 1, arrow.PrimitiveTypes.Uint8, "bullseye/dataframe: sequence value 259: overflows uint8"},
+		{0, 5, 1, arrow.BinaryTypes.String, "bullseye/dataframe: cannot create a sequence of type utf8"},
+	} {
+		if _, err := Sequence(pool, "S", tc.start, tc.stop, tc.step, tc.dtype); err == nil || err.Error() != tc.want {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+}
@@ -0,0 +1,428 @@
+package dataframe
+
+import (
+	"math"
+	"sort"
+
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/pkg/errors"
+)
+
+// Interpolation is how a quantile that falls between two values is computed.
+type Interpolation int
+
+const (
+	// InterpolationLinear interpolates linearly between the lower and higher values.
+	InterpolationLinear Interpolation = iota
+	// InterpolationLower picks the lower value.
+	InterpolationLower
+	// InterpolationHigher picks the higher value.
+	InterpolationHigher
+	// InterpolationNearest picks the nearest value, rounding half to even.
+	InterpolationNearest
+	// InterpolationMidpoint picks the mean of the lower and higher values.
+	InterpolationMidpoint
+)
+
+func (i Interpolation) String() string {
+	switch i {
+	case InterpolationLinear:
+		return "linear"
+	case InterpolationLower:
+		return "lower"
+	case InterpolationHigher:
+		return "higher"
+	case InterpolationNearest:
+		return "nearest"
+	case InterpolationMidpoint:
+		return "midpoint"
+	default:
+		return "unknown"
+	}
+}
+
+type reduceConfig struct {
+	propagateNulls bool
+	ddof           int
+	interpolation  Interpolation
+	approximate    bool
+}
+
+func newReduceConfig(opts ...Option) (*reduceConfig, error) {
+	cfg := &reduceConfig{ddof: 1}
+	for _, opt := range opts {
+		if err := opt(cfg); err != nil {
+			return nil, err
+		}
+	}
+	return cfg, nil
+}
+
+// WithPropagateNulls makes a reduction return null if the column has any null values.
+// By default null values are skipped.
+func WithPropagateNulls() Option {
+	return func(p interface{}) error {
+		cfg, ok := p.(*reduceConfig)
+		if !ok {
+			return errors.Errorf("cannot apply WithPropagateNulls to: %T", p)
+		}
+		cfg.propagateNulls = true
+		return nil
+	}
+}
+
+// WithDdof sets the delta degrees of freedom used by Var and Std.
+// The divisor is the number of values minus ddof, the default is 1.
+func WithDdof(ddof int) Option {
+	return func(p interface{}) error {
+		cfg, ok := p.(*reduceConfig)
+		if !ok {
+			return errors.Errorf("cannot apply WithDdof to: %T", p)
+		}
+		if ddof < 0 {
+			return errors.Errorf("ddof must not be negative: %d", ddof)
+		}
+		cfg.ddof = ddof
+		return nil
+	}
+}
+
+// WithInterpolation sets how Quantile computes a quantile that falls between two values.
+// The default is InterpolationLinear.
+func WithInterpolation(interpolation Interpolation) Option {
+	return func(p interface{}) error {
+		cfg, ok := p.(*reduceConfig)
+		if !ok {
+			return errors.Errorf("cannot apply WithInterpolation to: %T", p)
+		}
+		if interpolation < InterpolationLinear || interpolation > InterpolationMidpoint {
+			return errors.Errorf("unknown interpolation: %d", interpolation)
+		}
+		cfg.interpolation = interpolation
+		return nil
+	}
+}
+
+// WithApproximate makes Quantile estimate the quantile in constant memory
+// with the P² algorithm instead of sorting all values.
+// The interpolation mode is ignored when estimating.
+func WithApproximate() Option {
+	return func(p interface{}) error {
+		cfg, ok := p.(*reduceConfig)
+		if !ok {
+			return errors.Errorf("cannot apply WithApproximate to: %T", p)
+		}
+		cfg.approximate = true
+		return nil
+	}
+}
+
+// Series is a single column of a DataFrame with reductions over its values.
+// A Series does not retain the column, it is only valid as long as the DataFrame is.
+type Series struct {
+	name string
+	col  *array.Column
+}
+
+// Col returns the column with the given name as a Series.
+// If the column does not exist every reduction returns an error.
+func (df *DataFrame) Col(name string) *Series {
+	s := &Series{name: name}
+	for i, field := range df.schema.Fields() {
+		if field.Name == name {
+			s.col = &df.cols[i]
+			break
+		}
+	}
+	return s
+}
+
+// Name returns the name of the column.
+func (s *Series) Name() string {
+	return s.name
+}
+
+// Column returns the underlying column, or nil if it does not exist.
+func (s *Series) Column() *array.Column {
+	return s.col
+}
+
+// Sum returns the sum of the values. Signed integers are summed as int64,
+// unsigned integers as uint64 and floats as a Kahan-compensated float64.
+// The sum of a column without values is zero.
+func (s *Series) Sum(opts ...Option) (interface{}, error) {
+	r, _, err := s.reduce(opts)
+	if err != nil || r.null() {
+		return nil, err
+	}
+	switch {
+	case isSignedInteger(s.col.DataType()):
+		return r.isum, nil
+	case isUnsignedInteger(s.col.DataType()):
+		return r.usum, nil
+	default:
+		return r.fsum + r.fcomp, nil
+	}
+}
+
+// Mean returns the arithmetic mean of the values as a float64, or nil if there are none.
+func (s *Series) Mean(opts ...Option) (interface{}, error) {
+	r, _, err := s.reduce(opts)
+	if err != nil || r.null() || r.count == 0 {
+		return nil, err
+	}
+	return r.mean, nil
+}
+
+// Min returns the smallest value with the type of the column, or nil if there are none.
+func (s *Series) Min(opts ...Option) (interface{}, error) {
+	r, _, err := s.reduce(opts)
+	if err != nil || r.null() {
+		return nil, err
+	}
+	return r.min, nil
+}
+
+// Max returns the largest value with the type of the column, or nil if there are none.
+func (s *Series) Max(opts ...Option) (interface{}, error) {
+	r, _, err := s.reduce(opts)
+	if err != nil || r.null() {
+		return nil, err
+	}
+	return r.max, nil
+}
+
+// Var returns the variance of the values as a float64,
+// or nil if there are not more values than the delta degrees of freedom.
+func (s *Series) Var(opts ...Option) (interface{}, error) {
+	r, cfg, err := s.reduce(opts)
+	if err != nil || r.null() || r.count <= int64(cfg.ddof) {
+		return nil, err
+	}
+	return r.m2 / float64(r.count-int64(cfg.ddof)), nil
+}
+
+// Std returns the standard deviation of the values as a float64,
+// or nil if there are not more values than the delta degrees of freedom.
+func (s *Series) Std(opts ...Option) (interface{}, error) {
+	v, err := s.Var(opts...)
+	if err != nil || v == nil {
+		return nil, err
+	}
+	return math.Sqrt(v.(float64)), nil
+}
+
+// Quantile returns the q-th quantile of the values as a float64, or nil if there are none.
+// q must be between 0 and 1.
+func (s *Series) Quantile(q float64, opts ...Option) (interface{}, error) {
+	if q < 0 || q > 1 || math.IsNaN(q) {
+		return nil, errors.Errorf("bullseye/series: quantile must be between 0 and 1: %v", q)
+	}
+
+	cfg, err := newReduceConfig(opts...)
+	if err != nil {
+		return nil, err
+	}
+	r := &reduction{cfg: cfg}
+	if cfg.approximate {
+		r.estimator = newP2Quantile(q)
+	} else {
+		r.keepValues = true
+	}
+	if err := s.reduceInto(r); err != nil || r.null() || r.count == 0 {
+		return nil, err
+	}
+
+	if cfg.approximate {
+		return r.estimator.value(), nil
+	}
+	sort.Float64s(r.values)
+	return quantileSorted(r.values, q, cfg.interpolation), nil
+}
+
+func (s *Series) reduce(opts []Option) (*reduction, *reduceConfig, error) {
+	cfg, err := newReduceConfig(opts...)
+	if err != nil {
+		return nil, nil, err
+	}
+	r := &reduction{cfg: cfg}
+	if err := s.reduceInto(r); err != nil {
+		return nil, nil, err
+	}
+	return r, cfg, nil
+}
+
+func (s *Series) reduceInto(r *reduction) error {
+	if s.col == nil {
+		return errors.Errorf("bullseye/series: column %s does not exist", s.name)
+	}
+	if !reduceColumn(s.col, r) {
+		return errors.Errorf("bullseye/series: cannot reduce column %s of type %v, it is not numeric", s.name, s.col.DataType())
+	}
+	return nil
+}
+
+// reduction accumulates the statistics of the values of a column.
+type reduction struct {
+	cfg   *reduceConfig
+	count int64
+	nulls int64
+
+	min, max interface{}
+
+	isum  int64
+	usum  uint64
+	fsum  float64
+	fcomp float64 // the Kahan compensation of fsum
+
+	mean, m2 float64 // running moments using Welford's algorithm
+
+	keepValues bool
+	values     []float64
+	estimator  *p2Quantile
+}
+
+// null reports whether the result of the reduction is null because nulls propagate.
+func (r *reduction) null() bool {
+	return r.cfg.propagateNulls && r.nulls > 0
+}
+
+// addFloat adds a float value using Kahan-Babuska summation.
+func (r *reduction) addFloat(v float64) {
+	t := r.fsum + v
+	if math.Abs(r.fsum) >= math.Abs(v) {
+		r.fcomp += (r.fsum - t) + v
+	} else {
+		r.fcomp += (v - t) + r.fsum
+	}
+	r.fsum = t
+	r.addValue(v)
+}
+
+// addValue adds a value to the count, the moments and the quantile state.
+func (r *reduction) addValue(v float64) {
+	r.count++
+	delta := v - r.mean
+	r.mean += delta / float64(r.count)
+	r.m2 += delta * (v - r.mean)
+
+	if r.keepValues {
+		r.values = append(r.values, v)
+	}
+	if r.estimator != nil {
+		r.estimator.add(v)
+	}
+}
+
+// quantileSorted returns the q-th quantile of the sorted values.
+func quantileSorted(values []float64, q float64, interpolation Interpolation) float64 {
+	pos := q * float64(len(values)-1)
+	lower := int(math.Floor(pos))
+	upper := int(math.Ceil(pos))
+	frac := pos - float64(lower)
+
+	switch interpolation {
+	case InterpolationLower:
+		return values[lower]
+	case InterpolationHigher:
+		return values[upper]
+	case InterpolationNearest:
+		return values[int(math.RoundToEven(pos))]
+	case InterpolationMidpoint:
+		return (values[lower] + values[upper]) / 2
+	default:
+		return values[lower] + (values[upper]-values[lower])*frac
+	}
+}
+
+// p2Quantile estimates a quantile in constant memory using the P² algorithm
+// of Jain and Chlamtac. It is exact for fewer than five values.
+type p2Quantile struct {
+	p       float64
+	count   int
+	heights [5]float64
+	pos     [5]int
+	desired [5]float64
+	incr    [5]float64
+}
+
+func newP2Quantile(p float64) *p2Quantile {
+	return &p2Quantile{
+		p:    p,
+		incr: [5]float64{0, p / 2, p, (1 + p) / 2, 1},
+	}
+}
+
+func (e *p2Quantile) add(x float64) {
+	if e.count < 5 {
+		e.heights[e.count] = x
+		e.count++
+		if e.count == 5 {
+			sort.Float64s(e.heights[:])
+			e.pos = [5]int{1, 2, 3, 4, 5}
+			e.desired = [5]float64{1, 1 + 2*e.p, 1 + 4*e.p, 3 + 2*e.p, 5}
+		}
+		return
+	}
+	e.count++
+
+	var k int
+	switch {
+	case x < e.heights[0]:
+		e.heights[0] = x
+		k = 0
+	case x >= e.heights[4]:
+		e.heights[4] = x
+		k = 3
+	default:
+		for k = 0; k < 3; k++ {
+			if x < e.heights[k+1] {
+				break
+			}
+		}
+	}
+
+	for i := k + 1; i < 5; i++ {
+		e.pos[i]++
+	}
+	for i := range e.desired {
+		e.desired[i] += e.incr[i]
+	}
+
+	for i := 1; i <= 3; i++ {
+		d := e.desired[i] - float64(e.pos[i])
+		if (d >= 1 && e.pos[i+1]-e.pos[i] > 1) || (d <= -1 && e.pos[i-1]-e.pos[i] < -1) {
+			step := 1
+			if d < 0 {
+				step = -1
+			}
+			h := e.parabolic(i, step)
+			if e.heights[i-1] < h && h < e.heights[i+1] {
+				e.heights[i] = h
+			} else {
+				e.heights[i] = e.linear(i, step)
+			}
+			e.pos[i] += step
+		}
+	}
+}
+
+func (e *p2Quantile) parabolic(i, step int) float64 {
+	d := float64(step)
+	n0, n1, n2 := float64(e.pos[i-1]), float64(e.pos[i]), float64(e.pos[i+1])
+	q0, q1, q2 := e.heights[i-1], e.heights[i], e.heights[i+1]
+	return q1 + d/(n2-n0)*((n1-n0+d)*(q2-q1)/(n2-n1)+(n2-n1-d)*(q1-q0)/(n1-n0))
+}
+
+func (e *p2Quantile) linear(i, step int) float64 {
+	return e.heights[i] + float64(step)*(e.heights[i+step]-e.heights[i])/float64(e.pos[i+step]-e.pos[i])
+}
+
+func (e *p2Quantile) value() float64 {
+	if e.count < 5 {
+		values := append([]float64(nil), e.heights[:e.count]...)
+		sort.Float64s(values)
+		return quantileSorted(values, e.p, InterpolationLinear)
+	}
+	return e.heights[2]
+}
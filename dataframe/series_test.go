@@ -0,0 +1,226 @@
+package dataframe
+
+import (
+	"math"
+	"testing"
+
+	"github.com/apache/arrow/go/arrow/memory"
+)
+
+func TestSeriesReductions(t *testing.T) {
+	pool := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer pool.AssertSize(t, 0)
+
+	df, err := NewDataFrameFromMem(pool, Dict{
+		"I": []interface{}{int32(4), nil, int32(-2), int32(7), int32(1)},
+		"U": []uint8{3, 1, 2, 5, 4},
+		"F": []float64{1e100, 1, -1e100, 2, 3},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer df.Release()
+
+	type reduceFunc func(s *Series) (interface{}, error)
+	sum := func(opts ...Option) reduceFunc {
+		return func(s *Series) (interface{}, error) { return s.Sum(opts...) }
+	}
+	mean := func(opts ...Option) reduceFunc {
+		return func(s *Series) (interface{}, error) { return s.Mean(opts...) }
+	}
+	min := func(opts ...Option) reduceFunc {
+		return func(s *Series) (interface{}, error) { return s.Min(opts...) }
+	}
+	max := func(opts ...Option) reduceFunc {
+		return func(s *Series) (interface{}, error) { return s.Max(opts...) }
+	}
+	variance := func(opts ...Option) reduceFunc {
+		return func(s *Series) (interface{}, error) { return s.Var(opts...) }
+	}
+	std := func(opts ...Option) reduceFunc {
+		return func(s *Series) (interface{}, error) { return s.Std(opts...) }
+	}
+
+	tests := []struct {
+		name   string
+		column string
+		reduce reduceFunc
+		want   interface{}
+	}{
+		{"sum int", "I", sum(), int64(10)},
+		{"sum uint", "U", sum(), uint64(15)},
+		{"sum float compensated", "F", sum(), float64(6)},
+		{"sum propagate", "I", sum(WithPropagateNulls()), nil},
+		{"mean", "I", mean(), 2.5},
+		{"mean propagate", "I", mean(WithPropagateNulls()), nil},
+		{"min", "I", min(), int32(-2)},
+		{"max", "U", max(), uint8(5)},
+		{"var", "U", variance(), 2.5},
+		{"var population", "U", variance(WithDdof(0)), 2.0},
+		{"std", "U", std(WithDdof(0)), math.Sqrt(2)},
+		{"var ddof too large", "U", variance(WithDdof(5)), nil},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := tc.reduce(df.Col(tc.column))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tc.want {
+				t.Fatalf("got=%v (%T), want=%v (%T)", got, got, tc.want, tc.want)
+			}
+		})
+	}
+}
+
+func TestSeriesEmpty(t *testing.T) {
+	pool := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer pool.AssertSize(t, 0)
+
+	df, err := NewDataFrameFromMem(pool, Dict{
+		"A": []interface{}{nil, float32(1)},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer df.Release()
+
+	df2, err := df.Slice(0, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer df2.Release()
+
+	s := df2.Col("A")
+	if got, err := s.Sum(); err != nil || got != float64(0) {
+		t.Fatalf("sum: got=%v, err=%v", got, err)
+	}
+	if got, err := s.Mean(); err != nil || got != nil {
+		t.Fatalf("mean: got=%v, err=%v", got, err)
+	}
+	if got, err := s.Min(); err != nil || got != nil {
+		t.Fatalf("min: got=%v, err=%v", got, err)
+	}
+	if got, err := s.Quantile(0.5); err != nil || got != nil {
+		t.Fatalf("quantile: got=%v, err=%v", got, err)
+	}
+}
+
+func TestSeriesQuantile(t *testing.T) {
+	pool := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer pool.AssertSize(t, 0)
+
+	df, err := NewDataFrameFromMem(pool, Dict{
+		"A": []interface{}{int64(4), int64(1), nil, int64(3), int64(2)},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer df.Release()
+
+	tests := []struct {
+		interpolation Interpolation
+		q             float64
+		want          float64
+	}{
+		{InterpolationLinear, 0.5, 2.5},
+		{InterpolationLinear, 0.25, 1.75},
+		{InterpolationLower, 0.5, 2},
+		{InterpolationHigher, 0.5, 3},
+		{InterpolationNearest, 0.5, 3},
+		{InterpolationNearest, 0.25, 2},
+		{InterpolationMidpoint, 0.25, 1.5},
+		{InterpolationLinear, 0, 1},
+		{InterpolationLinear, 1, 4},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.interpolation.String(), func(t *testing.T) {
+			got, err := df.Col("A").Quantile(tc.q, WithInterpolation(tc.interpolation))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tc.want {
+				t.Fatalf("quantile %v: got=%v, want=%v", tc.q, got, tc.want)
+			}
+		})
+	}
+
+	// with fewer than five values the estimate is exact
+	got, err := df.Col("A").Quantile(0.5, WithApproximate())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != 2.5 {
+		t.Fatalf("approximate: got=%v, want=2.5", got)
+	}
+
+	got, err = df.Col("A").Quantile(0.5, WithPropagateNulls())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != nil {
+		t.Fatalf("propagate: got=%v, want=nil", got)
+	}
+}
+
+func TestSeriesApproximateQuantile(t *testing.T) {
+	pool := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer pool.AssertSize(t, 0)
+
+	// a permutation of 0..999 so the estimator does not see sorted input
+	values := make([]float64, 1000)
+	for i := range values {
+		values[i] = float64((i * 367) % 1000)
+	}
+	df, err := NewDataFrameFromMem(pool, Dict{"A": values})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer df.Release()
+
+	for _, q := range []float64{0.1, 0.5, 0.9} {
+		exact, err := df.Col("A").Quantile(q)
+		if err != nil {
+			t.Fatal(err)
+		}
+		approx, err := df.Col("A").Quantile(q, WithApproximate())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := math.Abs(exact.(float64) - approx.(float64)); diff > 10 {
+			t.Fatalf("quantile %v: exact=%v, approximate=%v", q, exact, approx)
+		}
+	}
+}
+
+func TestSeriesErrors(t *testing.T) {
+	pool := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer pool.AssertSize(t, 0)
+
+	df, err := NewDataFrameFromMem(pool, Dict{
+		"A": []int64{1, 2},
+		"S": []string{"a", "b"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer df.Release()
+
+	if _, err := df.Col("X").Sum(); err == nil || err.Error() != "bullseye/series: column X does not exist" {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := df.Col("S").Max(); err == nil || err.Error() != "bullseye/series: cannot reduce column S of type utf8, it is not numeric" {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := df.Col("A").Quantile(1.5); err == nil || err.Error() != "bullseye/series: quantile must be between 0 and 1: 1.5" {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := df.Col("A").Var(WithDdof(-1)); err == nil || err.Error() != "ddof must not be negative: -1" {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := df.Col("A").Sum(WithFillLimit(1)); err == nil {
+		t.Fatal("expected an error applying a fill option to a reduction")
+	}
+}
@@ -167,6 +167,56 @@ func initFieldAppender(field *arrow.Field) AppenderFunc {
 				builder.Append(vT)
 			}
 		}
+	case *arrow.Date32Type:
+		return func(field array.Builder, v interface{}) {
+			builder := field.(*array.Date32Builder)
+			if v == nil {
+				builder.AppendNull()
+			} else {
+				vT := v.(arrow.Date32)
+				builder.Append(vT)
+			}
+		}
+	case *arrow.Date64Type:
+		return func(field array.Builder, v interface{}) {
+			builder := field.(*array.Date64Builder)
+			if v == nil {
+				builder.AppendNull()
+			} else {
+				vT := v.(arrow.Date64)
+				builder.Append(vT)
+			}
+		}
+	case *arrow.TimestampType:
+		return func(field array.Builder, v interface{}) {
+			builder := field.(*array.TimestampBuilder)
+			if v == nil {
+				builder.AppendNull()
+			} else {
+				vT := v.(arrow.Timestamp)
+				builder.Append(vT)
+			}
+		}
+	case *arrow.Time32Type:
+		return func(field array.Builder, v interface{}) {
+			builder := field.(*array.Time32Builder)
+			if v == nil {
+				builder.AppendNull()
+			} else {
+				vT := v.(arrow.Time32)
+				builder.Append(vT)
+			}
+		}
+	case *arrow.Time64Type:
+		return func(field array.Builder, v interface{}) {
+			builder := field.(*array.Time64Builder)
+			if v == nil {
+				builder.AppendNull()
+			} else {
+				vT := v.(arrow.Time64)
+				builder.Append(vT)
+			}
+		}
 
 	default:
 		panic(fmt.Errorf("dataframe/smartbuilder: unhandled field type %T", field.Type))
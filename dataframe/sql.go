@@ -0,0 +1,552 @@
+package dataframe
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/memory"
+	"github.com/go-bullseye/bullseye/internal/constructors"
+	"github.com/go-bullseye/bullseye/internal/temporal"
+	"github.com/go-bullseye/bullseye/iterator"
+	"github.com/pkg/errors"
+)
+
+// DefaultSQLBatchSize is the number of rows in each chunk read by FromSQLRows
+// and in each INSERT statement of ToSQL.
+const DefaultSQLBatchSize = 1000
+
+// Placeholder is the style of the parameters of the statements of ToSQL.
+type Placeholder int
+
+const (
+	// PlaceholderQuestion numbers the parameters with question marks, e.g. "VALUES (?, ?)",
+	// as used by MySQL and SQLite.
+	PlaceholderQuestion Placeholder = iota
+	// PlaceholderDollar numbers the parameters with dollar signs, e.g. "VALUES ($1, $2)",
+	// as used by PostgreSQL.
+	PlaceholderDollar
+)
+
+func (p Placeholder) String() string {
+	switch p {
+	case PlaceholderQuestion:
+		return "question"
+	case PlaceholderDollar:
+		return "dollar"
+	default:
+		return fmt.Sprintf("Placeholder(%d)", int(p))
+	}
+}
+
+// IdentifierQuote is how ToSQL quotes the names of the table and of the columns.
+type IdentifierQuote int
+
+const (
+	// QuoteDouble quotes the names with double quotes, e.g. "name", as in standard SQL
+	// and as used by PostgreSQL and SQLite.
+	QuoteDouble IdentifierQuote = iota
+	// QuoteBacktick quotes the names with backticks, e.g. `name`, as used by MySQL.
+	QuoteBacktick
+)
+
+func (q IdentifierQuote) String() string {
+	switch q {
+	case QuoteDouble:
+		return "double"
+	case QuoteBacktick:
+		return "backtick"
+	default:
+		return fmt.Sprintf("IdentifierQuote(%d)", int(q))
+	}
+}
+
+// quote quotes the name, doubling the quote characters within it.
+func (q IdentifierQuote) quote(name string) string {
+	c := `"`
+	if q == QuoteBacktick {
+		c = "`"
+	}
+	return c + strings.Replace(name, c, c+c, -1) + c
+}
+
+type sqlConfig struct {
+	batchSize   int
+	types       map[string]arrow.DataType
+	placeholder Placeholder
+	quote       IdentifierQuote
+}
+
+func newSQLConfig(opts ...Option) (*sqlConfig, error) {
+	cfg := &sqlConfig{batchSize: DefaultSQLBatchSize}
+	for _, opt := range opts {
+		if err := opt(cfg); err != nil {
+			return nil, err
+		}
+	}
+	return cfg, nil
+}
+
+// WithBatchSize sets the number of rows in each chunk read by FromSQLRows and in each
+// INSERT statement of ToSQL. The default is DefaultSQLBatchSize. Databases limit the number
+// of parameters of a statement, so writing many columns may need smaller batches.
+func WithBatchSize(n int) Option {
+	return func(p interface{}) error {
+		cfg, ok := p.(*sqlConfig)
+		if !ok {
+			return errors.Errorf("cannot apply WithBatchSize to: %T", p)
+		}
+		if n < 1 {
+			return errors.Errorf("batch size must be positive: %d", n)
+		}
+		cfg.batchSize = n
+		return nil
+	}
+}
+
+// WithSQLTypes sets the types of the named columns read by FromSQLRows
+// instead of mapping them from the types of the database.
+func WithSQLTypes(types map[string]arrow.DataType) Option {
+	return func(p interface{}) error {
+		cfg, ok := p.(*sqlConfig)
+		if !ok {
+			return errors.Errorf("cannot apply WithSQLTypes to: %T", p)
+		}
+		for name, dtype := range types {
+			if !canReadSQL(dtype) {
+				return errors.Errorf("cannot read SQL into column %s of type %v", name, dtype)
+			}
+		}
+		cfg.types = types
+		return nil
+	}
+}
+
+// WithPlaceholder sets the style of the parameters of the statements of ToSQL.
+// The default is PlaceholderQuestion.
+func WithPlaceholder(placeholder Placeholder) Option {
+	return func(p interface{}) error {
+		cfg, ok := p.(*sqlConfig)
+		if !ok {
+			return errors.Errorf("cannot apply WithPlaceholder to: %T", p)
+		}
+		if placeholder < PlaceholderQuestion || placeholder > PlaceholderDollar {
+			return errors.Errorf("invalid placeholder %v", placeholder)
+		}
+		cfg.placeholder = placeholder
+		return nil
+	}
+}
+
+// WithIdentifierQuote sets how ToSQL quotes the names of the table and of the columns.
+// The default is QuoteDouble.
+func WithIdentifierQuote(quote IdentifierQuote) Option {
+	return func(p interface{}) error {
+		cfg, ok := p.(*sqlConfig)
+		if !ok {
+			return errors.Errorf("cannot apply WithIdentifierQuote to: %T", p)
+		}
+		if quote < QuoteDouble || quote > QuoteBacktick {
+			return errors.Errorf("invalid identifier quote %v", quote)
+		}
+		cfg.quote = quote
+		return nil
+	}
+}
+
+// FromSQLRows reads the remaining rows into a DataFrame, with a chunk for each batch of rows,
+// see WithBatchSize. The rows are not closed.
+//
+// The types of the columns are mapped from the Go types the driver scans them into, e.g. int64,
+// sql.NullFloat64 or time.Time, and otherwise from the names of their database types, e.g.
+// INTEGER, VARCHAR or TIMESTAMP. DATE columns make Date32 columns and other times nanosecond
+// Timestamp columns. The types can also be given with WithSQLTypes. NULL values, including
+// those of sql.NullString and the like, are null. All of the columns are nullable.
+func FromSQLRows(mem memory.Allocator, rows *sql.Rows, opts ...Option) (*DataFrame, error) {
+	cfg, err := newSQLConfig(opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	columnTypes, err := rows.ColumnTypes()
+	if err != nil {
+		return nil, errors.Wrap(err, "bullseye/dataframe: cannot read the column types")
+	}
+	fields := make([]arrow.Field, len(columnTypes))
+	for i, ct := range columnTypes {
+		dtype, ok := cfg.types[ct.Name()]
+		if !ok {
+			if dtype, ok = sqlColumnType(ct); !ok {
+				return nil, errors.Errorf("bullseye/dataframe: cannot read column %s of database type %s", ct.Name(), ct.DatabaseTypeName())
+			}
+		}
+		fields[i] = arrow.Field{Name: ct.Name(), Type: dtype, Nullable: true}
+	}
+
+	builders := make([]array.Builder, len(fields))
+	appenders := make([]AppenderFunc, len(fields))
+	chunks := make([][]array.Interface, len(fields))
+	defer func() {
+		for i := range fields {
+			if builders[i] != nil {
+				builders[i].Release()
+			}
+			for _, chunk := range chunks[i] {
+				chunk.Release()
+			}
+		}
+	}()
+	for i := range fields {
+		if builders[i], err = constructors.NewBuilder(mem, fields[i].Type); err != nil {
+			return nil, err
+		}
+		appenders[i] = initFieldAppender(&fields[i])
+	}
+	flush := func() {
+		for i := range builders {
+			chunks[i] = append(chunks[i], builders[i].NewArray())
+		}
+	}
+
+	dest := make([]interface{}, len(fields))
+	for i := range fields {
+		dest[i] = newSQLScanner(fields[i].Type)
+	}
+	for row := 0; rows.Next(); row++ {
+		if err := rows.Scan(dest...); err != nil {
+			return nil, errors.Wrapf(err, "bullseye/dataframe: cannot scan row %d", row)
+		}
+		for i := range fields {
+			v, err := sqlValue(dest[i], fields[i].Type)
+			if err != nil {
+				return nil, errors.Wrapf(err, "bullseye/dataframe: cannot read row %d of column %s", row, fields[i].Name)
+			}
+			appenders[i](builders[i], v)
+		}
+		if (row+1)%cfg.batchSize == 0 {
+			flush()
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(err, "bullseye/dataframe: cannot read the rows")
+	}
+	if len(fields) > 0 && (builders[0].Len() > 0 || len(chunks[0]) == 0) {
+		flush()
+	}
+
+	cols := make([]array.Column, len(fields))
+	for i := range fields {
+		cols[i] = *newColumnFromChunks(fields[i], chunks[i])
+	}
+	defer func() {
+		for i := range cols {
+			cols[i].Release()
+		}
+	}()
+	return NewDataFrameFromColumns(mem, cols)
+}
+
+// SQLExecer executes SQL statements, like *sql.DB, *sql.Conn and *sql.Tx.
+type SQLExecer interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+}
+
+// ToSQL inserts the rows of the DataFrame into the table, which must already exist, with a column
+// of the same name for each column of the DataFrame. The rows are inserted in batches, see WithBatchSize,
+// by INSERT statements with a parameter for each value. The names of the table and of the columns
+// are quoted following WithIdentifierQuote, which must be QuoteBacktick for MySQL, and the parts of
+// a table name qualified with dots, e.g. "schema.table", are quoted one by one.
+// Give a *sql.Tx to insert all of the rows or none. Nulls are inserted as NULL, dates and timestamps
+// as time.Time, times of day as time.Duration and uint64 values above math.MaxInt64, which
+// database/sql does not take, as decimal strings. WithPlaceholder can also be given as an option.
+func (df *DataFrame) ToSQL(ctx context.Context, db SQLExecer, table string, opts ...Option) error {
+	cfg, err := newSQLConfig(opts...)
+	if err != nil {
+		return err
+	}
+
+	fields := df.Schema().Fields()
+	if len(fields) == 0 {
+		return errors.New("bullseye/dataframe: cannot insert a DataFrame without columns")
+	}
+	names := make([]string, len(fields))
+	for i, field := range fields {
+		if !canReadSQL(field.Type) && field.Type.ID() != arrow.TIME32 && field.Type.ID() != arrow.TIME64 {
+			return errors.Errorf("bullseye/dataframe: cannot insert column %s of type %v", field.Name, field.Type)
+		}
+		names[i] = cfg.quote.quote(field.Name)
+	}
+	// The parts of a qualified table name, e.g. schema.table, are quoted on their own.
+	parts := strings.Split(table, ".")
+	for i := range parts {
+		parts[i] = cfg.quote.quote(parts[i])
+	}
+	prefix := fmt.Sprintf("INSERT INTO %s (%s) VALUES ", strings.Join(parts, "."), strings.Join(names, ", "))
+
+	it := iterator.NewStepIteratorForColumns(df.Columns())
+	defer it.Release()
+
+	var (
+		query bytes.Buffer
+		args  []interface{}
+		rows  int
+		first int
+	)
+	insert := func() error {
+		if rows == 0 {
+			return nil
+		}
+		if _, err := db.ExecContext(ctx, query.String(), args...); err != nil {
+			return errors.Wrapf(err, "bullseye/dataframe: cannot insert rows %d to %d into %s", first, first+rows-1, table)
+		}
+		first += rows
+		rows = 0
+		args = args[:0]
+		return nil
+	}
+	for it.Next() {
+		if rows == 0 {
+			query.Reset()
+			query.WriteString(prefix)
+		} else {
+			query.WriteString(", ")
+		}
+		query.WriteByte('(')
+		for i, v := range it.Values().Values {
+			if i > 0 {
+				query.WriteString(", ")
+			}
+			args = append(args, sqlArg(v, fields[i].Type))
+			if cfg.placeholder == PlaceholderDollar {
+				query.WriteString("$" + strconv.Itoa(len(args)))
+			} else {
+				query.WriteByte('?')
+			}
+		}
+		query.WriteByte(')')
+
+		rows++
+		if rows == cfg.batchSize {
+			if err := insert(); err != nil {
+				return err
+			}
+		}
+	}
+	return insert()
+}
+
+var (
+	sqlBoolType    = reflect.TypeOf(false)
+	sqlBytesType   = reflect.TypeOf([]byte(nil))
+	sqlRawType     = reflect.TypeOf(sql.RawBytes(nil))
+	sqlStringType  = reflect.TypeOf("")
+	sqlNullBool    = reflect.TypeOf(sql.NullBool{})
+	sqlNullInt64   = reflect.TypeOf(sql.NullInt64{})
+	sqlNullFloat64 = reflect.TypeOf(sql.NullFloat64{})
+	sqlNullString  = reflect.TypeOf(sql.NullString{})
+
+	sqlTimestampType = &arrow.TimestampType{Unit: arrow.Nanosecond}
+)
+
+// sqlColumnType returns the Arrow type of a database column.
+func sqlColumnType(ct *sql.ColumnType) (arrow.DataType, bool) {
+	if t := ct.ScanType(); t != nil {
+		switch t {
+		case sqlBoolType, sqlNullBool:
+			return arrow.FixedWidthTypes.Boolean, true
+		case sqlNullInt64:
+			return arrow.PrimitiveTypes.Int64, true
+		case sqlNullFloat64:
+			return arrow.PrimitiveTypes.Float64, true
+		case sqlStringType, sqlNullString, sqlBytesType, sqlRawType:
+			return arrow.BinaryTypes.String, true
+		case timeType:
+			if strings.ToUpper(ct.DatabaseTypeName()) == "DATE" {
+				return arrow.FixedWidthTypes.Date32, true
+			}
+			return sqlTimestampType, true
+		}
+		if dtype, ok := structFieldType(t); ok && t.Kind() != reflect.String {
+			return dtype, true
+		}
+	}
+
+	name := strings.ToUpper(ct.DatabaseTypeName())
+	if i := strings.IndexByte(name, '('); i >= 0 {
+		name = strings.TrimSpace(name[:i])
+	}
+	switch name {
+	case "BOOL", "BOOLEAN", "BIT":
+		return arrow.FixedWidthTypes.Boolean, true
+	case "TINYINT", "SMALLINT", "INT", "INT2", "INT4", "INT8", "INTEGER", "BIGINT", "MEDIUMINT", "SERIAL", "BIGSERIAL":
+		return arrow.PrimitiveTypes.Int64, true
+	case "REAL", "FLOAT", "FLOAT4", "FLOAT8", "DOUBLE", "DOUBLE PRECISION", "NUMERIC", "DECIMAL":
+		return arrow.PrimitiveTypes.Float64, true
+	case "CHAR", "VARCHAR", "TEXT", "NCHAR", "NVARCHAR", "CHARACTER", "CHARACTER VARYING", "CLOB", "UUID", "JSON", "JSONB":
+		return arrow.BinaryTypes.String, true
+	case "DATE":
+		return arrow.FixedWidthTypes.Date32, true
+	case "DATETIME", "TIMESTAMP", "TIMESTAMPTZ", "TIMESTAMP WITH TIME ZONE", "TIMESTAMP WITHOUT TIME ZONE":
+		return sqlTimestampType, true
+	}
+	return nil, false
+}
+
+// canReadSQL returns true if FromSQLRows can read values into columns of dtype.
+func canReadSQL(dtype arrow.DataType) bool {
+	return isNumeric(dtype) || isTemporal(dtype) || dtype.ID() == arrow.BOOL || dtype.ID() == arrow.STRING
+}
+
+// newSQLScanner returns the destination Scan scans values of columns of dtype into.
+func newSQLScanner(dtype arrow.DataType) interface{} {
+	switch {
+	case dtype.ID() == arrow.BOOL:
+		return &sql.NullBool{}
+	case dtype.ID() == arrow.STRING:
+		return &sql.NullString{}
+	case isTemporal(dtype):
+		return &sqlTime{}
+	case isFloat(dtype):
+		return &sql.NullFloat64{}
+	default:
+		// Scan converts the values to the integer type, failing if they don't fit.
+		return &sqlInteger{unsigned: isUnsignedInteger(dtype)}
+	}
+}
+
+// sqlValue returns the value scanned into dest as the Go type of dtype, or nil if it is NULL.
+func sqlValue(dest interface{}, dtype arrow.DataType) (interface{}, error) {
+	var v interface{}
+	switch d := dest.(type) {
+	case *sql.NullBool:
+		if !d.Valid {
+			return nil, nil
+		}
+		return d.Bool, nil
+	case *sql.NullString:
+		if !d.Valid {
+			return nil, nil
+		}
+		return d.String, nil
+	case *sqlTime:
+		if !d.valid {
+			return nil, nil
+		}
+		return fromTime(d.time, dtype)
+	case *sql.NullFloat64:
+		if !d.Valid {
+			return nil, nil
+		}
+		v = d.Float64
+	case *sqlInteger:
+		if !d.valid {
+			return nil, nil
+		}
+		v = d.value
+	}
+	if err := checkNumericRange(v, dtype); err != nil {
+		return nil, err
+	}
+	return castNumericValue(v, dtype)
+}
+
+// sqlInteger scans a nullable signed or unsigned integer.
+type sqlInteger struct {
+	unsigned bool
+	value    interface{}
+	valid    bool
+}
+
+func (s *sqlInteger) Scan(src interface{}) error {
+	if src == nil {
+		s.value, s.valid = nil, false
+		return nil
+	}
+	if s.unsigned {
+		var u sql.NullString
+		if err := u.Scan(src); err != nil {
+			return err
+		}
+		n, err := strconv.ParseUint(u.String, 10, 64)
+		if err != nil {
+			return errors.Errorf("cannot convert %v to an unsigned integer", src)
+		}
+		s.value, s.valid = n, true
+		return nil
+	}
+	var i sql.NullInt64
+	if err := i.Scan(src); err != nil {
+		return err
+	}
+	s.value, s.valid = i.Int64, true
+	return nil
+}
+
+// sqlTimeLayouts are the layouts of the times drivers return as text.
+var sqlTimeLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02 15:04:05.999999999Z07:00",
+	"2006-01-02 15:04:05.999999999",
+	"2006-01-02",
+}
+
+// sqlTime scans a nullable time, which drivers return as a time.Time or as text.
+type sqlTime struct {
+	time  time.Time
+	valid bool
+}
+
+func (s *sqlTime) Scan(src interface{}) error {
+	var text string
+	switch v := src.(type) {
+	case nil:
+		s.valid = false
+		return nil
+	case time.Time:
+		s.time, s.valid = v, true
+		return nil
+	case string:
+		text = v
+	case []byte:
+		text = string(v)
+	default:
+		return errors.Errorf("cannot convert %T to a time", src)
+	}
+	for _, layout := range sqlTimeLayouts {
+		if t, err := time.Parse(layout, text); err == nil {
+			s.time, s.valid = t, true
+			return nil
+		}
+	}
+	return errors.Errorf("cannot parse %q as a time", text)
+}
+
+// sqlArg converts the value v of a column of type dtype to a parameter of a statement.
+func sqlArg(v interface{}, dtype arrow.DataType) interface{} {
+	switch dt := dtype.(type) {
+	case *arrow.Time32Type:
+		if v != nil {
+			return time.Duration(v.(arrow.Time32)) * temporal.UnitDuration(dt.Unit)
+		}
+	case *arrow.Time64Type:
+		if v != nil {
+			return time.Duration(v.(arrow.Time64)) * temporal.UnitDuration(dt.Unit)
+		}
+	}
+	if v != nil && isTemporal(dtype) {
+		return toTime(v, dtype)
+	}
+	if u, ok := v.(uint64); ok && u > math.MaxInt64 {
+		// database/sql only takes uint64 values that fit in an int64.
+		return strconv.FormatUint(u, 10)
+	}
+	return v
+}
@@ -0,0 +1,309 @@
+package dataframe
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/memory"
+)
+
+// fakeDriver is an in-process database/sql driver. The rows of queries are given ahead of time
+// and the statements that are executed are recorded.
+type fakeDriver struct {
+	mu      sync.Mutex
+	results map[string]*fakeResult
+	execs   []fakeExec
+}
+
+type fakeResult struct {
+	columns   []string
+	dbTypes   []string
+	scanTypes []reflect.Type
+	rows      [][]driver.Value
+}
+
+type fakeExec struct {
+	query string
+	args  []driver.Value
+}
+
+var testFakeDriver = &fakeDriver{results: make(map[string]*fakeResult)}
+
+func init() {
+	sql.Register("bullseye-fake", testFakeDriver)
+}
+
+func (d *fakeDriver) Open(name string) (driver.Conn, error) { return &fakeConn{d}, nil }
+
+type fakeConn struct{ d *fakeDriver }
+
+func (c *fakeConn) Prepare(query string) (driver.Stmt, error) { return &fakeStmt{c.d, query}, nil }
+func (c *fakeConn) Close() error                              { return nil }
+func (c *fakeConn) Begin() (driver.Tx, error)                 { return c, nil }
+func (c *fakeConn) Commit() error                             { return nil }
+func (c *fakeConn) Rollback() error                           { return nil }
+
+type fakeStmt struct {
+	d     *fakeDriver
+	query string
+}
+
+func (s *fakeStmt) Close() error  { return nil }
+func (s *fakeStmt) NumInput() int { return -1 }
+
+func (s *fakeStmt) Exec(args []driver.Value) (driver.Result, error) {
+	s.d.mu.Lock()
+	defer s.d.mu.Unlock()
+	s.d.execs = append(s.d.execs, fakeExec{s.query, args})
+	return driver.RowsAffected(0), nil
+}
+
+func (s *fakeStmt) Query(args []driver.Value) (driver.Rows, error) {
+	s.d.mu.Lock()
+	defer s.d.mu.Unlock()
+	result, ok := s.d.results[s.query]
+	if !ok {
+		return nil, fmt.Errorf("no result for %q", s.query)
+	}
+	return &fakeRows{result: result}, nil
+}
+
+type fakeRows struct {
+	result *fakeResult
+	row    int
+}
+
+func (r *fakeRows) Columns() []string { return r.result.columns }
+func (r *fakeRows) Close() error      { return nil }
+
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if r.row >= len(r.result.rows) {
+		return io.EOF
+	}
+	copy(dest, r.result.rows[r.row])
+	r.row++
+	return nil
+}
+
+func (r *fakeRows) ColumnTypeScanType(i int) reflect.Type   { return r.result.scanTypes[i] }
+func (r *fakeRows) ColumnTypeDatabaseTypeName(i int) string { return r.result.dbTypes[i] }
+
+func openFakeDB(t *testing.T, query string, result *fakeResult) *sql.DB {
+	t.Helper()
+	testFakeDriver.mu.Lock()
+	testFakeDriver.results[query] = result
+	testFakeDriver.execs = nil
+	testFakeDriver.mu.Unlock()
+
+	db, err := sql.Open("bullseye-fake", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return db
+}
+
+func TestFromSQLRows(t *testing.T) {
+	pool := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer pool.AssertSize(t, 0)
+
+	anyType := reflect.TypeOf((*interface{})(nil)).Elem()
+	day := time.Date(2019, 6, 1, 0, 0, 0, 0, time.UTC)
+	db := openFakeDB(t, "SELECT * FROM orders", &fakeResult{
+		columns:   []string{"id", "name", "price", "day", "at", "paid", "qty"},
+		dbTypes:   []string{"BIGINT", "VARCHAR", "NUMERIC(10, 2)", "DATE", "TIMESTAMP", "BOOL", "INTEGER"},
+		scanTypes: []reflect.Type{reflect.TypeOf(int64(0)), reflect.TypeOf(sql.NullString{}), anyType, reflect.TypeOf(time.Time{}), anyType, anyType, anyType},
+		rows: [][]driver.Value{
+			{int64(1), "ann", 9.5, day, day.Add(time.Hour), true, int64(3)},
+			{int64(2), nil, nil, day.AddDate(0, 0, 1), "2019-06-02 10:30:00", false, nil},
+			{int64(3), []byte("cat"), int64(7), nil, nil, nil, int64(255)},
+		},
+	})
+	defer db.Close()
+
+	rows, err := db.Query("SELECT * FROM orders")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rows.Close()
+
+	df, err := FromSQLRows(pool, rows, WithBatchSize(2), WithSQLTypes(map[string]arrow.DataType{"qty": arrow.PrimitiveTypes.Uint8}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer df.Release()
+
+	got := df.Display(-1)
+	want := `rec[0]["id"]: [1 2]
+rec[0]["name"]: ["ann" (null)]
+rec[0]["price"]: [9.5 (null)]
+rec[0]["day"]: [18048 18049]
+rec[0]["at"]: [1559350800000000000 1559471400000000000]
+rec[0]["paid"]: [true false]
+rec[0]["qty"]: [3 (null)]
+rec[1]["id"]: [3]
+rec[1]["name"]: ["cat"]
+rec[1]["price"]: [7]
+rec[1]["day"]: [(null)]
+rec[1]["at"]: [(null)]
+rec[1]["paid"]: [(null)]
+rec[1]["qty"]: [255]
+`
+	if got != want {
+		t.Fatalf("\ngot=\n%v\nwant=\n%v", got, want)
+	}
+}
+
+func TestFromSQLRowsErrors(t *testing.T) {
+	pool := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer pool.AssertSize(t, 0)
+
+	anyType := reflect.TypeOf((*interface{})(nil)).Elem()
+	for _, tc := range []struct {
+		name   string
+		result *fakeResult
+		opts   []Option
+		want   string
+	}{
+		{
+			name:   "unknown type",
+			result: &fakeResult{columns: []string{"g"}, dbTypes: []string{"GEOMETRY"}, scanTypes: []reflect.Type{anyType}},
+			want:   "bullseye/dataframe: cannot read column g of database type GEOMETRY",
+		},
+		{
+			name:   "overflow",
+			result: &fakeResult{columns: []string{"n"}, dbTypes: []string{"INTEGER"}, scanTypes: []reflect.Type{anyType}, rows: [][]driver.Value{{int64(300)}}},
+			opts:   []Option{WithSQLTypes(map[string]arrow.DataType{"n": arrow.PrimitiveTypes.Int8})},
+			want:   "bullseye/dataframe: cannot read row 0 of column n: overflows int8",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			query := "SELECT " + tc.name
+			db := openFakeDB(t, query, tc.result)
+			defer db.Close()
+			rows, err := db.Query(query)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer rows.Close()
+
+			df, err := FromSQLRows(pool, rows, tc.opts...)
+			if err == nil {
+				df.Release()
+			}
+			if err == nil || err.Error() != tc.want {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestToSQL(t *testing.T) {
+	pool := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer pool.AssertSize(t, 0)
+
+	df, err := NewDataFrameFromMem(pool, Dict{
+		"A": []int32{1, 2, 3},
+		"B": []interface{}{"x", nil, "z"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer df.Release()
+
+	db := openFakeDB(t, "", &fakeResult{})
+	defer db.Close()
+
+	tests := []struct {
+		name  string
+		opts  []Option
+		table string
+		want  []fakeExec
+	}{
+		{
+			name: "question",
+			want: []fakeExec{
+				{`INSERT INTO "t" ("A", "B") VALUES (?, ?), (?, ?), (?, ?)`, []driver.Value{int64(1), "x", int64(2), nil, int64(3), "z"}},
+			},
+		},
+		{
+			name:  "backtick",
+			opts:  []Option{WithIdentifierQuote(QuoteBacktick)},
+			table: "s.t`x",
+			want: []fakeExec{
+				{"INSERT INTO `s`.`t``x` (`A`, `B`) VALUES (?, ?), (?, ?), (?, ?)", []driver.Value{int64(1), "x", int64(2), nil, int64(3), "z"}},
+			},
+		},
+		{
+			name:  "quoted table",
+			table: `t"; DROP TABLE t; --`,
+			want: []fakeExec{
+				{`INSERT INTO "t""; DROP TABLE t; --" ("A", "B") VALUES (?, ?), (?, ?), (?, ?)`, []driver.Value{int64(1), "x", int64(2), nil, int64(3), "z"}},
+			},
+		},
+		{
+			name: "dollar batches",
+			opts: []Option{WithBatchSize(2), WithPlaceholder(PlaceholderDollar)},
+			want: []fakeExec{
+				{`INSERT INTO "t" ("A", "B") VALUES ($1, $2), ($3, $4)`, []driver.Value{int64(1), "x", int64(2), nil}},
+				{`INSERT INTO "t" ("A", "B") VALUES ($1, $2)`, []driver.Value{int64(3), "z"}},
+			},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			testFakeDriver.mu.Lock()
+			testFakeDriver.execs = nil
+			testFakeDriver.mu.Unlock()
+
+			table := tc.table
+			if table == "" {
+				table = "t"
+			}
+			if err := df.ToSQL(context.Background(), db, table, tc.opts...); err != nil {
+				t.Fatal(err)
+			}
+			if got := testFakeDriver.execs; !reflect.DeepEqual(got, tc.want) {
+				t.Fatalf("\ngot=\n%v\nwant=\n%v", got, tc.want)
+			}
+		})
+	}
+
+	unsigned, err := NewDataFrameFromMem(pool, Dict{
+		"U": []uint64{1, 18446744073709551615},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer unsigned.Release()
+	testFakeDriver.mu.Lock()
+	testFakeDriver.execs = nil
+	testFakeDriver.mu.Unlock()
+	if err := unsigned.ToSQL(context.Background(), db, "t"); err != nil {
+		t.Fatal(err)
+	}
+	wantUnsigned := []fakeExec{
+		{`INSERT INTO "t" ("U") VALUES (?), (?)`, []driver.Value{int64(1), "18446744073709551615"}},
+	}
+	if got := testFakeDriver.execs; !reflect.DeepEqual(got, wantUnsigned) {
+		t.Fatalf("\ngot=\n%v\nwant=\n%v", got, wantUnsigned)
+	}
+
+	nested, err := ReadJSONLines(pool, strings.NewReader(`{"a": [1]}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer nested.Release()
+	if err := nested.ToSQL(context.Background(), db, "t"); err == nil ||
+		err.Error() != "bullseye/dataframe: cannot insert column a of type list<item: int64>" {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
@@ -0,0 +1,388 @@
+package dataframe
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/memory"
+	"github.com/pkg/errors"
+)
+
+// StructTag is the key of the struct field tags read by FromStructs.
+const StructTag = "bullseye"
+
+// StructSeparator joins the names of nested struct fields when they are flattened.
+const StructSeparator = "."
+
+// StructLayout is how FromStructs lays out the fields of nested structs.
+type StructLayout int
+
+const (
+	// StructFlatten makes a column for each field of a nested struct, named after the
+	// struct field and the nested field joined by StructSeparator, e.g. "Address.City".
+	StructFlatten StructLayout = iota
+	// StructColumns makes a Struct column for each nested struct.
+	StructColumns
+)
+
+func (l StructLayout) String() string {
+	switch l {
+	case StructFlatten:
+		return "flatten"
+	case StructColumns:
+		return "columns"
+	default:
+		return fmt.Sprintf("StructLayout(%d)", int(l))
+	}
+}
+
+type structsConfig struct {
+	layout StructLayout
+}
+
+func newStructsConfig(opts ...Option) (*structsConfig, error) {
+	cfg := &structsConfig{}
+	for _, opt := range opts {
+		if err := opt(cfg); err != nil {
+			return nil, err
+		}
+	}
+	return cfg, nil
+}
+
+// WithStructLayout sets how FromStructs lays out nested structs. The default is StructFlatten.
+func WithStructLayout(layout StructLayout) Option {
+	return func(p interface{}) error {
+		cfg, ok := p.(*structsConfig)
+		if !ok {
+			return errors.Errorf("cannot apply WithStructLayout to: %T", p)
+		}
+		if layout < StructFlatten || layout > StructColumns {
+			return errors.Errorf("invalid struct layout %v", layout)
+		}
+		cfg.layout = layout
+		return nil
+	}
+}
+
+var (
+	timeType = reflect.TypeOf(time.Time{})
+
+	// structTimestampType is the type of the columns of time.Time fields.
+	structTimestampType = &arrow.TimestampType{Unit: arrow.Nanosecond}
+
+	// minStructTime and maxStructTime are the range of the times of nanosecond timestamps.
+	minStructTime = time.Unix(0, math.MinInt64)
+	maxStructTime = time.Unix(0, math.MaxInt64)
+)
+
+// structColumn is a column of the fields of a struct type.
+type structColumn struct {
+	field arrow.Field
+	// index is the sequence of field indexes of the field, like reflect.StructField.Index,
+	// from the struct of the row, or from the nested struct for the fields of a Struct column.
+	index     []int
+	omitEmpty bool
+	// children are the columns of the fields of a Struct column.
+	children []structColumn
+}
+
+// FromStructs creates a DataFrame from a slice of structs, or of pointers to structs, with a
+// column for each exported field in the order of the fields. Fields are named after the field
+// or after the name in their `bullseye:"name,omitempty"` tag, and a field tagged "-" is left out.
+// With omitempty the zero values of the field are null. Pointer fields make nullable columns
+// where nil pointers are null.
+//
+// The fields can be booleans, integers, floats, strings, which make columns of the same type with
+// int and uint stored as int64 and uint64, time.Time, which makes a nanosecond Timestamp column
+// and must be within the years 1678 to 2262, and structs, which are laid out following
+// WithStructLayout. The fields of embedded structs are treated as fields of the outer struct,
+// like encoding/json does, unless the embedded struct is given a name in its tag. Fields of any
+// other kind are an error. Times outside of the range of the column are an error too, including
+// the zero time.Time, which can be stored as null with omitempty or a pointer field.
+func FromStructs(mem memory.Allocator, slice interface{}, opts ...Option) (*DataFrame, error) {
+	cfg, err := newStructsConfig(opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	rows := reflect.ValueOf(slice)
+	if rows.Kind() != reflect.Slice && rows.Kind() != reflect.Array {
+		return nil, errors.Errorf("bullseye/dataframe: cannot create a DataFrame from %T, it must be a slice of structs", slice)
+	}
+	rowType := rows.Type().Elem()
+	if rowType.Kind() == reflect.Ptr {
+		rowType = rowType.Elem()
+	}
+	if rowType.Kind() != reflect.Struct || rowType == timeType {
+		return nil, errors.Errorf("bullseye/dataframe: cannot create a DataFrame from %T, it must be a slice of structs", slice)
+	}
+
+	cols, err := structColumns(rowType, "", nil, false, cfg.layout, map[reflect.Type]bool{})
+	if err != nil {
+		return nil, err
+	}
+
+	values := make([][]interface{}, len(cols))
+	for i := range values {
+		values[i] = make([]interface{}, rows.Len())
+	}
+	for row := 0; row < rows.Len(); row++ {
+		v := rows.Index(row)
+		if v.Kind() == reflect.Ptr {
+			if v.IsNil() {
+				return nil, errors.Errorf("bullseye/dataframe: cannot create a DataFrame from %T, element %d is nil", slice, row)
+			}
+			v = v.Elem()
+		}
+		for i := range cols {
+			if values[i][row], err = cols[i].value(v); err != nil {
+				return nil, errors.Wrapf(err, "bullseye/dataframe: cannot create a DataFrame from %T, element %d", slice, row)
+			}
+		}
+	}
+
+	fields := make([]arrow.Field, len(cols))
+	arrs := make([]array.Interface, 0, len(cols))
+	defer func() {
+		for _, arr := range arrs {
+			arr.Release()
+		}
+	}()
+	for i := range cols {
+		fields[i] = cols[i].field
+		arr, err := newArrayFromValues(mem, cols[i].field.Type, values[i])
+		if err != nil {
+			return nil, err
+		}
+		arrs = append(arrs, arr)
+	}
+
+	return NewDataFrame(mem, arrow.NewSchema(fields, nil), arrs)
+}
+
+// structColumns returns the columns of the fields of the struct type t. The names of the columns start
+// with prefix, their indexes with index, and they are nullable when nullable is true. visiting holds
+// the struct types being flattened, to fail on recursive types instead of recursing forever.
+func structColumns(t reflect.Type, prefix string, index []int, nullable bool, layout StructLayout, visiting map[reflect.Type]bool) ([]structColumn, error) {
+	if visiting[t] {
+		return nil, errors.Errorf("bullseye/dataframe: cannot create columns of recursive struct %v", t)
+	}
+	visiting[t] = true
+	defer delete(visiting, t)
+
+	var cols []structColumn
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		tag := sf.Tag.Get(StructTag)
+		if tag == "-" {
+			continue
+		}
+		name, omitEmpty := parseStructTag(tag)
+
+		ft, pointer := sf.Type, false
+		if ft.Kind() == reflect.Ptr {
+			ft, pointer = ft.Elem(), true
+		}
+		nested := ft.Kind() == reflect.Struct && ft != timeType
+		if sf.PkgPath != "" && !(sf.Anonymous && nested) {
+			// Unexported fields are left out, but the fields of unexported embedded structs are not.
+			continue
+		}
+
+		fieldIndex := append(index[:len(index):len(index)], i)
+		if sf.Anonymous && nested && name == "" {
+			embedded, err := structColumns(ft, prefix, fieldIndex, nullable || pointer, layout, visiting)
+			if err != nil {
+				return nil, err
+			}
+			cols = append(cols, embedded...)
+			continue
+		}
+		if name == "" {
+			name = sf.Name
+		}
+		name = prefix + name
+
+		switch {
+		case nested && layout == StructFlatten:
+			flattened, err := structColumns(ft, name+StructSeparator, fieldIndex, nullable || pointer, layout, visiting)
+			if err != nil {
+				return nil, err
+			}
+			cols = append(cols, flattened...)
+
+		case nested:
+			children, err := structColumns(ft, "", nil, false, layout, visiting)
+			if err != nil {
+				return nil, err
+			}
+			childFields := make([]arrow.Field, len(children))
+			for j := range children {
+				childFields[j] = children[j].field
+			}
+			cols = append(cols, structColumn{
+				field:    arrow.Field{Name: name, Type: arrow.StructOf(childFields...), Nullable: nullable || pointer},
+				index:    fieldIndex,
+				children: children,
+			})
+
+		default:
+			dtype, ok := structFieldType(ft)
+			if !ok {
+				return nil, errors.Errorf("bullseye/dataframe: cannot create a column of field %v.%s of type %v", t, sf.Name, sf.Type)
+			}
+			cols = append(cols, structColumn{
+				field:     arrow.Field{Name: name, Type: dtype, Nullable: nullable || pointer || omitEmpty},
+				index:     fieldIndex,
+				omitEmpty: omitEmpty,
+			})
+		}
+	}
+
+	taken := make(map[string]struct{}, len(cols))
+	for _, col := range cols {
+		if _, ok := taken[col.field.Name]; ok {
+			return nil, errors.Errorf("bullseye/dataframe: more than one field of %v has the column name %s", t, col.field.Name)
+		}
+		taken[col.field.Name] = struct{}{}
+	}
+	return cols, nil
+}
+
+// parseStructTag returns the name and whether omitempty is set in a field tag.
+func parseStructTag(tag string) (string, bool) {
+	parts := strings.Split(tag, ",")
+	omitEmpty := false
+	for _, opt := range parts[1:] {
+		if opt == "omitempty" {
+			omitEmpty = true
+		}
+	}
+	return parts[0], omitEmpty
+}
+
+// structFieldType returns the type of the column of a field of type t.
+func structFieldType(t reflect.Type) (arrow.DataType, bool) {
+	if t == timeType {
+		return structTimestampType, true
+	}
+	switch t.Kind() {
+	case reflect.Bool:
+		return arrow.FixedWidthTypes.Boolean, true
+	case reflect.Int8:
+		return arrow.PrimitiveTypes.Int8, true
+	case reflect.Int16:
+		return arrow.PrimitiveTypes.Int16, true
+	case reflect.Int32:
+		return arrow.PrimitiveTypes.Int32, true
+	case reflect.Int, reflect.Int64:
+		return arrow.PrimitiveTypes.Int64, true
+	case reflect.Uint8:
+		return arrow.PrimitiveTypes.Uint8, true
+	case reflect.Uint16:
+		return arrow.PrimitiveTypes.Uint16, true
+	case reflect.Uint32:
+		return arrow.PrimitiveTypes.Uint32, true
+	case reflect.Uint, reflect.Uint64:
+		return arrow.PrimitiveTypes.Uint64, true
+	case reflect.Float32:
+		return arrow.PrimitiveTypes.Float32, true
+	case reflect.Float64:
+		return arrow.PrimitiveTypes.Float64, true
+	case reflect.String:
+		return arrow.BinaryTypes.String, true
+	}
+	return nil, false
+}
+
+// value returns the value of the column in the struct v, which is nil when
+// the field, or a struct pointer on the way to the field, is nil.
+func (c *structColumn) value(v reflect.Value) (interface{}, error) {
+	for _, i := range c.index {
+		if v.Kind() == reflect.Ptr {
+			if v.IsNil() {
+				return nil, nil
+			}
+			v = v.Elem()
+		}
+		v = v.Field(i)
+	}
+	if v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return nil, nil
+		}
+		v = v.Elem()
+	}
+
+	if c.children != nil {
+		values := make([]interface{}, len(c.children))
+		for i := range c.children {
+			var err error
+			if values[i], err = c.children[i].value(v); err != nil {
+				return nil, err
+			}
+		}
+		return values, nil
+	}
+	if c.omitEmpty && isZeroField(v) {
+		return nil, nil
+	}
+
+	switch c.field.Type.ID() {
+	case arrow.BOOL:
+		return v.Bool(), nil
+	case arrow.INT8:
+		return int8(v.Int()), nil
+	case arrow.INT16:
+		return int16(v.Int()), nil
+	case arrow.INT32:
+		return int32(v.Int()), nil
+	case arrow.INT64:
+		return v.Int(), nil
+	case arrow.UINT8:
+		return uint8(v.Uint()), nil
+	case arrow.UINT16:
+		return uint16(v.Uint()), nil
+	case arrow.UINT32:
+		return uint32(v.Uint()), nil
+	case arrow.UINT64:
+		return v.Uint(), nil
+	case arrow.FLOAT32:
+		return float32(v.Float()), nil
+	case arrow.FLOAT64:
+		return v.Float(), nil
+	case arrow.STRING:
+		return v.String(), nil
+	default:
+		t := v.Interface().(time.Time)
+		if t.Before(minStructTime) || t.After(maxStructTime) {
+			return nil, errors.Errorf("time %v of column %s is out of the range of %v", t, c.field.Name, c.field.Type)
+		}
+		return arrow.Timestamp(t.UnixNano()), nil
+	}
+}
+
+// isZeroField returns true if the value of a column field is the zero value of its type.
+func isZeroField(v reflect.Value) bool {
+	if v.Type() == timeType {
+		return v.Interface().(time.Time).IsZero()
+	}
+	switch v.Kind() {
+	case reflect.Bool:
+		return !v.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int() == 0
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return v.Uint() == 0
+	case reflect.Float32, reflect.Float64:
+		return v.Float() == 0
+	default:
+		return v.Len() == 0
+	}
+}
@@ -0,0 +1,207 @@
+package dataframe
+
+import (
+	"testing"
+	"time"
+
+	"github.com/apache/arrow/go/arrow/memory"
+)
+
+type testAddress struct {
+	City string
+	Zip  *string `bullseye:"zip"`
+}
+
+type testAudit struct {
+	CreatedBy string
+	internal  int
+}
+
+type testOrder struct {
+	testAudit
+	ID       int     `bullseye:"id"`
+	Quantity *int32  `bullseye:"qty"`
+	Price    float64 `bullseye:"price,omitempty"`
+	Paid     bool
+	Placed   time.Time
+	Ship     testAddress
+	Bill     *testAddress
+	Note     string `bullseye:"-"`
+	secret   string
+}
+
+func TestFromStructs(t *testing.T) {
+	pool := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer pool.AssertSize(t, 0)
+
+	qty, zip := int32(3), "10001"
+	placed := time.Date(2019, 6, 1, 0, 0, 0, 0, time.UTC)
+	orders := []*testOrder{
+		{
+			testAudit: testAudit{CreatedBy: "ann", internal: 1},
+			ID:        1, Quantity: &qty, Price: 9.5, Paid: true, Placed: placed,
+			Ship: testAddress{City: "NYC", Zip: &zip},
+			Bill: &testAddress{City: "LA"},
+			Note: "left out", secret: "left out",
+		},
+		{
+			testAudit: testAudit{CreatedBy: "bob"},
+			ID:        2, Placed: placed.Add(time.Second),
+			Ship: testAddress{City: "SF"},
+		},
+	}
+
+	tests := []struct {
+		name   string
+		layout StructLayout
+		want   string
+	}{
+		{
+			name:   "flatten",
+			layout: StructFlatten,
+			want: `rec[0]["CreatedBy"]: ["ann" "bob"]
+rec[0]["id"]: [1 2]
+rec[0]["qty"]: [3 (null)]
+rec[0]["price"]: [9.5 (null)]
+rec[0]["Paid"]: [true false]
+rec[0]["Placed"]: [1559347200000000000 1559347201000000000]
+rec[0]["Ship.City"]: ["NYC" "SF"]
+rec[0]["Ship.zip"]: ["10001" (null)]
+rec[0]["Bill.City"]: ["LA" (null)]
+rec[0]["Bill.zip"]: [(null) (null)]
+`,
+		},
+		{
+			name:   "columns",
+			layout: StructColumns,
+			want: `rec[0]["CreatedBy"]: ["ann" "bob"]
+rec[0]["id"]: [1 2]
+rec[0]["qty"]: [3 (null)]
+rec[0]["price"]: [9.5 (null)]
+rec[0]["Paid"]: [true false]
+rec[0]["Placed"]: [1559347200000000000 1559347201000000000]
+rec[0]["Ship"]: {["NYC" "SF"] ["10001" (null)]}
+rec[0]["Bill"]: {["LA" (null)] [(null) (null)]}
+`,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			df, err := FromStructs(pool, orders, WithStructLayout(tc.layout))
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer df.Release()
+
+			if got := df.Display(-1); got != tc.want {
+				t.Fatalf("\ngot=\n%v\nwant=\n%v", got, tc.want)
+			}
+		})
+	}
+
+	t.Run("schema", func(t *testing.T) {
+		df, err := FromStructs(pool, []testOrder{}, WithStructLayout(StructColumns))
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer df.Release()
+
+		for _, tc := range []struct {
+			name     string
+			nullable bool
+		}{
+			{"id", false},
+			{"qty", true},
+			{"price", true},
+			{"Ship", false},
+			{"Bill", true},
+		} {
+			field, ok := df.Schema().FieldByName(tc.name)
+			if !ok || field.Nullable != tc.nullable {
+				t.Fatalf("field %s: got=%v, want nullable=%v", tc.name, field, tc.nullable)
+			}
+		}
+		if got, want := df.NumRows(), int64(0); got != want {
+			t.Fatalf("got=%d rows, want=%d", got, want)
+		}
+	})
+}
+
+func TestFromStructsErrors(t *testing.T) {
+	pool := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer pool.AssertSize(t, 0)
+
+	type withSlice struct {
+		Items []string
+	}
+	type duplicate struct {
+		A int
+		B int `bullseye:"A"`
+	}
+	type node struct {
+		Next *node
+	}
+	type event struct {
+		At time.Time
+	}
+
+	for _, tc := range []struct {
+		name  string
+		slice interface{}
+		want  string
+	}{
+		{"not a slice", testOrder{}, "bullseye/dataframe: cannot create a DataFrame from dataframe.testOrder, it must be a slice of structs"},
+		{"not structs", []int{1}, "bullseye/dataframe: cannot create a DataFrame from []int, it must be a slice of structs"},
+		{"nil element", []*testAddress{nil}, "bullseye/dataframe: cannot create a DataFrame from []*dataframe.testAddress, element 0 is nil"},
+		{"unsupported", []withSlice{}, "bullseye/dataframe: cannot create a column of field dataframe.withSlice.Items of type []string"},
+		{"duplicate", []duplicate{}, "bullseye/dataframe: more than one field of dataframe.duplicate has the column name A"},
+		{"recursive", []node{}, "bullseye/dataframe: cannot create columns of recursive struct dataframe.node"},
+		{"zero time", []event{{}}, "bullseye/dataframe: cannot create a DataFrame from []dataframe.event, element 0: time 0001-01-01 00:00:00 +0000 UTC of column At is out of the range of timestamp[ns]"},
+		{"time out of range", []event{{At: time.Date(2263, 1, 1, 0, 0, 0, 0, time.UTC)}}, "bullseye/dataframe: cannot create a DataFrame from []dataframe.event, element 0: time 2263-01-01 00:00:00 +0000 UTC of column At is out of the range of timestamp[ns]"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			df, err := FromStructs(pool, tc.slice)
+			if err == nil {
+				df.Release()
+			}
+			if err == nil || err.Error() != tc.want {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestFromStructsZeroTime(t *testing.T) {
+	pool := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer pool.AssertSize(t, 0)
+
+	type event struct {
+		ID   int
+		At   time.Time  `bullseye:",omitempty"`
+		Seen *time.Time `bullseye:"seen"`
+	}
+	events := []event{{ID: 1}, {ID: 2, At: time.Unix(1, 0)}}
+
+	df, err := FromStructs(pool, events)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer df.Release()
+
+	got := df.Display(-1)
+	want := `rec[0]["ID"]: [1 2]
+rec[0]["At"]: [(null) 1000000000]
+rec[0]["seen"]: [(null) (null)]
+`
+	if got != want {
+		t.Fatalf("\ngot=\n%v\nwant=\n%v", got, want)
+	}
+
+	var scanned []event
+	if err := df.ScanStructs(&scanned, WithNullsAsZero()); err != nil {
+		t.Fatal(err)
+	}
+	if !scanned[0].At.IsZero() || !scanned[1].At.Equal(events[1].At) || scanned[0].Seen != nil {
+		t.Fatalf("got %v, want %v", scanned, events)
+	}
+}
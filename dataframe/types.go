@@ -0,0 +1,46 @@
+package dataframe
+
+import (
+	"github.com/apache/arrow/go/arrow"
+)
+
+// isSignedInteger returns true if dtype is one of the signed integer types.
+func isSignedInteger(dtype arrow.DataType) bool {
+	switch dtype.ID() {
+	case arrow.INT8, arrow.INT16, arrow.INT32, arrow.INT64:
+		return true
+	}
+	return false
+}
+
+// isUnsignedInteger returns true if dtype is one of the unsigned integer types.
+func isUnsignedInteger(dtype arrow.DataType) bool {
+	switch dtype.ID() {
+	case arrow.UINT8, arrow.UINT16, arrow.UINT32, arrow.UINT64:
+		return true
+	}
+	return false
+}
+
+// isFloat returns true if dtype is one of the floating point types.
+func isFloat(dtype arrow.DataType) bool {
+	switch dtype.ID() {
+	case arrow.FLOAT32, arrow.FLOAT64:
+		return true
+	}
+	return false
+}
+
+// isNumeric returns true if dtype is one of the integer or floating point types.
+func isNumeric(dtype arrow.DataType) bool {
+	return isSignedInteger(dtype) || isUnsignedInteger(dtype) || isFloat(dtype)
+}
+
+// isDate returns true if dtype is Date32 or Date64.
+func isDate(dtype arrow.DataType) bool {
+	switch dtype.ID() {
+	case arrow.DATE32, arrow.DATE64:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,180 @@
+package dataframe
+
+import (
+	"reflect"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/memory"
+	"github.com/go-bullseye/bullseye/internal/arrays"
+	"github.com/go-bullseye/bullseye/internal/constructors"
+)
+
+// arrayValues returns all of the values of arr, as returned by arrays.Value. Null values are nil.
+func arrayValues(arr array.Interface) []interface{} {
+	values := make([]interface{}, arr.Len())
+	for i := range values {
+		values[i] = arrays.Value(arr, i)
+	}
+	return values
+}
+
+// newArrayFromValues creates an array of the given type from values
+// that are the Go type used by the array or nil. The array must be released.
+func newArrayFromValues(mem memory.Allocator, dtype arrow.DataType, values []interface{}) (array.Interface, error) {
+	switch dt := dtype.(type) {
+	case *arrow.StructType:
+		return newStructArrayFromValues(mem, dt, values)
+	case *arrow.ListType:
+		return newListArrayFromValues(mem, dt, values)
+	}
+
+	builder, err := constructors.NewBuilder(mem, dtype)
+	if err != nil {
+		return nil, err
+	}
+	defer builder.Release()
+
+	appender := initFieldAppender(&arrow.Field{Type: dtype})
+	for _, v := range values {
+		appender(builder, v)
+	}
+	return builder.NewArray(), nil
+}
+
+// newStructArrayFromValues creates a struct array from values that are either nil or a []interface{}
+// with a value for each field of dtype. The array must be released.
+func newStructArrayFromValues(mem memory.Allocator, dtype *arrow.StructType, values []interface{}) (array.Interface, error) {
+	// The builders of this version of Arrow can't build struct arrays with temporal
+	// fields, so the array is put together from the arrays of its fields.
+	fields := dtype.Fields()
+	children := make([]*array.Data, 0, len(fields))
+	defer func() {
+		for _, child := range children {
+			child.Release()
+		}
+	}()
+	for i, field := range fields {
+		fieldValues := make([]interface{}, len(values))
+		for j, v := range values {
+			if v != nil {
+				fieldValues[j] = v.([]interface{})[i]
+			}
+		}
+		arr, err := newArrayFromValues(mem, field.Type, fieldValues)
+		if err != nil {
+			return nil, err
+		}
+		arr.Data().Retain()
+		children = append(children, arr.Data())
+		arr.Release()
+	}
+
+	bitmap, nulls := newValidityBitmap(mem, values)
+	defer bitmap.Release()
+
+	data := array.NewData(dtype, len(values), []*memory.Buffer{bitmap}, children, nulls, 0)
+	defer data.Release()
+	return array.MakeFromData(data), nil
+}
+
+// newListArrayFromValues creates a list array from values that are either nil
+// or a []interface{} with the values of the list. The array must be released.
+func newListArrayFromValues(mem memory.Allocator, dtype *arrow.ListType, values []interface{}) (array.Interface, error) {
+	// Like struct arrays, the list array is put together from the array of its values.
+	offsets := memory.NewResizableBuffer(mem)
+	defer offsets.Release()
+	offsets.Resize(arrow.Int32Traits.BytesRequired(len(values) + 1))
+	offsetValues := arrow.Int32Traits.CastFromBytes(offsets.Bytes())
+
+	var elems []interface{}
+	for i, v := range values {
+		offsetValues[i] = int32(len(elems))
+		if v != nil {
+			elems = append(elems, v.([]interface{})...)
+		}
+	}
+	offsetValues[len(values)] = int32(len(elems))
+
+	elemArr, err := newArrayFromValues(mem, dtype.Elem(), elems)
+	if err != nil {
+		return nil, err
+	}
+	defer elemArr.Release()
+
+	bitmap, nulls := newValidityBitmap(mem, values)
+	defer bitmap.Release()
+
+	data := array.NewData(dtype, len(values), []*memory.Buffer{bitmap, offsets}, []*array.Data{elemArr.Data()}, nulls, 0)
+	defer data.Release()
+	return array.MakeFromData(data), nil
+}
+
+// newValidityBitmap creates a bitmap of the values that are not nil and returns it with
+// the number of nil values. The bitmap must be released.
+func newValidityBitmap(mem memory.Allocator, values []interface{}) (*memory.Buffer, int) {
+	bitmap := memory.NewResizableBuffer(mem)
+	bitmap.Resize((len(values) + 7) / 8)
+	valid := bitmap.Bytes()
+	memory.Set(valid, 0)
+	nulls := 0
+	for i, v := range values {
+		if v == nil {
+			nulls++
+			continue
+		}
+		valid[i/8] |= 1 << uint(i%8)
+	}
+	return bitmap, nulls
+}
+
+// newColumnFromChunks creates a column from the chunks. The column must be released.
+func newColumnFromChunks(field arrow.Field, chunks []array.Interface) *array.Column {
+	chunked := array.NewChunked(field.Type, chunks)
+	defer chunked.Release()
+	return array.NewColumn(field, chunked)
+}
+
+// goType returns the Go type of the values of dtype.
+func goType(dtype arrow.DataType) (reflect.Type, bool) {
+	var v interface{}
+	switch dtype.ID() {
+	case arrow.BOOL:
+		v = false
+	case arrow.INT8:
+		v = int8(0)
+	case arrow.INT16:
+		v = int16(0)
+	case arrow.INT32:
+		v = int32(0)
+	case arrow.INT64:
+		v = int64(0)
+	case arrow.UINT8:
+		v = uint8(0)
+	case arrow.UINT16:
+		v = uint16(0)
+	case arrow.UINT32:
+		v = uint32(0)
+	case arrow.UINT64:
+		v = uint64(0)
+	case arrow.FLOAT32:
+		v = float32(0)
+	case arrow.FLOAT64:
+		v = float64(0)
+	case arrow.STRING:
+		v = ""
+	case arrow.DATE32:
+		v = arrow.Date32(0)
+	case arrow.DATE64:
+		v = arrow.Date64(0)
+	case arrow.TIMESTAMP:
+		v = arrow.Timestamp(0)
+	case arrow.TIME32:
+		v = arrow.Time32(0)
+	case arrow.TIME64:
+		v = arrow.Time64(0)
+	default:
+		return nil, false
+	}
+	return reflect.TypeOf(v), true
+}
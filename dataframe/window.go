@@ -0,0 +1,637 @@
+package dataframe
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/pkg/errors"
+)
+
+type windowConfig struct {
+	partitionBy []string
+	orderBy     []string
+	name        string
+}
+
+func newWindowConfig(opts ...Option) (*windowConfig, error) {
+	cfg := &windowConfig{}
+	for _, opt := range opts {
+		if err := opt(cfg); err != nil {
+			return nil, err
+		}
+	}
+	return cfg, nil
+}
+
+// WithPartitionBy computes a window function separately for the rows
+// of each distinct combination of values of the columns.
+func WithPartitionBy(columns ...string) Option {
+	return func(p interface{}) error {
+		cfg, ok := p.(*windowConfig)
+		if !ok {
+			return errors.Errorf("cannot apply WithPartitionBy to: %T", p)
+		}
+		cfg.partitionBy = columns
+		return nil
+	}
+}
+
+// WithOrderBy computes a window function over the rows sorted in ascending order
+// of the columns, with nulls last. Rows that are equal keep their original order.
+// The rows of the resulting DataFrame are always in their original order.
+func WithOrderBy(columns ...string) Option {
+	return func(p interface{}) error {
+		cfg, ok := p.(*windowConfig)
+		if !ok {
+			return errors.Errorf("cannot apply WithOrderBy to: %T", p)
+		}
+		cfg.orderBy = columns
+		return nil
+	}
+}
+
+// WithColumnName sets the name of the column a window function appends.
+func WithColumnName(name string) Option {
+	return func(p interface{}) error {
+		cfg, ok := p.(*windowConfig)
+		if !ok {
+			return errors.Errorf("cannot apply WithColumnName to: %T", p)
+		}
+		if name == "" {
+			return errors.New("column name must not be empty")
+		}
+		cfg.name = name
+		return nil
+	}
+}
+
+// windowFunc computes the values of a new column from the values of a column
+// for the rows of one partition, in order.
+type windowFunc struct {
+	// name is appended to the name of the column to name the new column.
+	name string
+	// resultType returns the type of the new column for a column of type dtype.
+	resultType func(dtype arrow.DataType) (arrow.DataType, error)
+	// apply computes the new values for the values of a partition.
+	apply func(dtype arrow.DataType, values []interface{}) ([]interface{}, error)
+}
+
+// window returns a MutationFunc that appends the result of fn over the column to the DataFrame.
+// When column is empty fn is given a nil type and null values for the rows.
+func (m *Mutator) window(column string, opts []Option, fn windowFunc) MutationFunc {
+	return func(df *DataFrame) (*DataFrame, error) {
+		cfg, err := newWindowConfig(opts...)
+		if err != nil {
+			return nil, err
+		}
+
+		// Functions of the rows alone, like RowNumber, don't have a column.
+		var (
+			dtype  arrow.DataType
+			values = make([]interface{}, df.NumRows())
+		)
+		if column != "" {
+			col := df.Column(column)
+			if col == nil {
+				return nil, errors.Errorf("bullseye/mutations: column %s does not exist", column)
+			}
+			dtype = col.DataType()
+			values = columnValues(col)
+		}
+
+		partitionCols, err := df.windowColumns(cfg.partitionBy)
+		if err != nil {
+			return nil, err
+		}
+		orderCols, err := df.windowColumns(cfg.orderBy)
+		if err != nil {
+			return nil, err
+		}
+		for _, c := range orderCols {
+			if !isOrdered(c.DataType()) && !isTemporal(c.DataType()) {
+				return nil, errors.Errorf("bullseye/mutations: cannot order by column %s of type %v", c.Name(), c.DataType())
+			}
+		}
+
+		resultType, err := fn.resultType(dtype)
+		if err != nil {
+			return nil, errors.Wrapf(err, "bullseye/mutations: cannot compute %s of column %s", fn.name, column)
+		}
+		name := cfg.name
+		if name == "" {
+			name = fn.name
+			if column != "" {
+				name = column + "_" + fn.name
+			}
+		}
+		if df.Column(name) != nil {
+			return nil, errors.Errorf("bullseye/mutations: column %s already exists", name)
+		}
+
+		result := make([]interface{}, len(values))
+		partitions, err := windowPartitions(partitionCols, orderCols, len(values))
+		if err != nil {
+			return nil, err
+		}
+		for _, rows := range partitions {
+			in := make([]interface{}, len(rows))
+			for i, row := range rows {
+				in[i] = values[row]
+			}
+			out, err := fn.apply(dtype, in)
+			if err != nil {
+				return nil, errors.Wrapf(err, "bullseye/mutations: cannot compute %s of column %s", fn.name, column)
+			}
+			for i, row := range rows {
+				result[row] = out[i]
+			}
+		}
+
+		arr, err := newArrayFromValues(m.mem, resultType, result)
+		if err != nil {
+			return nil, err
+		}
+		defer arr.Release()
+		newCol := newColumnFromChunks(arrow.Field{Name: name, Type: resultType, Nullable: true}, []array.Interface{arr})
+		defer newCol.Release()
+
+		return df.AppendColumn(newCol)
+	}
+}
+
+// windowColumns returns the named columns. It is an error if a column does not exist.
+func (df *DataFrame) windowColumns(names []string) ([]array.Column, error) {
+	if len(names) == 0 {
+		return nil, nil
+	}
+	return df.subsetColumns(names)
+}
+
+// columnValues returns the values of all of the chunks of the column.
+func columnValues(col *array.Column) []interface{} {
+	values := make([]interface{}, 0, col.Len())
+	for _, chunk := range col.Data().Chunks() {
+		values = append(values, arrayValues(chunk)...)
+	}
+	return values
+}
+
+// windowPartitions groups the rows by the values of the partition columns, in order of
+// their first appearance, and sorts the rows of each partition by the order columns.
+func windowPartitions(partitionCols, orderCols []array.Column, n int) ([][]int, error) {
+	var partitions [][]int
+	if len(partitionCols) == 0 {
+		rows := make([]int, n)
+		for i := range rows {
+			rows[i] = i
+		}
+		partitions = append(partitions, rows)
+	} else {
+		keys := make([][]interface{}, len(partitionCols))
+		for i := range partitionCols {
+			keys[i] = columnValues(&partitionCols[i])
+		}
+		index := make(map[string]int)
+		key := make([]interface{}, len(keys))
+		for row := 0; row < n; row++ {
+			for i := range keys {
+				key[i] = keys[i][row]
+			}
+			k := rowKey(key)
+			p, ok := index[k]
+			if !ok {
+				p = len(partitions)
+				index[k] = p
+				partitions = append(partitions, nil)
+			}
+			partitions[p] = append(partitions[p], row)
+		}
+	}
+
+	if len(orderCols) == 0 {
+		return partitions, nil
+	}
+
+	orderValues := make([][]interface{}, len(orderCols))
+	for i := range orderCols {
+		orderValues[i] = columnValues(&orderCols[i])
+	}
+	var sortErr error
+	for _, rows := range partitions {
+		sort.SliceStable(rows, func(i, j int) bool {
+			for c := range orderCols {
+				cmp, err := compareOrderValues(orderCols[c].DataType(), orderValues[c][rows[i]], orderValues[c][rows[j]])
+				if err != nil && sortErr == nil {
+					sortErr = err
+				}
+				if cmp != 0 {
+					return cmp < 0
+				}
+			}
+			return false
+		})
+	}
+	return partitions, sortErr
+}
+
+// compareOrderValues compares values like compareValues, but also supports timestamps.
+func compareOrderValues(dtype arrow.DataType, a, b interface{}) (int, error) {
+	if dtype.ID() != arrow.TIMESTAMP || a == nil || b == nil {
+		return compareValues(dtype, a, b)
+	}
+	switch x, y := a.(arrow.Timestamp), b.(arrow.Timestamp); {
+	case x < y:
+		return -1, nil
+	case x > y:
+		return 1, nil
+	}
+	return 0, nil
+}
+
+// Window is a rolling or expanding window over a column created with Rolling or Expanding.
+// Its methods return a MutationFunc that appends the aggregate of the window at each row
+// as a new float64 column. Null values are skipped, and the aggregate is null when the
+// window has fewer than minPeriods values that are not null.
+type Window struct {
+	m          *Mutator
+	column     string
+	size       int
+	expanding  bool
+	minPeriods int
+	opts       []Option
+}
+
+// Rolling creates a window over the current row and the window-1 rows before it.
+// WithPartitionBy, WithOrderBy and WithColumnName can be given as options.
+// The new column is named after the column, the aggregate and the size, e.g. "A_rolling_mean_3".
+func (m *Mutator) Rolling(column string, window, minPeriods int, opts ...Option) *Window {
+	return &Window{m: m, column: column, size: window, minPeriods: minPeriods, opts: opts}
+}
+
+// Expanding creates a window over the current row and all of the rows before it.
+// WithPartitionBy, WithOrderBy and WithColumnName can be given as options.
+// The new column is named after the column and the aggregate, e.g. "A_expanding_mean".
+func (m *Mutator) Expanding(column string, minPeriods int, opts ...Option) *Window {
+	return &Window{m: m, column: column, expanding: true, minPeriods: minPeriods, opts: opts}
+}
+
+// Sum of the values in the window.
+func (w *Window) Sum() MutationFunc {
+	return w.aggregate("sum", func(s *windowState) interface{} {
+		sum, _, _ := s.moments()
+		return sum
+	})
+}
+
+// Mean of the values in the window.
+func (w *Window) Mean() MutationFunc {
+	return w.aggregate("mean", func(s *windowState) interface{} {
+		_, mean, _ := s.moments()
+		return mean
+	})
+}
+
+// Min of the values in the window.
+func (w *Window) Min() MutationFunc {
+	return w.aggregate("min", func(s *windowState) interface{} { return s.mins[0].value })
+}
+
+// Max of the values in the window.
+func (w *Window) Max() MutationFunc {
+	return w.aggregate("max", func(s *windowState) interface{} { return s.maxs[0].value })
+}
+
+// Std is the sample standard deviation of the values in the window.
+// It is null when the window has fewer than two values.
+func (w *Window) Std() MutationFunc {
+	return w.aggregate("std", func(s *windowState) interface{} {
+		if s.n < 2 {
+			return nil
+		}
+		_, _, m2 := s.moments()
+		return math.Sqrt(math.Max(m2, 0) / float64(s.n-1))
+	})
+}
+
+func (w *Window) aggregate(aggName string, value func(s *windowState) interface{}) MutationFunc {
+	name := fmt.Sprintf("expanding_%s", aggName)
+	if !w.expanding {
+		name = fmt.Sprintf("rolling_%s_%d", aggName, w.size)
+	}
+
+	fn := windowFunc{
+		name: name,
+		resultType: func(dtype arrow.DataType) (arrow.DataType, error) {
+			if !isNumeric(dtype) {
+				return nil, errors.Errorf("%v is not numeric", dtype)
+			}
+			return arrow.PrimitiveTypes.Float64, nil
+		},
+		apply: func(dtype arrow.DataType, values []interface{}) ([]interface{}, error) {
+			return w.apply(values, value)
+		},
+	}
+
+	return func(df *DataFrame) (*DataFrame, error) {
+		switch {
+		case !w.expanding && w.size < 1:
+			return nil, errors.Errorf("bullseye/mutations: window must be positive: %d", w.size)
+		case w.minPeriods < 1:
+			return nil, errors.Errorf("bullseye/mutations: min periods must be positive: %d", w.minPeriods)
+		case !w.expanding && w.minPeriods > w.size:
+			return nil, errors.Errorf("bullseye/mutations: min periods %d is larger than the window %d", w.minPeriods, w.size)
+		}
+		return w.m.window(w.column, w.opts, fn)(df)
+	}
+}
+
+// apply computes the aggregate of the window at each of the values.
+func (w *Window) apply(values []interface{}, value func(s *windowState) interface{}) ([]interface{}, error) {
+	floats := make([]interface{}, len(values))
+	for i, v := range values {
+		if v == nil {
+			continue
+		}
+		x, err := castNumericValue(v, arrow.PrimitiveTypes.Float64)
+		if err != nil {
+			return nil, err
+		}
+		floats[i] = x
+	}
+
+	state := windowState{bounded: !w.expanding}
+	result := make([]interface{}, len(values))
+	for i, v := range floats {
+		if v != nil {
+			state.add(i, v.(float64))
+		}
+		if j := i - w.size; !w.expanding && j >= 0 && floats[j] != nil {
+			state.remove(j)
+		}
+		if state.n >= w.minPeriods {
+			result[i] = value(&state)
+		}
+	}
+	return result, nil
+}
+
+// windowState keeps the aggregates of the values in a window as they are added and removed.
+// Values must be removed in the order they were added. Removing values from running sums
+// accumulates rounding errors, so a bounded window keeps its values and computes the sum
+// and moments from them, while an expanding window, which never removes values, keeps
+// running sums.
+type windowState struct {
+	bounded bool
+	entries []windowEntry // the values in a bounded window
+
+	n        int
+	sum      float64
+	mean, m2 float64 // running moments of an expanding window using Welford's algorithm
+
+	// The candidates for the smallest and largest values, as monotonic queues of rows.
+	mins, maxs []windowEntry
+}
+
+type windowEntry struct {
+	row   int
+	value float64
+}
+
+func (s *windowState) add(row int, v float64) {
+	s.n++
+	if s.bounded {
+		s.entries = append(s.entries, windowEntry{row, v})
+	} else {
+		s.sum += v
+		delta := v - s.mean
+		s.mean += delta / float64(s.n)
+		s.m2 += delta * (v - s.mean)
+	}
+
+	for len(s.mins) > 0 && s.mins[len(s.mins)-1].value >= v {
+		s.mins = s.mins[:len(s.mins)-1]
+	}
+	s.mins = append(s.mins, windowEntry{row, v})
+	for len(s.maxs) > 0 && s.maxs[len(s.maxs)-1].value <= v {
+		s.maxs = s.maxs[:len(s.maxs)-1]
+	}
+	s.maxs = append(s.maxs, windowEntry{row, v})
+}
+
+func (s *windowState) remove(row int) {
+	s.n--
+	s.entries = s.entries[1:]
+	if len(s.mins) > 0 && s.mins[0].row == row {
+		s.mins = s.mins[1:]
+	}
+	if len(s.maxs) > 0 && s.maxs[0].row == row {
+		s.maxs = s.maxs[1:]
+	}
+}
+
+// moments returns the sum, the mean and the sum of squared differences from the mean.
+func (s *windowState) moments() (sum, mean, m2 float64) {
+	if !s.bounded {
+		return s.sum, s.mean, s.m2
+	}
+	for _, e := range s.entries {
+		sum += e.value
+	}
+	mean = sum / float64(s.n)
+	for _, e := range s.entries {
+		d := e.value - mean
+		m2 += d * d
+	}
+	return sum, mean, m2
+}
+
+// CumSum appends the cumulative sum of the column, named e.g. "A_cumsum".
+// Signed integers are summed as int64, unsigned integers as uint64 and floats as float64.
+// The result is null at rows that are null, which are otherwise skipped.
+// WithPartitionBy, WithOrderBy and WithColumnName can be given as options.
+func (m *Mutator) CumSum(column string, opts ...Option) MutationFunc {
+	return m.cumulative(column, "cumsum", AggSum.resultType, AggSum.newAggregator, opts)
+}
+
+// CumProd appends the cumulative product of the column, named e.g. "A_cumprod".
+// The product has the same type as CumSum and nulls are treated the same way.
+func (m *Mutator) CumProd(column string, opts ...Option) MutationFunc {
+	return m.cumulative(column, "cumprod", AggSum.resultType, func(dtype arrow.DataType) aggregator {
+		result, _ := AggSum.resultType(dtype)
+		return &productAggregator{dtype: result}
+	}, opts)
+}
+
+// CumMin appends the cumulative minimum of the column, named e.g. "A_cummin".
+// The minimum has the type of the column and nulls are treated the same way as CumSum.
+func (m *Mutator) CumMin(column string, opts ...Option) MutationFunc {
+	return m.cumulative(column, "cummin", AggMin.resultType, AggMin.newAggregator, opts)
+}
+
+// CumMax appends the cumulative maximum of the column, named e.g. "A_cummax".
+// The maximum has the type of the column and nulls are treated the same way as CumSum.
+func (m *Mutator) CumMax(column string, opts ...Option) MutationFunc {
+	return m.cumulative(column, "cummax", AggMax.resultType, AggMax.newAggregator, opts)
+}
+
+// cumulative appends the running aggregate of the column created by newAggregator.
+func (m *Mutator) cumulative(column, name string, resultType func(arrow.DataType) (arrow.DataType, error), newAggregator func(arrow.DataType) aggregator, opts []Option) MutationFunc {
+	return m.window(column, opts, windowFunc{
+		name:       name,
+		resultType: resultType,
+		apply: func(dtype arrow.DataType, values []interface{}) ([]interface{}, error) {
+			a := newAggregator(dtype)
+			result := make([]interface{}, len(values))
+			for i, v := range values {
+				if v == nil {
+					continue
+				}
+				if err := a.add(v); err != nil {
+					return nil, err
+				}
+				result[i] = a.value()
+			}
+			return result, nil
+		},
+	})
+}
+
+type productAggregator struct {
+	dtype   arrow.DataType
+	product interface{}
+}
+
+func (p *productAggregator) add(v interface{}) error {
+	if v == nil {
+		return nil
+	}
+	cv, err := castNumericValue(v, p.dtype)
+	if err != nil {
+		return err
+	}
+	if p.product == nil {
+		p.product = cv
+		return nil
+	}
+	switch t := cv.(type) {
+	case int64:
+		p.product = p.product.(int64) * t
+	case uint64:
+		p.product = p.product.(uint64) * t
+	case float64:
+		p.product = p.product.(float64) * t
+	}
+	return nil
+}
+
+func (p *productAggregator) value() interface{} { return p.product }
+
+// Shift appends the column shifted by n rows, named e.g. "A_shift_1". With a positive n
+// each row has the value of the row n rows before it, with a negative n the value
+// of the row n rows after it. Rows without such a row are null.
+// WithPartitionBy, WithOrderBy and WithColumnName can be given as options.
+func (m *Mutator) Shift(column string, n int, opts ...Option) MutationFunc {
+	return m.window(column, opts, windowFunc{
+		name: fmt.Sprintf("shift_%d", n),
+		resultType: func(dtype arrow.DataType) (arrow.DataType, error) {
+			return dtype, nil
+		},
+		apply: func(dtype arrow.DataType, values []interface{}) ([]interface{}, error) {
+			result := make([]interface{}, len(values))
+			for i := range values {
+				if j := i - n; j >= 0 && j < len(values) {
+					result[i] = values[j]
+				}
+			}
+			return result, nil
+		},
+	})
+}
+
+// Diff appends the difference between each row and the row n rows before it,
+// named e.g. "A_diff_1". A negative n uses the row n rows after it.
+// Integers are subtracted as int64 and floats as float64, and a difference
+// of integers that doesn't fit in an int64 is an error.
+// The difference is null when either of the values is null or there is no such row.
+// WithPartitionBy, WithOrderBy and WithColumnName can be given as options.
+func (m *Mutator) Diff(column string, n int, opts ...Option) MutationFunc {
+	diffType := func(dtype arrow.DataType) (arrow.DataType, error) {
+		switch {
+		case isSignedInteger(dtype), isUnsignedInteger(dtype):
+			return arrow.PrimitiveTypes.Int64, nil
+		case isFloat(dtype):
+			return arrow.PrimitiveTypes.Float64, nil
+		}
+		return nil, errors.Errorf("%v is not numeric", dtype)
+	}
+
+	return m.window(column, opts, windowFunc{
+		name:       fmt.Sprintf("diff_%d", n),
+		resultType: diffType,
+		apply: func(dtype arrow.DataType, values []interface{}) ([]interface{}, error) {
+			resultType, _ := diffType(dtype)
+			result := make([]interface{}, len(values))
+			for i, v := range values {
+				j := i - n
+				if j < 0 || j >= len(values) || v == nil || values[j] == nil {
+					continue
+				}
+				if isUnsignedInteger(dtype) {
+					d, ok := subUint64(uintValue(v), uintValue(values[j]))
+					if !ok {
+						return nil, errors.Errorf("the difference %v - %v overflows int64", v, values[j])
+					}
+					result[i] = d
+					continue
+				}
+				a, err := castNumericValue(v, resultType)
+				if err != nil {
+					return nil, err
+				}
+				b, err := castNumericValue(values[j], resultType)
+				if err != nil {
+					return nil, err
+				}
+				switch a := a.(type) {
+				case int64:
+					d := a - b.(int64)
+					if (b.(int64) > 0 && d > a) || (b.(int64) < 0 && d < a) {
+						return nil, errors.Errorf("the difference %v - %v overflows int64", v, values[j])
+					}
+					result[i] = d
+				case float64:
+					result[i] = a - b.(float64)
+				}
+			}
+			return result, nil
+		},
+	})
+}
+
+// subUint64 returns a - b as an int64, or false if the difference doesn't fit in an int64.
+func subUint64(a, b uint64) (int64, bool) {
+	if a >= b {
+		d := a - b
+		return int64(d), d <= math.MaxInt64
+	}
+	d := b - a
+	return -int64(d), d <= 1<<63
+}
+
+// uintValue returns an unsigned integer value as a uint64.
+func uintValue(v interface{}) uint64 {
+	switch t := v.(type) {
+	case uint8:
+		return uint64(t)
+	case uint16:
+		return uint64(t)
+	case uint32:
+		return uint64(t)
+	case uint64:
+		return t
+	}
+	panic(errors.Errorf("bullseye/mutations: %T is not an unsigned integer", v))
+}
@@ -0,0 +1,261 @@
+package dataframe
+
+import (
+	"math"
+	"testing"
+
+	"github.com/apache/arrow/go/arrow/memory"
+)
+
+func TestRolling(t *testing.T) {
+	pool := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer pool.AssertSize(t, 0)
+
+	df, err := NewDataFrameFromMem(pool, Dict{
+		"A": []interface{}{int64(1), int64(3), nil, int64(2), int64(6), int64(4)},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer df.Release()
+
+	m := NewMutator(pool)
+	resultDf, err := df.Apply(
+		m.Rolling("A", 3, 2).Sum(),
+		m.Rolling("A", 3, 1).Mean(),
+		m.Rolling("A", 2, 1).Min(),
+		m.Rolling("A", 2, 1).Max(),
+		m.Rolling("A", 3, 2).Std(),
+		m.Expanding("A", 1).Max(),
+		m.Expanding("A", 3).Mean(),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resultDf.Release()
+
+	got := resultDf.Display(-1)
+	want := `rec[0]["A"]: [1 3 (null) 2 6 4]
+rec[0]["A_rolling_sum_3"]: [(null) 4 4 5 8 12]
+rec[0]["A_rolling_mean_3"]: [1 2 2 2.5 4 4]
+rec[0]["A_rolling_min_2"]: [1 1 3 2 2 4]
+rec[0]["A_rolling_max_2"]: [1 3 3 2 6 6]
+rec[0]["A_rolling_std_3"]: [(null) 1.4142135623730951 1.4142135623730951 0.7071067811865476 2.8284271247461903 2]
+rec[0]["A_expanding_max"]: [1 3 3 3 6 6]
+rec[0]["A_expanding_mean"]: [(null) (null) (null) 2 3 3.2]
+`
+	if got != want {
+		t.Fatalf("\ngot=\n%v\nwant=\n%v", got, want)
+	}
+}
+
+func TestCumulative(t *testing.T) {
+	pool := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer pool.AssertSize(t, 0)
+
+	df, err := NewDataFrameFromMem(pool, Dict{
+		"A": []interface{}{int32(2), nil, int32(-1), int32(3)},
+		"B": []float64{1.5, 2, 0.5, 4},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer df.Release()
+
+	m := NewMutator(pool)
+	resultDf, err := df.Apply(
+		m.CumSum("A"),
+		m.CumProd("A"),
+		m.CumMin("A"),
+		m.CumMax("B", WithColumnName("peak")),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resultDf.Release()
+
+	got := resultDf.Display(-1)
+	want := `rec[0]["A"]: [2 (null) -1 3]
+rec[0]["B"]: [1.5 2 0.5 4]
+rec[0]["A_cumsum"]: [2 (null) 1 4]
+rec[0]["A_cumprod"]: [2 (null) -2 -6]
+rec[0]["A_cummin"]: [2 (null) -1 -1]
+rec[0]["peak"]: [1.5 2 2 4]
+`
+	if got != want {
+		t.Fatalf("\ngot=\n%v\nwant=\n%v", got, want)
+	}
+}
+
+func TestWindowSameFrame(t *testing.T) {
+	pool := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer pool.AssertSize(t, 0)
+
+	df, err := NewDataFrameFromMem(pool, Dict{
+		"A": []int64{1, 2, 3},
+		"B": []int64{4, 5, 6},
+		"C": []int64{7, 8, 9},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer df.Release()
+
+	m := NewMutator(pool)
+	derived, err := m.CumSum("A")(df)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer derived.Release()
+
+	// Both results are built from the same frame and must not share their new column.
+	sums, err := m.CumSum("B")(derived)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sums.Release()
+	maxes, err := m.CumMax("C")(derived)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer maxes.Release()
+
+	got := sums.Display(-1) + maxes.Display(-1)
+	want := `rec[0]["A"]: [1 2 3]
+rec[0]["B"]: [4 5 6]
+rec[0]["C"]: [7 8 9]
+rec[0]["A_cumsum"]: [1 3 6]
+rec[0]["B_cumsum"]: [4 9 15]
+rec[0]["A"]: [1 2 3]
+rec[0]["B"]: [4 5 6]
+rec[0]["C"]: [7 8 9]
+rec[0]["A_cumsum"]: [1 3 6]
+rec[0]["C_cummax"]: [7 8 9]
+`
+	if got != want {
+		t.Fatalf("\ngot=\n%v\nwant=\n%v", got, want)
+	}
+}
+
+func TestShiftDiff(t *testing.T) {
+	pool := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer pool.AssertSize(t, 0)
+
+	df, err := NewDataFrameFromMem(pool, Dict{
+		"A": []interface{}{uint8(5), uint8(3), nil, uint8(10)},
+		"B": []uint64{1 << 63, 0, math.MaxInt64, 0},
+		"S": []string{"a", "b", "c", "d"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer df.Release()
+
+	m := NewMutator(pool)
+	resultDf, err := df.Apply(
+		m.Shift("S", 1),
+		m.Shift("S", -2),
+		m.Diff("A", 1),
+		m.Diff("A", -3),
+		m.Diff("B", 1),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resultDf.Release()
+
+	got := resultDf.Display(-1)
+	want := `rec[0]["A"]: [5 3 (null) 10]
+rec[0]["B"]: [9223372036854775808 0 9223372036854775807 0]
+rec[0]["S"]: ["a" "b" "c" "d"]
+rec[0]["S_shift_1"]: [(null) "a" "b" "c"]
+rec[0]["S_shift_-2"]: ["c" "d" (null) (null)]
+rec[0]["A_diff_1"]: [(null) -2 (null) (null)]
+rec[0]["A_diff_-3"]: [-5 (null) (null) (null)]
+rec[0]["B_diff_1"]: [(null) -9223372036854775808 9223372036854775807 -9223372036854775807]
+`
+	if got != want {
+		t.Fatalf("\ngot=\n%v\nwant=\n%v", got, want)
+	}
+}
+
+func TestWindowPartitionOrder(t *testing.T) {
+	pool := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer pool.AssertSize(t, 0)
+
+	df, err := NewDataFrameFromMem(pool, Dict{
+		"G": []string{"x", "y", "x", "y", "x"},
+		"T": []int64{3, 2, 1, 1, 2},
+		"V": []float64{30, 200, 10, 100, 20},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer df.Release()
+
+	m := NewMutator(pool)
+	opts := []Option{WithPartitionBy("G"), WithOrderBy("T")}
+	resultDf, err := df.Apply(
+		m.CumSum("V", opts...),
+		m.Shift("V", 1, opts...),
+		m.Rolling("V", 2, 2, opts...).Mean(),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resultDf.Release()
+
+	got := resultDf.Display(-1)
+	want := `rec[0]["G"]: ["x" "y" "x" "y" "x"]
+rec[0]["T"]: [3 2 1 1 2]
+rec[0]["V"]: [30 200 10 100 20]
+rec[0]["V_cumsum"]: [60 300 10 100 30]
+rec[0]["V_shift_1"]: [20 100 (null) (null) 10]
+rec[0]["V_rolling_mean_2"]: [25 150 (null) (null) 15]
+`
+	if got != want {
+		t.Fatalf("\ngot=\n%v\nwant=\n%v", got, want)
+	}
+}
+
+func TestWindowErrors(t *testing.T) {
+	pool := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer pool.AssertSize(t, 0)
+
+	df, err := NewDataFrameFromMem(pool, Dict{
+		"A": []int64{1, 2},
+		"L": []int64{math.MinInt64, 1},
+		"U": []uint64{0, math.MaxUint64},
+		"S": []string{"a", "b"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer df.Release()
+
+	m := NewMutator(pool)
+	tests := []struct {
+		name string
+		fn   MutationFunc
+		want string
+	}{
+		{"missing column", m.CumSum("X"), "bullseye/mutations: column X does not exist"},
+		{"missing partition", m.CumSum("A", WithPartitionBy("X")), "bullseye/mutations: column X does not exist"},
+		{"not numeric", m.Rolling("S", 2, 1).Mean(), "bullseye/mutations: cannot compute rolling_mean_2 of column S: utf8 is not numeric"},
+		{"diff overflow", m.Diff("L", 1), "bullseye/mutations: cannot compute diff_1 of column L: the difference 1 - -9223372036854775808 overflows int64"},
+		{"unsigned diff overflow", m.Diff("U", -1), "bullseye/mutations: cannot compute diff_-1 of column U: the difference 0 - 18446744073709551615 overflows int64"},
+		{"window", m.Rolling("A", 0, 1).Sum(), "bullseye/mutations: window must be positive: 0"},
+		{"min periods", m.Rolling("A", 2, 3).Sum(), "bullseye/mutations: min periods 3 is larger than the window 2"},
+		{"expanding min periods", m.Expanding("A", 0).Sum(), "bullseye/mutations: min periods must be positive: 0"},
+		{"existing column", m.Shift("A", 1, WithColumnName("S")), "bullseye/mutations: column S already exists"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := tc.fn(df)
+			if err == nil || err.Error() != tc.want {
+				t.Fatalf("got=%v, want=%v", err, tc.want)
+			}
+		})
+	}
+}
@@ -4,5 +4,7 @@ go 1.12
 
 require (
 	github.com/apache/arrow/go/arrow v0.0.0-20190615061817-720be32a0bb5
-	github.com/pkg/errors v0.8.1
+	github.com/apache/thrift v0.13.1-0.20201008052519-daf620915714
+	github.com/pkg/errors v0.9.1
+	github.com/xitongsys/parquet-go v1.5.5-0.20201110004701-b09c49d6d457
 )
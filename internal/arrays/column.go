@@ -0,0 +1,19 @@
+package arrays
+
+import (
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+)
+
+// NewColumn creates a column of type dtype from the values of the builder, named after col.
+// The column must be released.
+func NewColumn(col *array.Column, dtype arrow.DataType, builder array.Builder) *array.Column {
+	arr := builder.NewArray()
+	defer arr.Release()
+
+	field := col.Field()
+	field.Type = dtype
+	chunked := array.NewChunked(dtype, []array.Interface{arr})
+	defer chunked.Release()
+	return array.NewColumn(field, chunked)
+}
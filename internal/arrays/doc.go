@@ -0,0 +1,5 @@
+/*
+Package arrays provides access to the values of arrow arrays and builds columns from them.
+
+*/
+package arrays
@@ -0,0 +1,71 @@
+package arrays
+
+import (
+	"fmt"
+
+	"github.com/apache/arrow/go/arrow/array"
+)
+
+// Value returns the i-th value of arr as the Go type used by the array, or nil if it is null.
+// The values of List and Struct arrays are []interface{} holding the values of the list, or of
+// the fields of the struct. The children of List and Struct arrays are not sliced along with
+// their parents, so their values are found using the offset of arr.
+func Value(arr array.Interface, i int) interface{} {
+	if arr.IsNull(i) {
+		return nil
+	}
+
+	switch a := arr.(type) {
+	case *array.Boolean:
+		return a.Value(i)
+	case *array.Int8:
+		return a.Value(i)
+	case *array.Int16:
+		return a.Value(i)
+	case *array.Int32:
+		return a.Value(i)
+	case *array.Int64:
+		return a.Value(i)
+	case *array.Uint8:
+		return a.Value(i)
+	case *array.Uint16:
+		return a.Value(i)
+	case *array.Uint32:
+		return a.Value(i)
+	case *array.Uint64:
+		return a.Value(i)
+	case *array.Float32:
+		return a.Value(i)
+	case *array.Float64:
+		return a.Value(i)
+	case *array.String:
+		return a.Value(i)
+	case *array.Date32:
+		return a.Value(i)
+	case *array.Date64:
+		return a.Value(i)
+	case *array.Timestamp:
+		return a.Value(i)
+	case *array.Time32:
+		return a.Value(i)
+	case *array.Time64:
+		return a.Value(i)
+	case *array.List:
+		j := i + a.Data().Offset()
+		offsets := a.Offsets()
+		values := make([]interface{}, 0, offsets[j+1]-offsets[j])
+		for k := offsets[j]; k < offsets[j+1]; k++ {
+			values = append(values, Value(a.ListValues(), int(k)))
+		}
+		return values
+	case *array.Struct:
+		j := i + a.Data().Offset()
+		values := make([]interface{}, a.NumField())
+		for k := range values {
+			values[k] = Value(a.Field(k), j)
+		}
+		return values
+	default:
+		panic(fmt.Errorf("bullseye/arrays: unhandled array type %T", arr))
+	}
+}
@@ -0,0 +1,52 @@
+package constructors
+
+import (
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/memory"
+	"github.com/pkg/errors"
+)
+
+// NewBuilder creates a new array.Builder for the provided data type.
+// Unlike the builders created by array.NewRecordBuilder, this supports
+// the Date32, Date64 and Timestamp types.
+func NewBuilder(mem memory.Allocator, dtype arrow.DataType) (array.Builder, error) {
+	switch t := dtype.(type) {
+	case *arrow.BooleanType:
+		return array.NewBooleanBuilder(mem), nil
+	case *arrow.Int8Type:
+		return array.NewInt8Builder(mem), nil
+	case *arrow.Int16Type:
+		return array.NewInt16Builder(mem), nil
+	case *arrow.Int32Type:
+		return array.NewInt32Builder(mem), nil
+	case *arrow.Int64Type:
+		return array.NewInt64Builder(mem), nil
+	case *arrow.Uint8Type:
+		return array.NewUint8Builder(mem), nil
+	case *arrow.Uint16Type:
+		return array.NewUint16Builder(mem), nil
+	case *arrow.Uint32Type:
+		return array.NewUint32Builder(mem), nil
+	case *arrow.Uint64Type:
+		return array.NewUint64Builder(mem), nil
+	case *arrow.Float32Type:
+		return array.NewFloat32Builder(mem), nil
+	case *arrow.Float64Type:
+		return array.NewFloat64Builder(mem), nil
+	case *arrow.StringType:
+		return array.NewStringBuilder(mem), nil
+	case *arrow.Date32Type:
+		return array.NewDate32Builder(mem), nil
+	case *arrow.Date64Type:
+		return array.NewDate64Builder(mem), nil
+	case *arrow.TimestampType:
+		return array.NewTimestampBuilder(mem, t), nil
+	case *arrow.Time32Type:
+		return array.NewTime32Builder(mem, t), nil
+	case *arrow.Time64Type:
+		return array.NewTime64Builder(mem, t), nil
+	default:
+		return nil, errors.Errorf("dataframe/builder: unsupported builder for %T", dtype)
+	}
+}
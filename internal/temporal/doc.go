@@ -0,0 +1,5 @@
+/*
+Package temporal provides the units and arithmetic of arrow dates and times.
+
+*/
+package temporal
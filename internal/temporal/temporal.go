@@ -0,0 +1,89 @@
+package temporal
+
+import (
+	"math"
+	"time"
+
+	"github.com/apache/arrow/go/arrow"
+)
+
+const (
+	// SecondsPerDay is the number of seconds in a Date32 day.
+	SecondsPerDay = 24 * 60 * 60
+	// MillisecondsPerDay is the number of milliseconds in a Date64 day.
+	MillisecondsPerDay = SecondsPerDay * 1000
+)
+
+// UnitDuration returns the duration of one unit.
+func UnitDuration(unit arrow.TimeUnit) time.Duration {
+	switch unit {
+	case arrow.Second:
+		return time.Second
+	case arrow.Millisecond:
+		return time.Millisecond
+	case arrow.Microsecond:
+		return time.Microsecond
+	default:
+		return time.Nanosecond
+	}
+}
+
+// FloorDiv divides rounding toward negative infinity so dates before 1970 work.
+func FloorDiv(a, b int64) int64 {
+	q := a / b
+	if a%b != 0 && (a < 0) != (b < 0) {
+		q--
+	}
+	return q
+}
+
+// UnitsPerSecond returns the number of units in a second.
+func UnitsPerSecond(unit arrow.TimeUnit) int64 {
+	return int64(time.Second / UnitDuration(unit))
+}
+
+// Time returns the time v units after the Unix epoch, in UTC. Unlike time.Unix(0, ns),
+// it works for every int64 value of every unit.
+func Time(v int64, unit arrow.TimeUnit) time.Time {
+	per := UnitsPerSecond(unit)
+	sec := FloorDiv(v, per)
+	return time.Unix(sec, (v-sec*per)*int64(UnitDuration(unit))).UTC()
+}
+
+// FromTime returns the number of units from the Unix epoch to t, rounded down to a whole unit.
+// It returns false when the number doesn't fit in an int64.
+func FromTime(t time.Time, unit arrow.TimeUnit) (int64, bool) {
+	per := UnitsPerSecond(unit)
+	sec := t.Unix()
+	if sec > math.MaxInt64/per || sec < math.MinInt64/per {
+		return 0, false
+	}
+	v, frac := sec*per, int64(t.Nanosecond())/int64(UnitDuration(unit))
+	if v > math.MaxInt64-frac {
+		return 0, false
+	}
+	return v + frac, true
+}
+
+// Days returns the number of days from the Unix epoch to the day of t in UTC.
+func Days(t time.Time) int64 {
+	return FloorDiv(t.Unix(), SecondsPerDay)
+}
+
+// Date32 returns the Date32 value of the day of t in UTC. It returns false when the day doesn't fit.
+func Date32(t time.Time) (arrow.Date32, bool) {
+	days := Days(t)
+	if days < math.MinInt32 || days > math.MaxInt32 {
+		return 0, false
+	}
+	return arrow.Date32(days), true
+}
+
+// Date64 returns the Date64 value of the day of t in UTC. It returns false when the day doesn't fit.
+func Date64(t time.Time) (arrow.Date64, bool) {
+	days := Days(t)
+	if days < math.MinInt64/MillisecondsPerDay || days > math.MaxInt64/MillisecondsPerDay {
+		return 0, false
+	}
+	return arrow.Date64(days * MillisecondsPerDay), true
+}
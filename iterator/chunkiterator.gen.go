@@ -64,6 +64,12 @@ func NewInt64ChunkIterator(col *array.Column) *Int64ChunkIterator {
 // Chunk will return the current chunk that the iterator is on.
 func (cr *Int64ChunkIterator) Chunk() *array.Int64 { return cr.currentChunk }
 
+// Len returns the number of values in the column, nulls included.
+func (cr *Int64ChunkIterator) Len() int64 { return cr.length }
+
+// NullN returns the number of null values in the column.
+func (cr *Int64ChunkIterator) NullN() int64 { return cr.nulls }
+
 // ChunkValues returns the underlying []int64 chunk values.
 // Keep in mind the []int64 type might not be able
 // to account for nil values. You must check for those explicitly via the chunk.
@@ -165,6 +171,12 @@ func NewUint64ChunkIterator(col *array.Column) *Uint64ChunkIterator {
 // Chunk will return the current chunk that the iterator is on.
 func (cr *Uint64ChunkIterator) Chunk() *array.Uint64 { return cr.currentChunk }
 
+// Len returns the number of values in the column, nulls included.
+func (cr *Uint64ChunkIterator) Len() int64 { return cr.length }
+
+// NullN returns the number of null values in the column.
+func (cr *Uint64ChunkIterator) NullN() int64 { return cr.nulls }
+
 // ChunkValues returns the underlying []uint64 chunk values.
 // Keep in mind the []uint64 type might not be able
 // to account for nil values. You must check for those explicitly via the chunk.
@@ -266,6 +278,12 @@ func NewFloat64ChunkIterator(col *array.Column) *Float64ChunkIterator {
 // Chunk will return the current chunk that the iterator is on.
 func (cr *Float64ChunkIterator) Chunk() *array.Float64 { return cr.currentChunk }
 
+// Len returns the number of values in the column, nulls included.
+func (cr *Float64ChunkIterator) Len() int64 { return cr.length }
+
+// NullN returns the number of null values in the column.
+func (cr *Float64ChunkIterator) NullN() int64 { return cr.nulls }
+
 // ChunkValues returns the underlying []float64 chunk values.
 // Keep in mind the []float64 type might not be able
 // to account for nil values. You must check for those explicitly via the chunk.
@@ -367,6 +385,12 @@ func NewInt32ChunkIterator(col *array.Column) *Int32ChunkIterator {
 // Chunk will return the current chunk that the iterator is on.
 func (cr *Int32ChunkIterator) Chunk() *array.Int32 { return cr.currentChunk }
 
+// Len returns the number of values in the column, nulls included.
+func (cr *Int32ChunkIterator) Len() int64 { return cr.length }
+
+// NullN returns the number of null values in the column.
+func (cr *Int32ChunkIterator) NullN() int64 { return cr.nulls }
+
 // ChunkValues returns the underlying []int32 chunk values.
 // Keep in mind the []int32 type might not be able
 // to account for nil values. You must check for those explicitly via the chunk.
@@ -468,6 +492,12 @@ func NewUint32ChunkIterator(col *array.Column) *Uint32ChunkIterator {
 // Chunk will return the current chunk that the iterator is on.
 func (cr *Uint32ChunkIterator) Chunk() *array.Uint32 { return cr.currentChunk }
 
+// Len returns the number of values in the column, nulls included.
+func (cr *Uint32ChunkIterator) Len() int64 { return cr.length }
+
+// NullN returns the number of null values in the column.
+func (cr *Uint32ChunkIterator) NullN() int64 { return cr.nulls }
+
 // ChunkValues returns the underlying []uint32 chunk values.
 // Keep in mind the []uint32 type might not be able
 // to account for nil values. You must check for those explicitly via the chunk.
@@ -569,6 +599,12 @@ func NewFloat32ChunkIterator(col *array.Column) *Float32ChunkIterator {
 // Chunk will return the current chunk that the iterator is on.
 func (cr *Float32ChunkIterator) Chunk() *array.Float32 { return cr.currentChunk }
 
+// Len returns the number of values in the column, nulls included.
+func (cr *Float32ChunkIterator) Len() int64 { return cr.length }
+
+// NullN returns the number of null values in the column.
+func (cr *Float32ChunkIterator) NullN() int64 { return cr.nulls }
+
 // ChunkValues returns the underlying []float32 chunk values.
 // Keep in mind the []float32 type might not be able
 // to account for nil values. You must check for those explicitly via the chunk.
@@ -670,6 +706,12 @@ func NewInt16ChunkIterator(col *array.Column) *Int16ChunkIterator {
 // Chunk will return the current chunk that the iterator is on.
 func (cr *Int16ChunkIterator) Chunk() *array.Int16 { return cr.currentChunk }
 
+// Len returns the number of values in the column, nulls included.
+func (cr *Int16ChunkIterator) Len() int64 { return cr.length }
+
+// NullN returns the number of null values in the column.
+func (cr *Int16ChunkIterator) NullN() int64 { return cr.nulls }
+
 // ChunkValues returns the underlying []int16 chunk values.
 // Keep in mind the []int16 type might not be able
 // to account for nil values. You must check for those explicitly via the chunk.
@@ -771,6 +813,12 @@ func NewUint16ChunkIterator(col *array.Column) *Uint16ChunkIterator {
 // Chunk will return the current chunk that the iterator is on.
 func (cr *Uint16ChunkIterator) Chunk() *array.Uint16 { return cr.currentChunk }
 
+// Len returns the number of values in the column, nulls included.
+func (cr *Uint16ChunkIterator) Len() int64 { return cr.length }
+
+// NullN returns the number of null values in the column.
+func (cr *Uint16ChunkIterator) NullN() int64 { return cr.nulls }
+
 // ChunkValues returns the underlying []uint16 chunk values.
 // Keep in mind the []uint16 type might not be able
 // to account for nil values. You must check for those explicitly via the chunk.
@@ -872,6 +920,12 @@ func NewInt8ChunkIterator(col *array.Column) *Int8ChunkIterator {
 // Chunk will return the current chunk that the iterator is on.
 func (cr *Int8ChunkIterator) Chunk() *array.Int8 { return cr.currentChunk }
 
+// Len returns the number of values in the column, nulls included.
+func (cr *Int8ChunkIterator) Len() int64 { return cr.length }
+
+// NullN returns the number of null values in the column.
+func (cr *Int8ChunkIterator) NullN() int64 { return cr.nulls }
+
 // ChunkValues returns the underlying []int8 chunk values.
 // Keep in mind the []int8 type might not be able
 // to account for nil values. You must check for those explicitly via the chunk.
@@ -973,6 +1027,12 @@ func NewUint8ChunkIterator(col *array.Column) *Uint8ChunkIterator {
 // Chunk will return the current chunk that the iterator is on.
 func (cr *Uint8ChunkIterator) Chunk() *array.Uint8 { return cr.currentChunk }
 
+// Len returns the number of values in the column, nulls included.
+func (cr *Uint8ChunkIterator) Len() int64 { return cr.length }
+
+// NullN returns the number of null values in the column.
+func (cr *Uint8ChunkIterator) NullN() int64 { return cr.nulls }
+
 // ChunkValues returns the underlying []uint8 chunk values.
 // Keep in mind the []uint8 type might not be able
 // to account for nil values. You must check for those explicitly via the chunk.
@@ -1074,6 +1134,12 @@ func NewTimestampChunkIterator(col *array.Column) *TimestampChunkIterator {
 // Chunk will return the current chunk that the iterator is on.
 func (cr *TimestampChunkIterator) Chunk() *array.Timestamp { return cr.currentChunk }
 
+// Len returns the number of values in the column, nulls included.
+func (cr *TimestampChunkIterator) Len() int64 { return cr.length }
+
+// NullN returns the number of null values in the column.
+func (cr *TimestampChunkIterator) NullN() int64 { return cr.nulls }
+
 // ChunkValues returns the underlying []arrow.Timestamp chunk values.
 // Keep in mind the []arrow.Timestamp type might not be able
 // to account for nil values. You must check for those explicitly via the chunk.
@@ -1175,6 +1241,12 @@ func NewTime32ChunkIterator(col *array.Column) *Time32ChunkIterator {
 // Chunk will return the current chunk that the iterator is on.
 func (cr *Time32ChunkIterator) Chunk() *array.Time32 { return cr.currentChunk }
 
+// Len returns the number of values in the column, nulls included.
+func (cr *Time32ChunkIterator) Len() int64 { return cr.length }
+
+// NullN returns the number of null values in the column.
+func (cr *Time32ChunkIterator) NullN() int64 { return cr.nulls }
+
 // ChunkValues returns the underlying []arrow.Time32 chunk values.
 // Keep in mind the []arrow.Time32 type might not be able
 // to account for nil values. You must check for those explicitly via the chunk.
@@ -1276,6 +1348,12 @@ func NewTime64ChunkIterator(col *array.Column) *Time64ChunkIterator {
 // Chunk will return the current chunk that the iterator is on.
 func (cr *Time64ChunkIterator) Chunk() *array.Time64 { return cr.currentChunk }
 
+// Len returns the number of values in the column, nulls included.
+func (cr *Time64ChunkIterator) Len() int64 { return cr.length }
+
+// NullN returns the number of null values in the column.
+func (cr *Time64ChunkIterator) NullN() int64 { return cr.nulls }
+
 // ChunkValues returns the underlying []arrow.Time64 chunk values.
 // Keep in mind the []arrow.Time64 type might not be able
 // to account for nil values. You must check for those explicitly via the chunk.
@@ -1377,6 +1455,12 @@ func NewDate32ChunkIterator(col *array.Column) *Date32ChunkIterator {
 // Chunk will return the current chunk that the iterator is on.
 func (cr *Date32ChunkIterator) Chunk() *array.Date32 { return cr.currentChunk }
 
+// Len returns the number of values in the column, nulls included.
+func (cr *Date32ChunkIterator) Len() int64 { return cr.length }
+
+// NullN returns the number of null values in the column.
+func (cr *Date32ChunkIterator) NullN() int64 { return cr.nulls }
+
 // ChunkValues returns the underlying []arrow.Date32 chunk values.
 // Keep in mind the []arrow.Date32 type might not be able
 // to account for nil values. You must check for those explicitly via the chunk.
@@ -1478,6 +1562,12 @@ func NewDate64ChunkIterator(col *array.Column) *Date64ChunkIterator {
 // Chunk will return the current chunk that the iterator is on.
 func (cr *Date64ChunkIterator) Chunk() *array.Date64 { return cr.currentChunk }
 
+// Len returns the number of values in the column, nulls included.
+func (cr *Date64ChunkIterator) Len() int64 { return cr.length }
+
+// NullN returns the number of null values in the column.
+func (cr *Date64ChunkIterator) NullN() int64 { return cr.nulls }
+
 // ChunkValues returns the underlying []arrow.Date64 chunk values.
 // Keep in mind the []arrow.Date64 type might not be able
 // to account for nil values. You must check for those explicitly via the chunk.
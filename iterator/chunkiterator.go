@@ -63,6 +63,12 @@ func NewChunkIterator(col *array.Column) *ChunkIterator {
 // Chunk will return the current chunk that the iterator is on.
 func (cr *ChunkIterator) Chunk() array.Interface { return cr.currentChunk }
 
+// Len returns the number of values in the column, nulls included.
+func (cr *ChunkIterator) Len() int64 { return cr.length }
+
+// NullN returns the number of null values in the column.
+func (cr *ChunkIterator) NullN() int64 { return cr.nulls }
+
 // Next moves the iterator to the next chunk. This will return false
 // when there are no more chunks.
 func (cr *ChunkIterator) Next() bool {
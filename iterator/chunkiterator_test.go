@@ -63,6 +63,13 @@ func TestChunkIterator(t *testing.T) {
 	cr := iterator.NewChunkIterator(column)
 	defer cr.Release()
 
+	if got, want := cr.Len(), int64(30); got != want {
+		t.Fatalf("got=%d, want=%d", got, want)
+	}
+	if got, want := cr.NullN(), int64(1); got != want {
+		t.Fatalf("got=%d, want=%d", got, want)
+	}
+
 	n := 0
 	for cr.Next() {
 		values := cr.Chunk().(*array.Int32).Int32Values()
@@ -96,6 +103,13 @@ func TestInt32ChunkIterator(t *testing.T) {
 	cr := iterator.NewInt32ChunkIterator(column)
 	defer cr.Release()
 
+	if got, want := cr.Len(), int64(30); got != want {
+		t.Fatalf("got=%d, want=%d", got, want)
+	}
+	if got, want := cr.NullN(), int64(1); got != want {
+		t.Fatalf("got=%d, want=%d", got, want)
+	}
+
 	n := 0
 	for cr.Next() {
 		values := cr.ChunkValues()
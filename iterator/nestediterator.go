@@ -0,0 +1,118 @@
+package iterator
+
+import (
+	"sync/atomic"
+
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/go-bullseye/bullseye/internal/arrays"
+	"github.com/go-bullseye/bullseye/internal/debug"
+)
+
+// NestedValueIterator is an iterator for reading an Arrow Column
+// value by value for List and Struct values. The values are
+// []interface{} holding the values of the list, or of the fields
+// of the struct, in the same Go types as the other ValueIterators.
+type NestedValueIterator struct {
+	refCount      int64
+	chunkIterator *ChunkIterator
+
+	// Things we need to maintain for the iterator
+	index int             // current value index
+	ref   array.Interface // the chunk reference
+	done  bool            // there are no more elements for this iterator
+}
+
+// NewNestedValueIterator creates a new NestedValueIterator for reading an Arrow Column.
+func NewNestedValueIterator(col *array.Column) *NestedValueIterator {
+	// We need a ChunkIterator to read the chunks
+	chunkIterator := NewChunkIterator(col)
+
+	return &NestedValueIterator{
+		refCount:      1,
+		chunkIterator: chunkIterator,
+
+		index: 0,
+		ref:   nil,
+	}
+}
+
+// Value will return the current value that the iterator is on and boolean value indicating if the value is actually null.
+func (vr *NestedValueIterator) Value() ([]interface{}, bool) {
+	if vr.ref.IsNull(vr.index) {
+		return nil, true
+	}
+	return arrays.Value(vr.ref, vr.index).([]interface{}), false
+}
+
+// ValueInterface returns the value as an interface{}.
+func (vr *NestedValueIterator) ValueInterface() interface{} {
+	if vr.ref.IsNull(vr.index) {
+		return nil
+	}
+	return arrays.Value(vr.ref, vr.index)
+}
+
+// Next moves the iterator to the next value. This will return false
+// when there are no more values.
+func (vr *NestedValueIterator) Next() bool {
+	if vr.done {
+		return false
+	}
+
+	// Move the index up
+	vr.index++
+
+	// Keep moving the chunk up until we get one with data
+	for vr.ref == nil || vr.index >= vr.ref.Len() {
+		if !vr.nextChunk() {
+			// There were no more chunks with data in them
+			vr.done = true
+			return false
+		}
+	}
+
+	return true
+}
+
+func (vr *NestedValueIterator) nextChunk() bool {
+	// Advance the chunk until we get one with data in it or we are done
+	if !vr.chunkIterator.Next() {
+		// No more chunks
+		return false
+	}
+
+	// There was another chunk.
+	// We maintain the ref because the ref is going to allow us to retain the memory.
+	ref := vr.chunkIterator.Chunk()
+	ref.Retain()
+
+	if vr.ref != nil {
+		vr.ref.Release()
+	}
+
+	vr.ref = ref
+	vr.index = 0
+	return true
+}
+
+// Retain keeps a reference to the NestedValueIterator
+func (vr *NestedValueIterator) Retain() {
+	atomic.AddInt64(&vr.refCount, 1)
+}
+
+// Release removes a reference to the NestedValueIterator
+func (vr *NestedValueIterator) Release() {
+	debug.Assert(atomic.LoadInt64(&vr.refCount) > 0, "too many releases")
+
+	if atomic.AddInt64(&vr.refCount, -1) == 0 {
+		if vr.chunkIterator != nil {
+			vr.chunkIterator.Release()
+			vr.chunkIterator = nil
+		}
+
+		if vr.ref != nil {
+			vr.ref.Release()
+			vr.ref = nil
+		}
+	}
+}
@@ -50,10 +50,18 @@ func NewValueIterator(column *array.Column) ValueIterator {
 		return NewDate32ValueIterator(column)
 	case *arrow.Date64Type:
 		return NewDate64ValueIterator(column)
+	case *arrow.TimestampType:
+		return NewTimestampValueIterator(column)
+	case *arrow.Time32Type:
+		return NewTime32ValueIterator(column)
+	case *arrow.Time64Type:
+		return NewTime64ValueIterator(column)
 	case *arrow.BooleanType:
 		return NewBooleanValueIterator(column)
 	case *arrow.StringType:
 		return NewStringValueIterator(column)
+	case *arrow.ListType, *arrow.StructType:
+		return NewNestedValueIterator(column)
 
 	default:
 		panic(fmt.Errorf("dataframe/valueiterator: unhandled field type %T", field.Type))
@@ -0,0 +1,10 @@
+/*
+Package strops provides vectorized operations on string columns.
+
+Each operation reads a utf8 Column value by value with a StringValueIterator, which slices
+the values out of the chunk's offsets without copying them, and builds a new single chunk
+Column with the same name. A null value produces a null result. The new Column must be
+released and can be added to a DataFrame with AppendColumn.
+
+*/
+package strops
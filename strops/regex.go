@@ -0,0 +1,93 @@
+package strops
+
+import (
+	"regexp"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/memory"
+	"github.com/pkg/errors"
+)
+
+// RegexMatch returns a Boolean column reporting whether each value contains a match of the pattern.
+// The pattern uses the syntax of the regexp package and is compiled once before reading the column.
+func RegexMatch(mem memory.Allocator, col *array.Column, pattern string) (*array.Column, error) {
+	re, err := compile(pattern)
+	if err != nil {
+		return nil, err
+	}
+	return mapBooleans(mem, col, re.MatchString)
+}
+
+// RegexExtract returns a string column for each named capture group of the pattern, in the
+// order of the groups and named after them, holding the text the group matched in the leftmost
+// match of each value. A value is null when the value is null, the pattern doesn't match it,
+// or the group is not part of the match. It is an error for the pattern to have no named groups,
+// or two groups with the same name.
+// The pattern uses the syntax of the regexp package and is compiled once before reading the column.
+// The columns must be released.
+func RegexExtract(mem memory.Allocator, col *array.Column, pattern string) ([]*array.Column, error) {
+	re, err := compile(pattern)
+	if err != nil {
+		return nil, err
+	}
+
+	var groups []int
+	seen := make(map[string]struct{})
+	for i, name := range re.SubexpNames() {
+		if name == "" {
+			continue
+		}
+		if _, ok := seen[name]; ok {
+			return nil, errors.Errorf("bullseye/strops: pattern %q has more than one capture group named %s", pattern, name)
+		}
+		seen[name] = struct{}{}
+		groups = append(groups, i)
+	}
+	if len(groups) == 0 {
+		return nil, errors.Errorf("bullseye/strops: pattern %q has no named capture groups", pattern)
+	}
+
+	builders := make([]*array.StringBuilder, len(groups))
+	for i := range builders {
+		builders[i] = array.NewStringBuilder(mem)
+		defer builders[i].Release()
+	}
+
+	err = each(col, func(s string, valid bool) {
+		var match []int
+		if valid {
+			match = re.FindStringSubmatchIndex(s)
+		}
+		for i, group := range groups {
+			if match == nil || match[2*group] < 0 {
+				builders[i].AppendNull()
+				continue
+			}
+			builders[i].Append(s[match[2*group]:match[2*group+1]])
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	names := re.SubexpNames()
+	cols := make([]*array.Column, len(groups))
+	for i, group := range groups {
+		arr := builders[i].NewArray()
+		field := arrow.Field{Name: names[group], Type: arrow.BinaryTypes.String, Nullable: true}
+		chunked := array.NewChunked(field.Type, []array.Interface{arr})
+		cols[i] = array.NewColumn(field, chunked)
+		chunked.Release()
+		arr.Release()
+	}
+	return cols, nil
+}
+
+func compile(pattern string) (*regexp.Regexp, error) {
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, errors.Wrapf(err, "bullseye/strops: invalid pattern %q", pattern)
+	}
+	return re, nil
+}
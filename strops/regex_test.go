@@ -0,0 +1,83 @@
+package strops
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/apache/arrow/go/arrow/memory"
+)
+
+func TestRegexMatch(t *testing.T) {
+	pool := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer pool.AssertSize(t, 0)
+
+	col := newStringColumn(pool, "line", str("GET /index 200"), nil, str("POST /login 500"), str("garbage"))
+	defer col.Release()
+
+	result, err := RegexMatch(pool, col, `\s[45]\d\d$`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer result.Release()
+
+	want := `[false (null) true false]`
+	if got := result.Data().Chunk(0).(fmt.Stringer).String(); got != want {
+		t.Fatalf("\ngot=\n%v\nwant=\n%v", got, want)
+	}
+}
+
+func TestRegexExtract(t *testing.T) {
+	pool := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer pool.AssertSize(t, 0)
+
+	col := newStringColumn(pool, "line", str("GET /index 200"), nil, str("POST /login"), str("garbage"))
+	defer col.Release()
+
+	cols, err := RegexExtract(pool, col, `^(?P<method>[A-Z]+) (?P<path>\S+)(?: (\d+))?(?: (?P<status>\d+))?$`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		for _, c := range cols {
+			c.Release()
+		}
+	}()
+
+	want := []struct {
+		name   string
+		values string
+	}{
+		{"method", `["GET" (null) "POST" (null)]`},
+		{"path", `["/index" (null) "/login" (null)]`},
+		{"status", `[(null) (null) (null) (null)]`},
+	}
+	if len(cols) != len(want) {
+		t.Fatalf("got %d columns, want %d", len(cols), len(want))
+	}
+	for i, w := range want {
+		if cols[i].Name() != w.name {
+			t.Fatalf("column %d: got name=%s, want=%s", i, cols[i].Name(), w.name)
+		}
+		if got := cols[i].Data().Chunk(0).(fmt.Stringer).String(); got != w.values {
+			t.Fatalf("column %s:\ngot=\n%v\nwant=\n%v", w.name, got, w.values)
+		}
+	}
+}
+
+func TestRegexErrors(t *testing.T) {
+	pool := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer pool.AssertSize(t, 0)
+
+	col := newStringColumn(pool, "line", str("a"), str("b"))
+	defer col.Release()
+
+	if _, err := RegexMatch(pool, col, `(`); err == nil || err.Error() != "bullseye/strops: invalid pattern \"(\": error parsing regexp: missing closing ): `(`" {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := RegexExtract(pool, col, `(\w)`); err == nil || err.Error() != "bullseye/strops: pattern \"(\\\\w)\" has no named capture groups" {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := RegexExtract(pool, col, `(?P<a>x)|(?P<a>y)`); err == nil || err.Error() != "bullseye/strops: pattern \"(?P<a>x)|(?P<a>y)\" has more than one capture group named a" {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
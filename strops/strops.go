@@ -0,0 +1,289 @@
+package strops
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/memory"
+	"github.com/go-bullseye/bullseye/internal/arrays"
+	"github.com/go-bullseye/bullseye/iterator"
+	"github.com/pkg/errors"
+)
+
+// Upper returns the values with all letters mapped to upper case.
+func Upper(mem memory.Allocator, col *array.Column) (*array.Column, error) {
+	return mapStrings(mem, col, strings.ToUpper)
+}
+
+// Lower returns the values with all letters mapped to lower case.
+func Lower(mem memory.Allocator, col *array.Column) (*array.Column, error) {
+	return mapStrings(mem, col, strings.ToLower)
+}
+
+// Trim returns the values with all leading and trailing characters in cutset removed.
+func Trim(mem memory.Allocator, col *array.Column, cutset string) (*array.Column, error) {
+	return mapStrings(mem, col, func(s string) string {
+		return strings.Trim(s, cutset)
+	})
+}
+
+// TrimSpace returns the values with all leading and trailing white space removed.
+func TrimSpace(mem memory.Allocator, col *array.Column) (*array.Column, error) {
+	return mapStrings(mem, col, strings.TrimSpace)
+}
+
+// Replace returns the values with the first n non-overlapping instances of old replaced by new.
+// If n < 0, all of the instances are replaced.
+func Replace(mem memory.Allocator, col *array.Column, old, new string, n int) (*array.Column, error) {
+	return mapStrings(mem, col, func(s string) string {
+		return strings.Replace(s, old, new, n)
+	})
+}
+
+// Substring returns length characters of the values starting at the character start.
+// A negative start counts from the end of the value and a negative length takes the
+// rest of the value. The substring is cut short at the end of the value.
+func Substring(mem memory.Allocator, col *array.Column, start, length int) (*array.Column, error) {
+	return mapStrings(mem, col, func(s string) string {
+		runes := []rune(s)
+		beg := start
+		if beg < 0 {
+			beg += len(runes)
+			if beg < 0 {
+				beg = 0
+			}
+		}
+		if beg > len(runes) {
+			return ""
+		}
+		end := len(runes)
+		if length >= 0 && length < end-beg {
+			end = beg + length
+		}
+		return string(runes[beg:end])
+	})
+}
+
+// PadSide is the side of the values Pad adds characters to.
+type PadSide int
+
+const (
+	// PadLeft adds the characters before the value, aligning it to the right.
+	PadLeft PadSide = iota
+	// PadRight adds the characters after the value, aligning it to the left.
+	PadRight
+	// PadBoth adds the characters on both sides of the value, centering it.
+	// When an odd number of characters is added the extra one goes after the value.
+	PadBoth
+)
+
+// Pad returns the values padded with fill to be at least width characters long.
+// Values that are already at least width characters long are left as they are.
+func Pad(mem memory.Allocator, col *array.Column, width int, side PadSide, fill rune) (*array.Column, error) {
+	if side < PadLeft || side > PadBoth {
+		return nil, errors.Errorf("bullseye/strops: invalid pad side %d", side)
+	}
+	return mapStrings(mem, col, func(s string) string {
+		n := width - utf8.RuneCountInString(s)
+		if n <= 0 {
+			return s
+		}
+		switch side {
+		case PadLeft:
+			return strings.Repeat(string(fill), n) + s
+		case PadRight:
+			return s + strings.Repeat(string(fill), n)
+		default:
+			return strings.Repeat(string(fill), n/2) + s + strings.Repeat(string(fill), n-n/2)
+		}
+	})
+}
+
+// Contains returns a Boolean column reporting whether substr is within each value.
+func Contains(mem memory.Allocator, col *array.Column, substr string) (*array.Column, error) {
+	return mapBooleans(mem, col, func(s string) bool {
+		return strings.Contains(s, substr)
+	})
+}
+
+// HasPrefix returns a Boolean column reporting whether each value begins with prefix.
+func HasPrefix(mem memory.Allocator, col *array.Column, prefix string) (*array.Column, error) {
+	return mapBooleans(mem, col, func(s string) bool {
+		return strings.HasPrefix(s, prefix)
+	})
+}
+
+// HasSuffix returns a Boolean column reporting whether each value ends with suffix.
+func HasSuffix(mem memory.Allocator, col *array.Column, suffix string) (*array.Column, error) {
+	return mapBooleans(mem, col, func(s string) bool {
+		return strings.HasSuffix(s, suffix)
+	})
+}
+
+// Len returns an Int64 column with the number of characters (runes) of each value.
+func Len(mem memory.Allocator, col *array.Column) (*array.Column, error) {
+	builder := array.NewInt64Builder(mem)
+	defer builder.Release()
+
+	err := each(col, func(s string, valid bool) {
+		if !valid {
+			builder.AppendNull()
+			return
+		}
+		builder.Append(int64(utf8.RuneCountInString(s)))
+	})
+	if err != nil {
+		return nil, err
+	}
+	return arrays.NewColumn(col, arrow.PrimitiveTypes.Int64, builder), nil
+}
+
+// Split returns a List column with the values split into all of the substrings separated by sep.
+// The splitting follows strings.Split.
+func Split(mem memory.Allocator, col *array.Column, sep string) (*array.Column, error) {
+	builder := array.NewListBuilder(mem, arrow.BinaryTypes.String)
+	defer builder.Release()
+	valueBuilder := builder.ValueBuilder().(*array.StringBuilder)
+
+	err := each(col, func(s string, valid bool) {
+		if !valid {
+			builder.AppendNull()
+			return
+		}
+		builder.Append(true)
+		for _, part := range strings.Split(s, sep) {
+			valueBuilder.Append(part)
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+	return arrays.NewColumn(col, arrow.ListOf(arrow.BinaryTypes.String), builder), nil
+}
+
+// Concat returns a column named name with the values of the columns at each row joined by sep.
+// The columns must have the same length. The result is null if any of the values is null.
+func Concat(mem memory.Allocator, name, sep string, cols ...*array.Column) (*array.Column, error) {
+	if len(cols) == 0 {
+		return nil, errors.New("bullseye/strops: no columns to concatenate")
+	}
+	iterators := make([]*iterator.StringValueIterator, len(cols))
+	defer func() {
+		for _, it := range iterators {
+			if it != nil {
+				it.Release()
+			}
+		}
+	}()
+	for i, col := range cols {
+		if err := checkString(col); err != nil {
+			return nil, err
+		}
+		if col.Len() != cols[0].Len() {
+			return nil, errors.Errorf("bullseye/strops: column %s has %d values, column %s has %d", col.Name(), col.Len(), cols[0].Name(), cols[0].Len())
+		}
+		iterators[i] = iterator.NewStringValueIterator(col)
+	}
+
+	builder := array.NewStringBuilder(mem)
+	defer builder.Release()
+
+	var b strings.Builder
+	values := make([]string, len(cols))
+	for iterators[0].Next() {
+		valid := true
+		for i, it := range iterators {
+			if i > 0 {
+				it.Next()
+			}
+			v, isNull := it.Value()
+			valid = valid && !isNull
+			values[i] = v
+		}
+		if !valid {
+			builder.AppendNull()
+			continue
+		}
+		b.Reset()
+		for i, v := range values {
+			if i > 0 {
+				b.WriteString(sep)
+			}
+			b.WriteString(v)
+		}
+		builder.Append(b.String())
+	}
+
+	arr := builder.NewArray()
+	defer arr.Release()
+	field := arrow.Field{Name: name, Type: arrow.BinaryTypes.String}
+	for _, col := range cols {
+		field.Nullable = field.Nullable || col.Field().Nullable
+	}
+	chunked := array.NewChunked(field.Type, []array.Interface{arr})
+	defer chunked.Release()
+	return array.NewColumn(field, chunked), nil
+}
+
+// mapStrings returns a column with fn applied to each value.
+func mapStrings(mem memory.Allocator, col *array.Column, fn func(string) string) (*array.Column, error) {
+	builder := array.NewStringBuilder(mem)
+	defer builder.Release()
+
+	err := each(col, func(s string, valid bool) {
+		if !valid {
+			builder.AppendNull()
+			return
+		}
+		builder.Append(fn(s))
+	})
+	if err != nil {
+		return nil, err
+	}
+	return arrays.NewColumn(col, arrow.BinaryTypes.String, builder), nil
+}
+
+// mapBooleans returns a Boolean column with fn applied to each value.
+func mapBooleans(mem memory.Allocator, col *array.Column, fn func(string) bool) (*array.Column, error) {
+	builder := array.NewBooleanBuilder(mem)
+	defer builder.Release()
+
+	err := each(col, func(s string, valid bool) {
+		if !valid {
+			builder.AppendNull()
+			return
+		}
+		builder.Append(fn(s))
+	})
+	if err != nil {
+		return nil, err
+	}
+	return arrays.NewColumn(col, arrow.FixedWidthTypes.Boolean, builder), nil
+}
+
+// each calls fn with every value of a string column and whether it is not null.
+func each(col *array.Column, fn func(s string, valid bool)) error {
+	if err := checkString(col); err != nil {
+		return err
+	}
+
+	it := iterator.NewStringValueIterator(col)
+	defer it.Release()
+	for it.Next() {
+		s, isNull := it.Value()
+		fn(s, !isNull)
+	}
+	return nil
+}
+
+func checkString(col *array.Column) error {
+	if col == nil {
+		return errors.New("bullseye/strops: column is nil")
+	}
+	if col.DataType().ID() != arrow.STRING {
+		return errors.Errorf("bullseye/strops: column %s of type %v is not a string column", col.Name(), col.DataType())
+	}
+	return nil
+}
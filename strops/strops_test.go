@@ -0,0 +1,135 @@
+package strops
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/memory"
+)
+
+// newStringColumn creates a column from two chunks of values, where nil values are null.
+func newStringColumn(mem memory.Allocator, name string, values ...*string) *array.Column {
+	field := arrow.Field{Name: name, Type: arrow.BinaryTypes.String, Nullable: true}
+	chunks := make([]array.Interface, 0, 2)
+	for _, part := range [][]*string{values[:len(values)/2], values[len(values)/2:]} {
+		builder := array.NewStringBuilder(mem)
+		for _, v := range part {
+			if v == nil {
+				builder.AppendNull()
+				continue
+			}
+			builder.Append(*v)
+		}
+		chunks = append(chunks, builder.NewArray())
+		builder.Release()
+	}
+	chunked := array.NewChunked(field.Type, chunks)
+	defer chunked.Release()
+	for _, chunk := range chunks {
+		chunk.Release()
+	}
+	return array.NewColumn(field, chunked)
+}
+
+func str(s string) *string { return &s }
+
+func TestStringOps(t *testing.T) {
+	pool := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer pool.AssertSize(t, 0)
+
+	col := newStringColumn(pool, "A", str("  Hello "), nil, str("wörld"), str("a,b,,c"))
+	defer col.Release()
+
+	tests := []struct {
+		name string
+		fn   func() (*array.Column, error)
+		want string
+	}{
+		{"upper", func() (*array.Column, error) { return Upper(pool, col) }, `["  HELLO " (null) "WÖRLD" "A,B,,C"]`},
+		{"lower", func() (*array.Column, error) { return Lower(pool, col) }, `["  hello " (null) "wörld" "a,b,,c"]`},
+		{"trim", func() (*array.Column, error) { return Trim(pool, col, " a") }, `["Hello" (null) "wörld" ",b,,c"]`},
+		{"trim space", func() (*array.Column, error) { return TrimSpace(pool, col) }, `["Hello" (null) "wörld" "a,b,,c"]`},
+		{"replace", func() (*array.Column, error) { return Replace(pool, col, "l", "L", 1) }, `["  HeLlo " (null) "wörLd" "a,b,,c"]`},
+		{"substring", func() (*array.Column, error) { return Substring(pool, col, 1, 3) }, `[" He" (null) "örl" ",b,"]`},
+		{"substring from end", func() (*array.Column, error) { return Substring(pool, col, -2, -1) }, `["o " (null) "ld" ",c"]`},
+		{"substring of max length", func() (*array.Column, error) { return Substring(pool, col, 1, math.MaxInt64) }, `[" Hello " (null) "örld" ",b,,c"]`},
+		{"pad left", func() (*array.Column, error) { return Pad(pool, col, 7, PadLeft, '*') }, `["  Hello " (null) "**wörld" "*a,b,,c"]`},
+		{"pad both", func() (*array.Column, error) { return Pad(pool, col, 8, PadBoth, '.') }, `["  Hello " (null) ".wörld.." ".a,b,,c."]`},
+		{"contains", func() (*array.Column, error) { return Contains(pool, col, "ll") }, `[true (null) false false]`},
+		{"has prefix", func() (*array.Column, error) { return HasPrefix(pool, col, "a,") }, `[false (null) false true]`},
+		{"has suffix", func() (*array.Column, error) { return HasSuffix(pool, col, "rld") }, `[false (null) true false]`},
+		{"len", func() (*array.Column, error) { return Len(pool, col) }, `[8 (null) 5 6]`},
+		{"split", func() (*array.Column, error) { return Split(pool, col, ",") }, `[["  Hello "] (null) ["wörld"] ["a" "b" "" "c"]]`},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := tc.fn()
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer result.Release()
+
+			if result.Name() != "A" {
+				t.Fatalf("got name=%s, want=A", result.Name())
+			}
+			if got := result.Data().Chunk(0).(fmt.Stringer).String(); got != tc.want {
+				t.Fatalf("\ngot=\n%v\nwant=\n%v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestConcat(t *testing.T) {
+	pool := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer pool.AssertSize(t, 0)
+
+	first := newStringColumn(pool, "first", str("ada"), str("alan"), nil, str("grace"))
+	defer first.Release()
+	last := newStringColumn(pool, "last", str("lovelace"), str("turing"), str("knuth"), nil)
+	defer last.Release()
+
+	result, err := Concat(pool, "name", " ", first, last)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer result.Release()
+
+	want := `["ada lovelace" "alan turing" (null) (null)]`
+	if got := result.Data().Chunk(0).(fmt.Stringer).String(); got != want {
+		t.Fatalf("\ngot=\n%v\nwant=\n%v", got, want)
+	}
+	if result.Name() != "name" {
+		t.Fatalf("got name=%s, want=name", result.Name())
+	}
+}
+
+func TestErrors(t *testing.T) {
+	pool := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer pool.AssertSize(t, 0)
+
+	builder := array.NewInt64Builder(pool)
+	builder.AppendValues([]int64{1, 2}, nil)
+	arr := builder.NewArray()
+	builder.Release()
+	chunked := array.NewChunked(arr.DataType(), []array.Interface{arr})
+	arr.Release()
+	ints := array.NewColumn(arrow.Field{Name: "I", Type: arr.DataType()}, chunked)
+	chunked.Release()
+	defer ints.Release()
+
+	if _, err := Upper(pool, ints); err == nil || err.Error() != "bullseye/strops: column I of type int64 is not a string column" {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	col := newStringColumn(pool, "A", str("a"))
+	defer col.Release()
+	other := newStringColumn(pool, "B", str("a"), str("b"))
+	defer other.Release()
+	if _, err := Concat(pool, "C", "", col, other); err == nil || err.Error() != "bullseye/strops: column B has 2 values, column A has 1" {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
@@ -0,0 +1,11 @@
+/*
+Package timeops provides vectorized date and time operations on Date32, Date64 and Timestamp columns.
+
+Dates are calendar days in UTC. Timestamps are instants and their components are read in the
+time zone of the TimestampType, or in UTC when it doesn't have one, so that a day or a month
+starts at midnight local time. Each operation builds a new single chunk Column with the same
+name, where a null value produces a null result. The new Column must be released and can be
+added to a DataFrame with AppendColumn.
+
+*/
+package timeops
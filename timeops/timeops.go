@@ -0,0 +1,411 @@
+package timeops
+
+import (
+	"fmt"
+	"math"
+	"math/bits"
+	"time"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/memory"
+	"github.com/go-bullseye/bullseye/internal/arrays"
+	"github.com/go-bullseye/bullseye/internal/constructors"
+	"github.com/go-bullseye/bullseye/internal/temporal"
+	"github.com/pkg/errors"
+)
+
+// Component is a part of a date or time that Extract can read.
+type Component int
+
+const (
+	// Year is the year, e.g. 2019.
+	Year Component = iota
+	// Quarter is the quarter of the year, from 1 to 4.
+	Quarter
+	// Month is the month of the year, from 1 to 12.
+	Month
+	// Day is the day of the month, from 1 to 31.
+	Day
+	// Weekday is the day of the week, from 0 for Sunday to 6 for Saturday like time.Weekday.
+	Weekday
+	// DayOfYear is the day of the year, from 1 to 366.
+	DayOfYear
+	// Hour is the hour of the day, from 0 to 23.
+	Hour
+	// Minute is the minute of the hour, from 0 to 59.
+	Minute
+	// Second is the second of the minute, from 0 to 59.
+	Second
+)
+
+func (c Component) String() string {
+	switch c {
+	case Year:
+		return "year"
+	case Quarter:
+		return "quarter"
+	case Month:
+		return "month"
+	case Day:
+		return "day"
+	case Weekday:
+		return "weekday"
+	case DayOfYear:
+		return "day_of_year"
+	case Hour:
+		return "hour"
+	case Minute:
+		return "minute"
+	case Second:
+		return "second"
+	default:
+		return fmt.Sprintf("Component(%d)", int(c))
+	}
+}
+
+// Extract returns an Int32 column with the component of each value.
+func Extract(mem memory.Allocator, col *array.Column, component Component) (*array.Column, error) {
+	if component < Year || component > Second {
+		return nil, errors.Errorf("bullseye/timeops: invalid component %v", component)
+	}
+
+	builder := array.NewInt32Builder(mem)
+	defer builder.Release()
+
+	err := each(col, func(t time.Time, valid bool) {
+		if !valid {
+			builder.AppendNull()
+			return
+		}
+		var v int
+		switch component {
+		case Year:
+			v = t.Year()
+		case Quarter:
+			v = (int(t.Month())-1)/3 + 1
+		case Month:
+			v = int(t.Month())
+		case Day:
+			v = t.Day()
+		case Weekday:
+			v = int(t.Weekday())
+		case DayOfYear:
+			v = t.YearDay()
+		case Hour:
+			v = t.Hour()
+		case Minute:
+			v = t.Minute()
+		case Second:
+			v = t.Second()
+		}
+		builder.Append(int32(v))
+	})
+	if err != nil {
+		return nil, err
+	}
+	return arrays.NewColumn(col, arrow.PrimitiveTypes.Int32, builder), nil
+}
+
+// Unit is a calendar unit that Truncate can round down to.
+type Unit int
+
+const (
+	// UnitHour truncates to the start of the hour.
+	UnitHour Unit = iota
+	// UnitDay truncates to midnight.
+	UnitDay
+	// UnitWeek truncates to midnight of the Monday of the week, following ISO 8601.
+	UnitWeek
+	// UnitMonth truncates to midnight of the first day of the month.
+	UnitMonth
+	// UnitYear truncates to midnight of the first day of the year.
+	UnitYear
+)
+
+func (u Unit) String() string {
+	switch u {
+	case UnitHour:
+		return "hour"
+	case UnitDay:
+		return "day"
+	case UnitWeek:
+		return "week"
+	case UnitMonth:
+		return "month"
+	case UnitYear:
+		return "year"
+	default:
+		return fmt.Sprintf("Unit(%d)", int(u))
+	}
+}
+
+// Truncate returns a column of the same type with each value rounded down to the start of the unit.
+func Truncate(mem memory.Allocator, col *array.Column, unit Unit) (*array.Column, error) {
+	if unit < UnitHour || unit > UnitYear {
+		return nil, errors.Errorf("bullseye/timeops: invalid unit %v", unit)
+	}
+	return mapTimes(mem, col, func(t time.Time) time.Time {
+		return TruncateTime(t, unit)
+	})
+}
+
+// TruncateTime rounds t down to the start of the unit in the location of t.
+func TruncateTime(t time.Time, unit Unit) time.Time {
+	y, m, d := t.Date()
+	switch unit {
+	case UnitHour:
+		return time.Date(y, m, d, t.Hour(), 0, 0, 0, t.Location())
+	case UnitWeek:
+		// Weekday counts from Sunday, weeks start on Monday.
+		offset := (int(t.Weekday()) + 6) % 7
+		return time.Date(y, m, d-offset, 0, 0, 0, 0, t.Location())
+	case UnitMonth:
+		return time.Date(y, m, 1, 0, 0, 0, 0, t.Location())
+	case UnitYear:
+		return time.Date(y, time.January, 1, 0, 0, 0, 0, t.Location())
+	default:
+		return time.Date(y, m, d, 0, 0, 0, 0, t.Location())
+	}
+}
+
+// Add returns a column of the same type with the duration added to each value.
+// The results for dates are truncated to midnight, so a duration shorter than a day may not change them.
+// A result the type can't hold is an error.
+func Add(mem memory.Allocator, col *array.Column, d time.Duration) (*array.Column, error) {
+	return mapTimes(mem, col, func(t time.Time) time.Time {
+		return t.Add(d)
+	})
+}
+
+// AddMonths returns a column of the same type with a number of calendar months added to each value.
+// The day of the month is kept, or is the last day of the month when the month is too short,
+// so adding one month to January 31st gives the last day of February.
+func AddMonths(mem memory.Allocator, col *array.Column, months int) (*array.Column, error) {
+	return mapTimes(mem, col, func(t time.Time) time.Time {
+		return AddMonthsTime(t, months)
+	})
+}
+
+// AddMonthsTime adds a number of calendar months to t like AddMonths.
+func AddMonthsTime(t time.Time, months int) time.Time {
+	y, m, d := t.Date()
+	first := time.Date(y, m+time.Month(months), 1, t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), t.Location())
+	if last := first.AddDate(0, 1, -1).Day(); d > last {
+		d = last
+	}
+	return first.AddDate(0, 0, d-1)
+}
+
+// Sub returns an Int64 column with the difference between the values of a and b, a - b,
+// as a number of units, truncated toward zero. Use 24 * time.Hour for a number of days.
+// The columns can have different temporal types but must have the same length.
+// A difference that doesn't fit in an int64 is an error.
+func Sub(mem memory.Allocator, a, b *array.Column, unit time.Duration) (*array.Column, error) {
+	if unit <= 0 {
+		return nil, errors.Errorf("bullseye/timeops: unit must be positive: %v", unit)
+	}
+	if err := checkTemporal(a); err != nil {
+		return nil, err
+	}
+	if err := checkTemporal(b); err != nil {
+		return nil, err
+	}
+	if a.Len() != b.Len() {
+		return nil, errors.Errorf("bullseye/timeops: column %s has %d values, column %s has %d", a.Name(), a.Len(), b.Name(), b.Len())
+	}
+
+	// Read the values of b so they can be paired with the values of a.
+	type value struct {
+		t     time.Time
+		valid bool
+	}
+	values := make([]value, 0, b.Len())
+	err := each(b, func(t time.Time, valid bool) {
+		values = append(values, value{t, valid})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	builder := array.NewInt64Builder(mem)
+	defer builder.Release()
+
+	var rangeErr error
+	i := 0
+	err = each(a, func(t time.Time, valid bool) {
+		other := values[i]
+		i++
+		if rangeErr != nil {
+			return
+		}
+		if !valid || !other.valid {
+			builder.AppendNull()
+			return
+		}
+		v, ok := subUnits(t, other.t, unit)
+		if !ok {
+			rangeErr = errors.Errorf("bullseye/timeops: the difference between %v and %v at row %d is too large for an int64 number of %v", t, other.t, i-1, unit)
+			return
+		}
+		builder.Append(v)
+	})
+	if err != nil {
+		return nil, err
+	}
+	if rangeErr != nil {
+		return nil, rangeErr
+	}
+	return arrays.NewColumn(a, arrow.PrimitiveTypes.Int64, builder), nil
+}
+
+// subUnits returns a - b as a number of units, truncated toward zero.
+// Unlike a.Sub(b), it doesn't saturate past 292 years; it returns false when the result doesn't fit in an int64.
+func subUnits(a, b time.Time, unit time.Duration) (int64, bool) {
+	as, bs := a.Unix(), b.Unix()
+	if (bs < 0 && as > math.MaxInt64+bs) || (bs > 0 && as < math.MinInt64+bs) {
+		return 0, false
+	}
+	sec, nsec := as-bs, int64(a.Nanosecond()-b.Nanosecond())
+	// Give both parts the same sign so the magnitude is |sec| seconds plus |nsec| nanoseconds.
+	if sec > 0 && nsec < 0 {
+		sec, nsec = sec-1, nsec+int64(time.Second)
+	} else if sec < 0 && nsec > 0 {
+		sec, nsec = sec+1, nsec-int64(time.Second)
+	}
+	neg := sec < 0 || nsec < 0
+	if neg {
+		sec, nsec = -sec, -nsec
+	}
+
+	// The magnitude in nanoseconds can take up to 94 bits.
+	hi, lo := bits.Mul64(uint64(sec), uint64(time.Second))
+	lo, carry := bits.Add64(lo, uint64(nsec), 0)
+	hi += carry
+	if hi >= uint64(unit) {
+		return 0, false
+	}
+	q, _ := bits.Div64(hi, lo, uint64(unit))
+	if neg {
+		if q > 1<<63 {
+			return 0, false
+		}
+		return int64(-q), true
+	}
+	if q > math.MaxInt64 {
+		return 0, false
+	}
+	return int64(q), true
+}
+
+// Location returns the location the values of a column of type dtype are read in:
+// the time zone of a TimestampType, or UTC for dates and timestamps without one.
+func Location(dtype arrow.DataType) (*time.Location, error) {
+	ts, ok := dtype.(*arrow.TimestampType)
+	if !ok || ts.TimeZone == "" {
+		return time.UTC, nil
+	}
+	loc, err := time.LoadLocation(ts.TimeZone)
+	if err != nil {
+		return nil, errors.Wrapf(err, "bullseye/timeops: invalid time zone %q", ts.TimeZone)
+	}
+	return loc, nil
+}
+
+// each calls fn with every value of a temporal column as a time in its location and whether it is not null.
+func each(col *array.Column, fn func(t time.Time, valid bool)) error {
+	if err := checkTemporal(col); err != nil {
+		return err
+	}
+	loc, err := Location(col.DataType())
+	if err != nil {
+		return err
+	}
+
+	for _, chunk := range col.Data().Chunks() {
+		for i := 0; i < chunk.Len(); i++ {
+			if chunk.IsNull(i) {
+				fn(time.Time{}, false)
+				continue
+			}
+			var t time.Time
+			switch c := chunk.(type) {
+			case *array.Date32:
+				t = time.Unix(int64(c.Value(i))*temporal.SecondsPerDay, 0)
+			case *array.Date64:
+				t = temporal.Time(int64(c.Value(i)), arrow.Millisecond)
+			case *array.Timestamp:
+				t = temporal.Time(int64(c.Value(i)), col.DataType().(*arrow.TimestampType).Unit)
+			}
+			fn(t.In(loc), true)
+		}
+	}
+	return nil
+}
+
+// mapTimes returns a column of the same type with fn applied to each value.
+// It returns an error when a result doesn't fit in the type.
+func mapTimes(mem memory.Allocator, col *array.Column, fn func(time.Time) time.Time) (*array.Column, error) {
+	if err := checkTemporal(col); err != nil {
+		return nil, err
+	}
+	dtype := col.DataType()
+	builder, err := constructors.NewBuilder(mem, dtype)
+	if err != nil {
+		return nil, err
+	}
+	defer builder.Release()
+
+	var rangeErr error
+	row := 0
+	err = each(col, func(t time.Time, valid bool) {
+		row++
+		if rangeErr != nil {
+			return
+		}
+		if !valid {
+			builder.AppendNull()
+			return
+		}
+		t = fn(t)
+		ok := false
+		switch b := builder.(type) {
+		case *array.Date32Builder:
+			var v arrow.Date32
+			if v, ok = temporal.Date32(t); ok {
+				b.Append(v)
+			}
+		case *array.Date64Builder:
+			var v arrow.Date64
+			if v, ok = temporal.Date64(t); ok {
+				b.Append(v)
+			}
+		case *array.TimestampBuilder:
+			var v int64
+			if v, ok = temporal.FromTime(t, dtype.(*arrow.TimestampType).Unit); ok {
+				b.Append(arrow.Timestamp(v))
+			}
+		}
+		if !ok {
+			rangeErr = errors.Errorf("bullseye/timeops: the result %v for row %d of column %s is out of the range of %v", t, row-1, col.Name(), dtype)
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+	if rangeErr != nil {
+		return nil, rangeErr
+	}
+	return arrays.NewColumn(col, dtype, builder), nil
+}
+
+func checkTemporal(col *array.Column) error {
+	if col == nil {
+		return errors.New("bullseye/timeops: column is nil")
+	}
+	switch col.DataType().ID() {
+	case arrow.DATE32, arrow.DATE64, arrow.TIMESTAMP:
+		return nil
+	}
+	return errors.Errorf("bullseye/timeops: column %s of type %v is not a date or timestamp column", col.Name(), col.DataType())
+}
@@ -0,0 +1,296 @@
+package timeops
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/memory"
+	"github.com/go-bullseye/bullseye/internal/constructors"
+	"github.com/go-bullseye/bullseye/internal/temporal"
+)
+
+// newTimeColumn creates a column of type dtype from RFC 3339 values, where empty values are null.
+func newTimeColumn(t *testing.T, mem memory.Allocator, name string, dtype arrow.DataType, values ...string) *array.Column {
+	t.Helper()
+	builder, err := constructors.NewBuilder(mem, dtype)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer builder.Release()
+
+	for _, v := range values {
+		if v == "" {
+			builder.AppendNull()
+			continue
+		}
+		tm, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			t.Fatal(err)
+		}
+		switch b := builder.(type) {
+		case *array.Date32Builder:
+			b.Append(arrow.Date32(temporal.FloorDiv(tm.Unix(), temporal.SecondsPerDay)))
+		case *array.Date64Builder:
+			b.Append(arrow.Date64(temporal.FloorDiv(tm.Unix(), temporal.SecondsPerDay) * temporal.MillisecondsPerDay))
+		case *array.TimestampBuilder:
+			b.Append(arrow.Timestamp(tm.Unix()))
+		}
+	}
+
+	arr := builder.NewArray()
+	defer arr.Release()
+	chunked := array.NewChunked(dtype, []array.Interface{arr})
+	defer chunked.Release()
+	return array.NewColumn(arrow.Field{Name: name, Type: dtype, Nullable: true}, chunked)
+}
+
+// formatTimes formats the values of a temporal column in RFC 3339.
+func formatTimes(t *testing.T, col *array.Column) string {
+	t.Helper()
+	var parts []string
+	err := each(col, func(tm time.Time, valid bool) {
+		if !valid {
+			parts = append(parts, "(null)")
+			return
+		}
+		parts = append(parts, tm.Format(time.RFC3339))
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return "[" + strings.Join(parts, " ") + "]"
+}
+
+func TestExtract(t *testing.T) {
+	pool := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer pool.AssertSize(t, 0)
+
+	dates := newTimeColumn(t, pool, "D", arrow.FixedWidthTypes.Date32, "2019-03-31T00:00:00Z", "", "1969-12-31T00:00:00Z")
+	defer dates.Release()
+	// 03:30 UTC is still the previous day in New York.
+	stamps := newTimeColumn(t, pool, "T", &arrow.TimestampType{Unit: arrow.Second, TimeZone: "America/New_York"}, "2019-07-01T03:30:15Z")
+	defer stamps.Release()
+
+	tests := []struct {
+		col       *array.Column
+		component Component
+		want      string
+	}{
+		{dates, Year, "[2019 (null) 1969]"},
+		{dates, Quarter, "[1 (null) 4]"},
+		{dates, Month, "[3 (null) 12]"},
+		{dates, Day, "[31 (null) 31]"},
+		{dates, Weekday, "[0 (null) 3]"},
+		{dates, DayOfYear, "[90 (null) 365]"},
+		{stamps, Day, "[30]"},
+		{stamps, Hour, "[23]"},
+		{stamps, Minute, "[30]"},
+		{stamps, Second, "[15]"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.col.Name()+"_"+tc.component.String(), func(t *testing.T) {
+			result, err := Extract(pool, tc.col, tc.component)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer result.Release()
+
+			if got := result.Data().Chunk(0).(fmt.Stringer).String(); got != tc.want {
+				t.Fatalf("got=%v, want=%v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestTruncate(t *testing.T) {
+	pool := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer pool.AssertSize(t, 0)
+
+	dates := newTimeColumn(t, pool, "D", arrow.FixedWidthTypes.Date64, "2019-03-31T00:00:00Z", "", "2019-04-03T00:00:00Z")
+	defer dates.Release()
+	stamps := newTimeColumn(t, pool, "T", &arrow.TimestampType{Unit: arrow.Second, TimeZone: "America/New_York"}, "2019-07-01T03:30:15Z")
+	defer stamps.Release()
+
+	tests := []struct {
+		col  *array.Column
+		unit Unit
+		want string
+	}{
+		{dates, UnitWeek, "[2019-03-25T00:00:00Z (null) 2019-04-01T00:00:00Z]"},
+		{dates, UnitMonth, "[2019-03-01T00:00:00Z (null) 2019-04-01T00:00:00Z]"},
+		{dates, UnitYear, "[2019-01-01T00:00:00Z (null) 2019-01-01T00:00:00Z]"},
+		{stamps, UnitHour, "[2019-06-30T23:00:00-04:00]"},
+		{stamps, UnitDay, "[2019-06-30T00:00:00-04:00]"},
+		{stamps, UnitMonth, "[2019-06-01T00:00:00-04:00]"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.col.Name()+"_"+tc.unit.String(), func(t *testing.T) {
+			result, err := Truncate(pool, tc.col, tc.unit)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer result.Release()
+
+			if got := formatTimes(t, result); got != tc.want {
+				t.Fatalf("got=%v, want=%v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestAdd(t *testing.T) {
+	pool := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer pool.AssertSize(t, 0)
+
+	dates := newTimeColumn(t, pool, "D", arrow.FixedWidthTypes.Date32, "2019-01-31T00:00:00Z", "", "2020-01-31T00:00:00Z", "2019-03-15T00:00:00Z")
+	defer dates.Release()
+	stamps := newTimeColumn(t, pool, "T", &arrow.TimestampType{Unit: arrow.Second}, "2019-01-31T23:00:00Z")
+	defer stamps.Release()
+
+	result, err := AddMonths(pool, dates, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer result.Release()
+	if got, want := formatTimes(t, result), "[2019-02-28T00:00:00Z (null) 2020-02-29T00:00:00Z 2019-04-15T00:00:00Z]"; got != want {
+		t.Fatalf("got=%v, want=%v", got, want)
+	}
+
+	result2, err := AddMonths(pool, dates, -2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer result2.Release()
+	if got, want := formatTimes(t, result2), "[2018-11-30T00:00:00Z (null) 2019-11-30T00:00:00Z 2019-01-15T00:00:00Z]"; got != want {
+		t.Fatalf("got=%v, want=%v", got, want)
+	}
+
+	result3, err := Add(pool, stamps, 2*time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer result3.Release()
+	if got, want := formatTimes(t, result3), "[2019-02-01T01:00:00Z]"; got != want {
+		t.Fatalf("got=%v, want=%v", got, want)
+	}
+
+	result4, err := Add(pool, dates, 36*time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer result4.Release()
+	if got, want := formatTimes(t, result4), "[2019-02-01T00:00:00Z (null) 2020-02-01T00:00:00Z 2019-03-16T00:00:00Z]"; got != want {
+		t.Fatalf("got=%v, want=%v", got, want)
+	}
+}
+
+func TestSub(t *testing.T) {
+	pool := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer pool.AssertSize(t, 0)
+
+	a := newTimeColumn(t, pool, "A", arrow.FixedWidthTypes.Date32, "2019-03-10T00:00:00Z", "2019-01-01T00:00:00Z", "")
+	defer a.Release()
+	b := newTimeColumn(t, pool, "B", &arrow.TimestampType{Unit: arrow.Second}, "2019-03-01T12:00:00Z", "2019-01-03T00:00:00Z", "2019-01-01T00:00:00Z")
+	defer b.Release()
+
+	result, err := Sub(pool, a, b, 24*time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer result.Release()
+
+	if got, want := result.Data().Chunk(0).(fmt.Stringer).String(), "[8 -2 (null)]"; got != want {
+		t.Fatalf("got=%v, want=%v", got, want)
+	}
+}
+
+func TestOutOfNanoseconds(t *testing.T) {
+	pool := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer pool.AssertSize(t, 0)
+
+	// Nanosecond times only reach from 1678 to 2262.
+	dates := newTimeColumn(t, pool, "D", arrow.FixedWidthTypes.Date64, "3000-05-20T00:00:00Z", "1000-06-15T00:00:00Z")
+	defer dates.Release()
+	stamps := newTimeColumn(t, pool, "T", &arrow.TimestampType{Unit: arrow.Second}, "1000-01-01T12:00:00Z", "3000-01-01T00:00:00Z")
+	defer stamps.Release()
+
+	years, err := Extract(pool, dates, Year)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer years.Release()
+	if got, want := years.Data().Chunk(0).(fmt.Stringer).String(), "[3000 1000]"; got != want {
+		t.Fatalf("got=%v, want=%v", got, want)
+	}
+
+	months, err := Truncate(pool, dates, UnitMonth)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer months.Release()
+	if got, want := formatTimes(t, months), "[3000-05-01T00:00:00Z 1000-06-01T00:00:00Z]"; got != want {
+		t.Fatalf("got=%v, want=%v", got, want)
+	}
+
+	days, err := Sub(pool, dates, stamps, 24*time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer days.Release()
+	if got, want := days.Data().Chunk(0).(fmt.Stringer).String(), "[730623 -730320]"; got != want {
+		t.Fatalf("got=%v, want=%v", got, want)
+	}
+
+	_, err = Sub(pool, dates, stamps, time.Nanosecond)
+	if want := "bullseye/timeops: the difference between 3000-05-20 00:00:00 +0000 UTC and 1000-01-01 12:00:00 +0000 UTC at row 0 is too large for an int64 number of 1ns"; err == nil || err.Error() != want {
+		t.Fatalf("got=%v, want=%v", err, want)
+	}
+
+	b := array.NewDate32Builder(pool)
+	b.AppendNull()
+	b.Append(math.MaxInt32)
+	arr := b.NewArray()
+	b.Release()
+	chunked := array.NewChunked(arr.DataType(), []array.Interface{arr})
+	arr.Release()
+	last := array.NewColumn(arrow.Field{Name: "L", Type: arr.DataType(), Nullable: true}, chunked)
+	chunked.Release()
+	defer last.Release()
+
+	_, err = Add(pool, last, 24*time.Hour)
+	if want := "bullseye/timeops: the result 5881580-07-12 00:00:00 +0000 UTC for row 1 of column L is out of the range of date32"; err == nil || err.Error() != want {
+		t.Fatalf("got=%v, want=%v", err, want)
+	}
+}
+
+func TestErrors(t *testing.T) {
+	pool := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer pool.AssertSize(t, 0)
+
+	builder := array.NewInt64Builder(pool)
+	builder.Append(1)
+	arr := builder.NewArray()
+	builder.Release()
+	chunked := array.NewChunked(arr.DataType(), []array.Interface{arr})
+	arr.Release()
+	ints := array.NewColumn(arrow.Field{Name: "I", Type: arr.DataType()}, chunked)
+	chunked.Release()
+	defer ints.Release()
+
+	if _, err := Extract(pool, ints, Year); err == nil || err.Error() != "bullseye/timeops: column I of type int64 is not a date or timestamp column" {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	stamps := newTimeColumn(t, pool, "T", &arrow.TimestampType{Unit: arrow.Second, TimeZone: "Nowhere/Special"}, "2019-01-01T00:00:00Z")
+	defer stamps.Release()
+	if _, err := Truncate(pool, stamps, UnitDay); err == nil || !strings.HasPrefix(err.Error(), `bullseye/timeops: invalid time zone "Nowhere/Special"`) {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}